@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/pronovic/go-apologies/source"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	registry := source.NewRegistry()
+
+	addr := flag.String("addr", ":9090", "address to listen on")
+	name := flag.String("source", "RandomInputSource", "input source to serve, one of "+strings.Join(registry.Names(), ", "))
+	flag.Parse()
+
+	input, err := registry.Lookup(*name, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	grpcServer := grpc.NewServer()
+	source.RegisterGRPCInputSource(grpcServer, input)
+
+	// shut down cleanly on SIGINT or SIGTERM, letting requests in progress finish
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+	}()
+
+	log.Printf("Serving %s on %s", input.Name(), *addr)
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatal(err)
+	}
+}
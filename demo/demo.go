@@ -14,8 +14,8 @@ import (
 
 	"github.com/pronovic/go-apologies/engine"
 	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
 	"github.com/pronovic/go-apologies/render"
-	"github.com/pronovic/go-apologies/source"
 	"github.com/rthornton128/goncurses"
 )
 
@@ -25,15 +25,21 @@ const (
 )
 
 func main() {
-	players, delay, exit, mode, cis := parseArgs()
+	players, delay, exit, mode, randomizer, input, seats := parseArgs()
+
+	display := &display{humans: seats.humans(), delay: delay}
 
 	characters := make([]engine.Character, players)
 	for player := 0; player < players; player++ {
 		name := fmt.Sprintf("Player %d", player)
-		characters[player] = engine.NewCharacter(name, cis)
+		kind, exists := seats[model.PlayerColors.Members()[player]]
+		if !exists {
+			kind = input
+		}
+		characters[player] = engine.NewCharacter(name, newSource(kind, randomizer, display))
 	}
 
-	runtime, err := engine.NewEngine(mode, characters, nil);
+	runtime, err := engine.NewEngine(mode, characters, nil, randomizer);
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -43,30 +49,46 @@ func main() {
 		log.Fatal(err)
 	}
 
+	display.runtime = runtime
+
 	forceMinimumSize()
-	cursesMain(cis, runtime, delay, exit)
+	cursesMain(display, exit)
 }
 
-func parseArgs() (int, int, bool, model.GameMode, source.CharacterInputSource) {
+func parseArgs() (int, int, bool, model.GameMode, random.Randomizer, string, seats) {
 	players := flag.Int("players", 2, "number of players")
 	delay := flag.Int("delay", 200, "delay between moves (milliseconds)")
 	adult := flag.Bool("adult", false, "run in adult mode")
-	input := flag.String("input", "random", "'random' or 'reward' for input source")
+	input := flag.String("input", "random", "default input source for seats without -seat: random, reward, hand, montecarlo or expectimax")
 	exit := flag.Bool("exit", false, "exit immediately upon completion")
+	seed := flag.Int64("seed", 0, "seed for a reproducible game (0 for a random game)")
+
+	seats := make(seats)
+	flag.Var(seats, "seat", fmt.Sprintf("input source for one seat as color=kind, where kind is one of %s (repeatable)", strings.Join(kinds, ", ")))
 
 	flag.Parse()
 
+	for color := range seats {
+		if slices.Index(model.PlayerColors.Members(), color) >= *players {
+			log.Fatalf("Seat %s is not part of a %d-player game", strings.ToLower(color.Value()), *players)
+		}
+	}
+
 	mode := model.StandardMode
 	if *adult {
 		mode = model.AdultMode
 	}
 
-	cis := source.RandomInputSource()
-	if *input == "reward" {
-		cis = source.RewardInputSource(nil, nil)
+	randomizer := random.NewRandomizer()
+	if *seed != 0 {
+		randomizer = random.NewSeededRandomizer(*seed)
+	}
+
+	if !slices.Contains(kinds, *input) || *input == "human" {
+		log.Fatalf("Unknown input source %q", *input)
 	}
 
-	return *players, *delay, *exit, mode, cis
+	return *players, *delay, *exit, mode, randomizer, *input, seats
 }
 
 // forceMinimimumSize Force an xterm to resize via a control sequence.
@@ -76,7 +98,10 @@ func forceMinimumSize() {
 }
 
 // cursesMain is the ncurses main routine
-func cursesMain(cis source.CharacterInputSource, runtime engine.Engine, delay int, exit bool) {
+func cursesMain(display *display, exit bool) {
+	runtime := display.runtime
+	delay := display.delay
+
 	stdscr, err := goncurses.Init()
 	if err != nil {
 		log.Fatal(err)
@@ -103,6 +128,11 @@ func cursesMain(cis source.CharacterInputSource, runtime engine.Engine, delay in
 		log.Fatal(err)
 	}
 
+	display.stdscr = stdscr
+	display.board = board
+	display.state = state
+	display.history = history
+
 	complete := false
 
 	interrupt := make(chan os.Signal, 1)
@@ -111,6 +141,9 @@ func cursesMain(cis source.CharacterInputSource, runtime engine.Engine, delay in
 		<-interrupt
 		complete = true
 		goncurses.End()
+		if len(display.humans) > 0 {
+			os.Exit(0) // a human's turn would otherwise keep waiting for a key
+		}
 	}()
 
 	resize := make(chan os.Signal, 1)
@@ -130,8 +163,11 @@ func cursesMain(cis source.CharacterInputSource, runtime engine.Engine, delay in
 				complete = true
 			}
 		} else {
-			game, _ := runtime.PlayNext()
-			refresh(cis, runtime, game, delay, stdscr, board, state, history)
+			game, err := runtime.PlayNext()
+			if err != nil {
+				log.Fatal(err)
+			}
+			refresh(display, game, stdscr, board, state, history)
 		}
 
 		time.Sleep(time.Duration(delay) * time.Millisecond)
@@ -171,10 +207,8 @@ func draw(
 }
 
 func refresh(
-	cis source.CharacterInputSource,
-	runtime engine.Engine,
+	display *display,
 	game model.Game,
-	delay int,
 	stdscr *goncurses.Window,
 	board *goncurses.Window,
 	state *goncurses.Window,
@@ -182,7 +216,8 @@ func refresh(
 ) {
 	refreshScreen(stdscr)
 	refreshBoard(game, board)
-	refreshState(cis, runtime, game, delay, state)
+	players, visible := display.perspective(game)
+	refreshState(display.runtime, players, display.delay, state, visible)
 	refreshHistory(game, history)
 }
 
@@ -261,31 +296,13 @@ func refreshBoard(game model.Game, board *goncurses.Window) {
 }
 
 func refreshState(
-	cis source.CharacterInputSource,
 	runtime engine.Engine,
-	game model.Game,
+	players []model.Player,
 	delay int,
 	state *goncurses.Window,
+	visible func(color model.PlayerColor) bool,
 ) {
-	if err := state.Clear(); err != nil {
-		log.Fatal(err)
-	}
-
-	if err := state.Box(0, 0); err != nil {
-		log.Fatal(err)
-	}
-
-	state.MovePrint(1, 2, "CONFIGURATION")
-	state.MovePrintf(3, 3, "Players..: %d", runtime.Players())
-	state.MovePrintf(4, 3, "Mode.....: %s", runtime.Mode().Value())
-	state.MovePrintf(5, 3, "Source...: %s", cis.Name())
-	state.MovePrintf(6, 3, "Delay....: %d ms", delay)
-	state.MovePrintf(7, 3, "State....: %s", runtime.State())
-
-	players := make([]model.Player, 0)
-	for _, player := range game.Players() {
-		players = append(players, player)
-	}
+	refreshConfiguration(runtime, delay, state)
 
 	slices.SortStableFunc(players, func(i, j model.Player) int {
 		return cmp.Compare(i.Color().Value(), j.Color().Value())
@@ -293,8 +310,14 @@ func refreshState(
 
 	row := 10
 	for _, player := range players {
+		hand := "hidden"
+		if visible(player.Color()) {
+			hand = renderHand(player)
+		}
+
 		state.MovePrintf(row+0, 2, "%s PLAYER", strings.ToUpper(player.Color().Value()))
-		state.MovePrintf(row+2, 3, "Hand.....: %s", renderHand(player))
+		state.MovePrintf(row+1, 3, "Source...: %s", runtime.ColorMap()[player.Color()].Source().Name())
+		state.MovePrintf(row+2, 3, "Hand.....: %s", hand)
 		state.MovePrintf(row+3, 3, "Pawns....:")
 		state.MovePrint(row+4, 6, player.Pawns()[0])
 		state.MovePrint(row+5, 6, player.Pawns()[1])
@@ -306,6 +329,23 @@ func refreshState(
 	state.Refresh()
 }
 
+// refreshConfiguration clears the state window and shows the game configuration at the top
+func refreshConfiguration(runtime engine.Engine, delay int, state *goncurses.Window) {
+	if err := state.Clear(); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := state.Box(0, 0); err != nil {
+		log.Fatal(err)
+	}
+
+	state.MovePrint(1, 2, "CONFIGURATION")
+	state.MovePrintf(3, 3, "Players..: %d", runtime.Players())
+	state.MovePrintf(4, 3, "Mode.....: %s", runtime.Mode().Value())
+	state.MovePrintf(5, 3, "Delay....: %d ms", delay)
+	state.MovePrintf(6, 3, "State....: %s", runtime.State())
+}
+
 func refreshHistory(game model.Game, history *goncurses.Window) {
 	if err := history.Clear(); err != nil {
 		log.Fatal(err)
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/pronovic/go-apologies/engine"
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/source"
+	"github.com/rthornton128/goncurses"
+)
+
+// kinds are the kinds of input source that can be assigned to a seat
+var kinds = []string{"human", "random", "reward", "hand", "montecarlo", "expectimax"}
+
+// seats maps a seat color to the kind of input source that plays it, configured by repeated -seat flags
+type seats map[model.PlayerColor]string
+
+func (s seats) String() string {
+	assigned := make([]string, 0, len(s))
+	for _, color := range model.PlayerColors.Members() {
+		if kind, exists := s[color]; exists {
+			assigned = append(assigned, fmt.Sprintf("%s=%s", strings.ToLower(color.Value()), kind))
+		}
+	}
+
+	return strings.Join(assigned, ",")
+}
+
+func (s seats) Set(value string) error {
+	name, kind, found := strings.Cut(value, "=")
+	if !found {
+		return fmt.Errorf("seat must look like color=kind, not %q", value)
+	}
+
+	index := slices.IndexFunc(model.PlayerColors.Members(), func(color model.PlayerColor) bool {
+		return strings.EqualFold(color.Value(), name)
+	})
+	if index < 0 {
+		return fmt.Errorf("unknown color %q", name)
+	}
+
+	color := model.PlayerColors.Members()[index]
+	if _, exists := s[color]; exists {
+		return fmt.Errorf("seat %s is configured more than once", strings.ToLower(color.Value()))
+	}
+
+	kind = strings.ToLower(kind)
+	if !slices.Contains(kinds, kind) {
+		return fmt.Errorf("unknown input source %q, expected one of %s", kind, strings.Join(kinds, ", "))
+	}
+
+	s[color] = kind
+	return nil
+}
+
+// humans returns the colors of the seats played by humans, in color order
+func (s seats) humans() []model.PlayerColor {
+	colors := make([]model.PlayerColor, 0)
+	for _, color := range model.PlayerColors.Members() {
+		if s[color] == "human" {
+			colors = append(colors, color)
+		}
+	}
+
+	return colors
+}
+
+// newSource constructs the input source for a kind of seat
+func newSource(kind string, randomizer random.Randomizer, display *display) source.CharacterInputSource {
+	switch kind {
+	case "human":
+		return &humanInputSource{display: display}
+	case "reward":
+		return source.RewardInputSource(nil, nil)
+	case "hand":
+		return source.HandRewardInputSource(nil, nil, nil)
+	case "montecarlo":
+		return source.MonteCarloInputSource(nil, nil, randomizer)
+	case "expectimax":
+		return source.ExpectimaxInputSource(nil, nil)
+	default:
+		return source.RandomInputSource(randomizer)
+	}
+}
+
+// display holds the ncurses windows, so a human input source can draw on them during its turn
+type display struct {
+	runtime engine.Engine
+	humans  []model.PlayerColor
+	delay   int
+	stdscr  *goncurses.Window
+	board   *goncurses.Window
+	state   *goncurses.Window
+	history *goncurses.Window
+}
+
+// perspective returns the players to show between turns, along with whether each player's hand is visible.
+// A single human sees the game from their own player view, which shows only their own hand.  Several humans
+// share the terminal, so every hand is hidden, and without any humans, every hand is visible.
+func (d *display) perspective(game model.Game) ([]model.Player, func(color model.PlayerColor) bool) {
+	if len(d.humans) == 1 {
+		human := d.humans[0]
+		view, err := game.CreatePlayerView(human)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		players := []model.Player{view.Player()}
+		for _, opponent := range view.Opponents() {
+			players = append(players, opponent)
+		}
+
+		return players, func(color model.PlayerColor) bool { return color == human }
+	}
+
+	players := make([]model.Player, 0, len(game.Players()))
+	for _, player := range game.Players() {
+		players = append(players, player)
+	}
+
+	return players, func(_ model.PlayerColor) bool { return len(d.humans) == 0 }
+}
+
+// humanInputSource source of input for a human player, which pauses for keyboard input on the human's turn
+type humanInputSource struct {
+	display *display
+}
+
+func (s *humanInputSource) Name() string {
+	return "Human"
+}
+
+func (s *humanInputSource) ChooseMove(_ model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	d := s.display
+	color := view.Player().Color()
+	name := strings.ToUpper(color.Value())
+
+	// with more than one human, the previous player's hand must be out of sight before this player sits down
+	if len(d.humans) > 1 {
+		d.board.Erase()
+		d.state.Erase()
+		d.history.Erase()
+		_ = d.board.Box(0, 0)
+		_ = d.state.Box(0, 0)
+		_ = d.history.Box(0, 0)
+		d.board.MovePrintf(25, 20, "Pass the terminal to the %s player, then press any key", name)
+		d.board.Refresh()
+		d.state.Refresh()
+		d.history.Refresh()
+
+		if d.board.GetChar() == 0 {
+			return nil, errors.New("input is closed")
+		}
+	}
+
+	game := d.runtime.Game()
+	refreshScreen(d.stdscr)
+	refreshBoard(game, d.board)
+	refreshHistory(game, d.history)
+
+	_ = d.state.Keypad(true)
+	selected := 0
+
+	for {
+		refreshConfiguration(d.runtime, d.delay, d.state)
+		refreshMoves(view, legalMoves, selected, d.state, d.history)
+
+		switch key := d.state.GetChar(); key {
+		case 0:
+			return nil, errors.New("input is closed")
+		case goncurses.KEY_UP, 'k':
+			selected = (selected + len(legalMoves) - 1) % len(legalMoves)
+		case goncurses.KEY_DOWN, 'j':
+			selected = (selected + 1) % len(legalMoves)
+		case goncurses.KEY_RETURN, goncurses.KEY_ENTER:
+			return legalMoves[selected], nil
+		default:
+			if key >= '1' && key <= '9' && int(key-'1') < len(legalMoves) {
+				selected = int(key - '1')
+			}
+		}
+	}
+}
+
+// refreshMoves lists the legal moves in place of the player details, highlighting the selected move
+func refreshMoves(view model.PlayerView, legalMoves []model.Move, selected int, state *goncurses.Window, history *goncurses.Window) {
+	const top, rows, width = 10, 40, 54
+
+	hand := renderHand(view.Player())
+	state.MovePrintf(top, 2, "%s PLAYER TO MOVE", strings.ToUpper(view.Player().Color().Value()))
+	state.MovePrintf(top+2, 3, "Hand.....: %s", hand)
+	state.MovePrint(top+3, 3, "Use up/down or 1-9 to choose, and enter to play")
+
+	// scroll the list so the selected move is always visible
+	first := 0
+	if selected >= rows-5 {
+		first = selected - (rows - 6)
+	}
+
+	row := top + 5
+	for i := first; i < len(legalMoves) && row < top+rows; i++ {
+		description := fmt.Sprintf("%2d) %s", i+1, source.DescribeMove(view, legalMoves[i]))
+		if runes := []rune(description); len(runes) > width {
+			description = string(runes[:width-3]) + "..."
+		}
+
+		if i == selected {
+			_ = state.AttrOn(goncurses.A_REVERSE)
+		}
+
+		state.MovePrint(row, 3, description)
+
+		if i == selected {
+			_ = state.AttrOff(goncurses.A_REVERSE)
+		}
+
+		row += 1
+	}
+
+	// the full description of the selected move might not fit in the list
+	history.Erase()
+	_ = history.Box(0, 0)
+	history.MovePrintf(2, 2, "Selected: %s", source.DescribeMove(view, legalMoves[selected]))
+
+	state.Refresh()
+	history.Refresh()
+}
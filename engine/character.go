@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+
 	"github.com/pronovic/go-apologies/model"
 	"github.com/pronovic/go-apologies/source"
 )
@@ -21,12 +23,29 @@ type Character interface {
 
 	// ChooseMove Choose the next move for a character via the input source
 	ChooseMove(mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error)
+
+	// ChooseMoveContext Choose the next move for a character via the input source, passing along the context if the source accepts one
+	ChooseMoveContext(ctx context.Context, mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error)
+
+	// Violations The number of times the character's input source chose an illegal move
+	Violations() int
+
+	// IncrementViolations Increment the number of illegal moves for the character
+	IncrementViolations()
+
+	// Timeouts The number of times the character's input source failed to choose a move within the time limit
+	Timeouts() int
+
+	// IncrementTimeouts Increment the number of timeouts for the character
+	IncrementTimeouts()
 }
 
 type character struct {
-	name   string
-	source source.CharacterInputSource
-	color  model.PlayerColor
+	name       string
+	source     source.CharacterInputSource
+	color      model.PlayerColor
+	violations int
+	timeouts   int
 }
 
 // NewCharacter constructs a new Character
@@ -59,3 +78,23 @@ func (c *character) SetColor(color model.PlayerColor) {
 func (c *character) ChooseMove(mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
 	return c.source.ChooseMove(mode, view, legalMoves)
 }
+
+func (c *character) ChooseMoveContext(ctx context.Context, mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	return source.ChooseMoveContext(ctx, c.source, mode, view, legalMoves)
+}
+
+func (c *character) Violations() int {
+	return c.violations
+}
+
+func (c *character) IncrementViolations() {
+	c.violations += 1
+}
+
+func (c *character) Timeouts() int {
+	return c.timeouts
+}
+
+func (c *character) IncrementTimeouts() {
+	c.timeouts += 1
+}
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"testing"
 
 	"github.com/pronovic/go-apologies/model"
@@ -36,3 +37,38 @@ func TestCharacterChooseMove(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Same(t, &move, result)
 }
+
+func TestCharacterChooseMoveContext(t *testing.T) {
+	input := source.MockContextInputSource{}
+	obj := NewCharacter("character", &input)
+	mode := model.AdultMode
+	view := model.MockPlayerView{}
+	move := model.MockMove{}
+	legalMoves := make([]model.Move, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	input.On("ChooseMoveContext", ctx, mode, &view, legalMoves).Return(&move, nil)
+	result, err := obj.ChooseMoveContext(ctx, mode, &view, legalMoves)
+	assert.NoError(t, err)
+	assert.Same(t, &move, result)
+}
+
+func TestCharacterViolations(t *testing.T) {
+	input := source.MockCharacterInputSource{}
+	obj := NewCharacter("character", &input)
+	assert.Equal(t, 0, obj.Violations())
+	obj.IncrementViolations()
+	assert.Equal(t, 1, obj.Violations())
+	obj.IncrementViolations()
+	assert.Equal(t, 2, obj.Violations())
+}
+
+func TestCharacterTimeouts(t *testing.T) {
+	input := source.MockCharacterInputSource{}
+	obj := NewCharacter("character", &input)
+	assert.Equal(t, 0, obj.Timeouts())
+	obj.IncrementTimeouts()
+	assert.Equal(t, 1, obj.Timeouts())
+	obj.IncrementTimeouts()
+	assert.Equal(t, 2, obj.Timeouts())
+}
@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/pronovic/go-apologies/internal/enum"
+	"github.com/pronovic/go-apologies/model"
+)
+
+// Termination defines the reasons that a game played to completion can stop
+type Termination struct{ value string }
+
+func (e Termination) Value() string                         { return e.value }
+func (e Termination) MarshalText() (text []byte, err error) { return enum.Marshal(e) }
+func (e *Termination) UnmarshalText(text []byte) error      { return enum.Unmarshal(e, text, Terminations) }
+
+var (
+	Terminations = enum.NewValues[Termination](Won, TurnLimit, TimeLimit, Failed)
+	Won          = Termination{"Won"}
+	TurnLimit    = Termination{"TurnLimit"}
+	TimeLimit    = Termination{"TimeLimit"}
+	Failed       = Termination{"Failed"}
+)
+
+// CompletionOptions Options for playing a game to completion, where zero values mean that there is no limit.
+type CompletionOptions struct {
+	// MaxTurns The maximum number of turns to play, where each turn is one call to PlayNext
+	MaxTurns int
+
+	// MaxTime The maximum wall time to play for, which abandons a turn that is in progress when the time runs out.
+	// Without a move timeout, an input source that does not accept a context is not interrupted mid-move.
+	MaxTime time.Duration
+
+	// OnTurn A callback invoked at the end of every turn with the character that played and the game state
+	OnTurn func(character Character, game model.Game) // optional
+}
+
+// Completion The result of playing a game to completion
+type Completion struct {
+	// Reason The reason that play stopped
+	Reason Termination
+
+	// Winner The character that won the game, or nil if the game was not won
+	Winner Character
+
+	// Turns The number of turns played, not counting any turns played before the call
+	Turns int
+
+	// Game The final game state
+	Game model.Game
+}
+
+func (e *engine) PlayToCompletion(options CompletionOptions) (Completion, error) {
+	return e.PlayToCompletionContext(context.Background(), options)
+}
+
+func (e *engine) PlayToCompletionContext(ctx context.Context, options CompletionOptions) (Completion, error) {
+	result := Completion{}
+
+	play := ctx
+	if options.MaxTime > 0 {
+		var cancel context.CancelFunc
+		play, cancel = context.WithTimeout(ctx, options.MaxTime)
+		defer cancel()
+	}
+
+	// a time limit is only reached if the caller's own context is still live
+	stopped := func(err error) (Completion, error) {
+		result.Game = e.game
+		if ctx.Err() == nil && play.Err() != nil {
+			result.Reason = TimeLimit
+			return result, nil
+		}
+
+		result.Reason = Failed
+		return result, err
+	}
+
+	if !e.Started() {
+		if _, err := e.StartGameContext(play); err != nil {
+			return stopped(err)
+		}
+	}
+
+	for !e.Completed() {
+		if options.MaxTurns > 0 && result.Turns >= options.MaxTurns {
+			result.Reason = TurnLimit
+			result.Game = e.game
+			return result, nil
+		}
+
+		color, err := e.queue.Peek()
+		if err != nil {
+			return stopped(err)
+		}
+
+		if _, err = e.PlayNextContext(play); err != nil {
+			return stopped(err)
+		}
+
+		result.Turns += 1
+
+		if options.OnTurn != nil {
+			options.OnTurn(e.colorMap[color], e.game)
+		}
+	}
+
+	result.Reason = Won
+	result.Winner = e.Winner()
+	result.Game = e.game
+	return result, nil
+}
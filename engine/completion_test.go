@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTerminationJSON(t *testing.T) {
+	for _, termination := range Terminations.Members() {
+		marshalled, err := json.Marshal(termination)
+		assert.NoError(t, err)
+
+		var unmarshalled Termination
+		err = json.Unmarshal(marshalled, &unmarshalled)
+		assert.NoError(t, err)
+		assert.Equal(t, termination, unmarshalled)
+	}
+}
+
+func TestEnginePlayToCompletionWon(t *testing.T) {
+	randomizer := random.NewSeededRandomizer(42)
+	input := source.RandomInputSource(randomizer)
+	characters := []Character{NewCharacter("character1", input), NewCharacter("character2", input)}
+	e, _ := NewEngine(model.StandardMode, characters, nil, randomizer)
+
+	turns := 0
+	colors := make(map[model.PlayerColor]int)
+	callback := func(character Character, game model.Game) {
+		turns += 1
+		colors[character.Color()] += 1
+		assert.Same(t, e.Game(), game)
+	}
+
+	// the game is started automatically, and the callback sees every turn
+	result, err := e.PlayToCompletion(CompletionOptions{OnTurn: callback})
+	assert.NoError(t, err)
+	assert.Equal(t, Won, result.Reason)
+	assert.True(t, e.Completed())
+	assert.Same(t, e.Winner(), result.Winner)
+	assert.Same(t, e.Game(), result.Game)
+	assert.Equal(t, turns, result.Turns)
+	assert.Equal(t, 2, len(colors))
+	assert.InDelta(t, colors[model.Red], colors[model.Yellow], 1)
+
+	// a game that is already won has nothing left to play
+	result, err = e.PlayToCompletion(CompletionOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, Won, result.Reason)
+	assert.Equal(t, 0, result.Turns)
+}
+
+func TestEnginePlayToCompletionTurnLimit(t *testing.T) {
+	randomizer := random.NewSeededRandomizer(42)
+	input := source.RandomInputSource(randomizer)
+	characters := []Character{NewCharacter("character1", input), NewCharacter("character2", input)}
+	e, _ := NewEngine(model.AdultMode, characters, nil, randomizer)
+
+	result, err := e.PlayToCompletion(CompletionOptions{MaxTurns: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, TurnLimit, result.Reason)
+	assert.Nil(t, result.Winner)
+	assert.Equal(t, 5, result.Turns)
+	assert.False(t, e.Completed())
+	assert.Same(t, e.Game(), result.Game)
+
+	// the limit applies to each call, so play can continue where it left off
+	result, err = e.PlayToCompletion(CompletionOptions{MaxTurns: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, result.Turns)
+}
+
+func TestEnginePlayToCompletionTimeLimit(t *testing.T) {
+	input := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, nil, input)
+	e.SetMoveTimeout(time.Minute, nil) // so that the source, which does not accept a context, can be interrupted
+
+	release := make(chan time.Time)
+	defer close(release)
+
+	input.On("ChooseMove", model.StandardMode, mock.Anything, mock.Anything).WaitUntil(release).Return(nil, nil).Once()
+
+	// the turn in progress is abandoned when the time runs out, leaving the game as it was before the turn
+	result, err := e.PlayToCompletion(CompletionOptions{MaxTime: 10 * time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, TimeLimit, result.Reason)
+	assert.Equal(t, 0, result.Turns)
+	assert.True(t, e.Started())
+	assert.Equal(t, 0, e.Game().Turn())
+	assert.Same(t, e.Game(), result.Game)
+}
+
+func TestEnginePlayToCompletionFailed(t *testing.T) {
+	input := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, nil, input)
+
+	input.On("ChooseMove", model.StandardMode, mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+
+	result, err := e.PlayToCompletion(CompletionOptions{})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, Failed, result.Reason)
+	assert.Equal(t, 0, result.Turns)
+	assert.Same(t, e.Game(), result.Game)
+
+	// a cancelled context is a failure rather than a time limit
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = e.PlayToCompletionContext(ctx, CompletionOptions{MaxTime: time.Minute})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, Failed, result.Reason)
+}
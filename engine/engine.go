@@ -1,16 +1,29 @@
 package engine
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/pronovic/go-apologies/internal/circularqueue"
-	"github.com/pronovic/go-apologies/internal/randomutil"
+	"github.com/pronovic/go-apologies/internal/equality"
 	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
 	"github.com/pronovic/go-apologies/rules"
+	"github.com/pronovic/go-apologies/source"
 )
 
 // Engine Game engine that coordinates character actions in a game.
+//
+// An engine can be saved between turns with json.Marshal and resumed later with NewEngineFromJSON.
+// Only the name of each character's input source is saved, not the source's settings.
 type Engine interface {
 	// Mode The game mode
 	Mode() model.GameMode
@@ -51,6 +64,9 @@ type Engine interface {
 	// StartGame Start the game, returning game state.
 	StartGame() (model.Game, error)
 
+	// StartGameContext Start the game, as for StartGame, unless the context is already done.
+	StartGameContext(ctx context.Context) (model.Game, error)
+
 	// NextTurn Get the color and character for the next turn
 	// This will give you a different player each time you call it.
 	NextTurn() (Character, error)
@@ -58,20 +74,64 @@ type Engine interface {
 	// PlayNext Play the next turn of the game, returning game state as of the end of the turn.
 	PlayNext() (model.Game, error)
 
+	// PlayNextContext Play the next turn of the game, as for PlayNext, abandoning the turn if the context is done.
+	// An abandoned turn leaves the game exactly as it was before the turn started, along with the characters' violation
+	// and timeout counters and a seeded randomizer, so it can simply be played again.
+	PlayNextContext(ctx context.Context) (model.Game, error)
+
+	// PlayToCompletion Play turns until the game is won or one of the limits in the options is reached, starting
+	// the game first if necessary.  If a turn fails, the reason is Failed and the error is returned along with the result.
+	PlayToCompletion(options CompletionOptions) (Completion, error)
+
+	// PlayToCompletionContext Play the game to completion, as for PlayToCompletion, stopping with an error if the context is done.
+	PlayToCompletionContext(ctx context.Context, options CompletionOptions) (Completion, error)
+
 	// Draw Draw a random card from the game's draw pile.
 	Draw() (model.Card, error)
 
 	// Discard Discard back to the game's discard pile.
 	Discard(card model.Card) error
 
-	// ConstructLegalMoves Construct the legal moves based on a player view, using the passed-in card if provided.
-	ConstructLegalMoves(view model.PlayerView, card model.Card) (model.Card, []model.Move, error)
+	// StartTurn Start a play for a character, drawing a card in standard mode and constructing the legal moves.
+	StartTurn(character Character) (Turn, error)
 
-	// ChooseNextMove Choose the next move for a character based on a player view.
-	ChooseNextMove(character Character, view model.PlayerView) (model.Move, error)
+	// ConstructLegalMoves Construct the legal moves based on a player view, using the passed-in card if provided.
+	ConstructLegalMoves(view model.PlayerView, card model.Card) ([]model.Move, error)
+
+	// SetMoveTimeout Limit the time that a character's input source may take to choose a move.
+	// If the limit is reached, the move is taken from the fallback source instead, or chosen at random
+	// if there is no fallback.  The fallback is held to the same limit, and the move is chosen at random
+	// if it is reached again, so choosing a move takes no more than about twice the limit.  A limit of
+	// zero (the default) means that there is no limit.  The limit is configuration rather than state, so
+	// it is not saved with the engine.  An input source is never asked for a move while it is still working
+	// on a move that was abandoned, even by another character sharing the same source instance or when it
+	// is also the fallback, so it is never called concurrently by the engine.
+	SetMoveTimeout(limit time.Duration, fallback source.CharacterInputSource)
+
+	// ChooseNextMove Choose the next move for the character playing a turn.
+	// If the character chooses an illegal move, a legal move is chosen at random instead.
+	ChooseNextMove(current Turn) (model.Move, error)
+
+	// ChooseNextMoveContext Choose the next move for the character playing a turn, as for ChooseNextMove.
+	// If the context is done before the character has chosen a move, the context's error is returned.  Without a
+	// move timeout, an input source that does not accept a context can't be interrupted, so it is checked afterwards.
+	ChooseNextMoveContext(ctx context.Context, current Turn) (model.Move, error)
+
+	// ExecuteMove Execute the move chosen for a turn and discard the card in play, returning true if the player's turn is done.
+	ExecuteMove(current Turn) (bool, error)
+
+	// Subscribe Register a listener for the events emitted as the game is played, returning a function that unsubscribes it.
+	// Listeners are configuration rather than state, so they are not saved with the engine.
+	Subscribe(listener Listener) func()
+}
 
-	// ExecuteMove Execute a move for a player, returning true if the player's turn is done.
-	ExecuteMove(color model.PlayerColor, move model.Move) (bool, error)
+// Checkpointer An optional interface for a rules evaluator that keeps state of its own about the game, like a recorder.
+// When a turn fails or is abandoned, the engine puts back the game as it was before the turn started, and then it
+// calls the function returned by Checkpoint at the start of the turn, so the evaluator can put back its state too.
+// The engine's randomizer is checkpointed the same way, which the seeded randomizer supports.
+type Checkpointer interface {
+	// Checkpoint Save the evaluator's state, returning a function that restores it
+	Checkpoint() func()
 }
 
 type engine struct {
@@ -84,10 +144,17 @@ type engine struct {
 	queue      circularqueue.CircularQueue[model.PlayerColor]
 	game       model.Game
 	colorMap   map[model.PlayerColor]Character
+	randomizer random.Randomizer
+	limit      time.Duration
+	fallback   source.CharacterInputSource
+	abandoned  map[any]chan struct{}
+	lock       sync.Mutex
+	listeners  []subscription
+	subscribed int
 }
 
-// NewEngine constructs a new Engine
-func NewEngine(mode model.GameMode, characters []Character, evaluator rules.Rules) (Engine, error) {
+// NewEngine constructs a new Engine, optionally accepting a rules evaluator and a randomizer
+func NewEngine(mode model.GameMode, characters []Character, evaluator rules.Rules, randomizer random.Randomizer) (Engine, error) {
 	if characters == nil || len(characters) < 1 {
 		return nil, errors.New("at least one character required")
 	}
@@ -96,10 +163,14 @@ func NewEngine(mode model.GameMode, characters []Character, evaluator rules.Rule
 		evaluator = rules.NewRules(nil)
 	}
 
+	if randomizer == nil {
+		randomizer = random.NewRandomizer()
+	}
+
 	players := len(characters)
 	colors := model.PlayerColors.Members()[0:players]
 
-	first, err := randomutil.RandomChoice(colors)
+	first, err := random.Choice(randomizer, colors)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +181,7 @@ func NewEngine(mode model.GameMode, characters []Character, evaluator rules.Rule
 		return nil, err
 	}
 
-	game, err := model.NewGame(players, nil)
+	game, err := model.NewGame(players, nil, randomizer)
 	if err != nil {
 		return nil, err
 	}
@@ -134,11 +205,166 @@ func NewEngine(mode model.GameMode, characters []Character, evaluator rules.Rule
 		queue:      queue,
 		game:       game,
 		colorMap:   colorMap,
+		randomizer: randomizer,
+		abandoned:  make(map[any]chan struct{}),
 	}
 
 	return constructed, nil
 }
 
+// snapshot is the saved state of an engine, used to serialize it to JSON
+type snapshot struct {
+	Xmode       model.GameMode      `json:"mode"`
+	Xfirst      model.PlayerColor   `json:"first"`
+	Xnext       model.PlayerColor   `json:"next"`
+	Xcharacters []characterSnapshot `json:"characters"`
+	Xgame       model.Game          `json:"game"`
+}
+
+// characterSnapshot is the saved state of a character, identifying its input source by name
+type characterSnapshot struct {
+	Xname       string            `json:"name"`
+	Xsource     string            `json:"source"`
+	Xcolor      model.PlayerColor `json:"color"`
+	Xviolations int               `json:"violations"`
+	Xtimeouts   int               `json:"timeouts"`
+}
+
+// NewEngineFromJSON constructs a new Engine from JSON in an io.Reader, as saved by json.Marshal.
+// Each character's input source is constructed by name from the registry, which defaults to
+// source.NewRegistry() if nil.  The rules evaluator and randomizer are optional, as for NewEngine.
+// A source's settings are not saved, so the sources built into source.NewRegistry() resume with their
+// default configuration, even if the engine was saved using (for instance) a deeper expectimax lookahead.
+// The saved game is loaded strictly, so a save file that has been corrupted is rejected, as is a save file
+// with a missing or unknown mode or first player.
+func NewEngineFromJSON(reader io.Reader, registry source.Registry, evaluator rules.Rules, randomizer random.Randomizer) (Engine, error) {
+	type raw struct {
+		Xmode       model.GameMode      `json:"mode"`
+		Xfirst      model.PlayerColor   `json:"first"`
+		Xnext       model.PlayerColor   `json:"next"`
+		Xcharacters []characterSnapshot `json:"characters"`
+		Xgame       json.RawMessage     `json:"game"`
+	}
+
+	var temp raw
+	err := json.NewDecoder(reader).Decode(&temp)
+	if err != nil {
+		return nil, err
+	}
+
+	// an unknown value fails to decode, but a missing value decodes as an empty value that isn't a member
+	if !model.GameModes.MemberOf(temp.Xmode.Value()) {
+		return nil, errors.New("saved engine has no mode")
+	}
+
+	if registry == nil {
+		registry = source.NewRegistry()
+	}
+
+	if evaluator == nil {
+		evaluator = rules.NewRules(nil)
+	}
+
+	if randomizer == nil {
+		randomizer = random.NewRandomizer()
+	}
+
+	game, err := model.NewGameFromJSONStrict(bytes.NewReader(temp.Xgame))
+	if err != nil {
+		return nil, err
+	}
+	game.Deck().SetRandomizer(randomizer)
+
+	players := len(temp.Xcharacters)
+	if players != game.PlayerCount() {
+		return nil, fmt.Errorf("expected %d characters but found %d", game.PlayerCount(), players)
+	}
+
+	colors := model.PlayerColors.Members()[0:players]
+	if !slices.Contains(colors, temp.Xfirst) {
+		return nil, fmt.Errorf("saved engine has invalid first player %q", temp.Xfirst.Value())
+	}
+
+	characters := make([]Character, 0, players)
+	colorMap := make(map[model.PlayerColor]Character, players)
+	for i, saved := range temp.Xcharacters {
+		if saved.Xcolor != colors[i] {
+			return nil, fmt.Errorf("character %s has unexpected color %s", saved.Xname, saved.Xcolor.Value())
+		}
+
+		input, err := registry.Lookup(saved.Xsource, randomizer)
+		if err != nil {
+			return nil, err
+		}
+
+		c := &character{
+			name:       saved.Xname,
+			source:     input,
+			color:      saved.Xcolor,
+			violations: saved.Xviolations,
+			timeouts:   saved.Xtimeouts,
+		}
+
+		characters = append(characters, c)
+		colorMap[c.Color()] = c
+	}
+
+	// the queue resumes with whichever player was going to take the next turn
+	queue := circularqueue.NewCircularQueue(colors)
+	err = queue.SetFirst(temp.Xnext)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := &engine{
+		mode:       temp.Xmode,
+		characters: characters,
+		evaluator:  evaluator,
+		players:    players,
+		colors:     colors,
+		first:      temp.Xfirst,
+		queue:      queue,
+		game:       game,
+		colorMap:   colorMap,
+		randomizer: randomizer,
+		abandoned:  make(map[any]chan struct{}),
+	}
+
+	return restored, nil
+}
+
+// MarshalJSON saves the state of the engine, so it can be restored with NewEngineFromJSON.
+// Each character's input source is saved by name only, without its settings.
+func (e *engine) MarshalJSON() ([]byte, error) {
+	next, err := e.queue.Peek()
+	if err != nil {
+		return nil, err
+	}
+
+	characters := make([]characterSnapshot, 0, len(e.characters))
+	for _, c := range e.characters {
+		if c.Source() == nil {
+			return nil, fmt.Errorf("character %s has no input source", c.Name())
+		}
+
+		characters = append(characters, characterSnapshot{
+			Xname:       c.Name(),
+			Xsource:     c.Source().Name(),
+			Xcolor:      c.Color(),
+			Xviolations: c.Violations(),
+			Xtimeouts:   c.Timeouts(),
+		})
+	}
+
+	return json.Marshal(snapshot{
+		Xmode:       e.mode,
+		Xfirst:      e.first,
+		Xnext:       next,
+		Xcharacters: characters,
+		Xgame:       e.game,
+	})
+}
+
 func (e *engine) Mode() model.GameMode {
 	return e.mode
 }
@@ -197,7 +423,7 @@ func (e *engine) Winner() Character {
 }
 
 func (e *engine) Reset() (model.Game, error) {
-	game, err := model.NewGame(e.players, nil)
+	game, err := model.NewGame(e.players, nil, e.randomizer)
 	if err != nil {
 		return nil, err
 	}
@@ -207,10 +433,19 @@ func (e *engine) Reset() (model.Game, error) {
 }
 
 func (e *engine) StartGame() (model.Game, error) {
+	return e.StartGameContext(context.Background())
+}
+
+func (e *engine) StartGameContext(ctx context.Context) (model.Game, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if err := e.evaluator.StartGame(e.game, e.mode); err != nil {
 		return nil, err
 	}
 
+	e.emit(Event{Type: GameStarted})
 	return e.game, nil
 }
 
@@ -224,41 +459,69 @@ func (e *engine) NextTurn() (Character, error) {
 }
 
 func (e *engine) PlayNext() (model.Game, error) {
+	return e.PlayNextContext(context.Background())
+}
+
+func (e *engine) PlayNextContext(ctx context.Context) (model.Game, error) {
 	if e.Completed() {
 		return e.game, errors.New("game is complete")
 	}
 
 	saved := e.game.Copy()
-
-	next, err := e.NextTurn()
+	upcoming, err := e.queue.Peek()
 	if err != nil {
-		e.game = saved // put back original so failed call is idempotent
 		return nil, err
 	}
 
-	color := next.Color()
+	rollback := func() {}
+	if checkpointer, ok := e.evaluator.(Checkpointer); ok {
+		rollback = checkpointer.Checkpoint()
+	}
+
+	rewind := func() {}
+	if checkpointer, ok := e.randomizer.(Checkpointer); ok {
+		rewind = checkpointer.Checkpoint()
+	}
+
+	counters := e.saveCounters()
+
+	// put back the original game, player, counters and randomizer so a failed or cancelled call is idempotent,
+	// and a seeded game that is resumed afterward plays out exactly as if the call had never been made
+	restore := func(err error) (model.Game, error) {
+		e.game = saved
+		_ = e.queue.SetFirst(upcoming)
+		rollback()
+		rewind()
+		counters()
+		return nil, err
+	}
+
+	next, err := e.NextTurn()
+	if err != nil {
+		return restore(err)
+	}
 
-	done := false
 	for {
-		var view model.PlayerView
-		var move model.Move
+		var current Turn
+		var done bool
+
+		if err = ctx.Err(); err != nil {
+			return restore(err)
+		}
 
-		view, err = e.game.CreatePlayerView(color)
+		current, err = e.StartTurn(next)
 		if err != nil {
-			e.game = saved // put back original so failed call is idempotent
-			return nil, err
+			return restore(err)
 		}
 
-		move, err = e.ChooseNextMove(next, view)
+		_, err = e.ChooseNextMoveContext(ctx, current)
 		if err != nil {
-			e.game = saved // put back original so failed call is idempotent
-			return nil, err
+			return restore(err)
 		}
 
-		done, err = e.ExecuteMove(color, move)
+		done, err = e.ExecuteMove(current)
 		if err != nil {
-			e.game = saved // put back original so failed call is idempotent
-			return nil, err
+			return restore(err)
 		}
 
 		if done {
@@ -269,6 +532,34 @@ func (e *engine) PlayNext() (model.Game, error) {
 	return e.game, nil
 }
 
+// saveCounters saves the violation and timeout counters for every character, returning a function that restores them.
+// Only characters constructed by NewCharacter can be restored, since the Character interface can only increment them.
+func (e *engine) saveCounters() func() {
+	type counters struct {
+		violations int
+		timeouts   int
+	}
+
+	saved := make(map[*character]counters, len(e.characters))
+	for _, c := range e.characters {
+		if concrete, ok := c.(*character); ok {
+			saved[concrete] = counters{concrete.violations, concrete.timeouts}
+		}
+	}
+
+	return func() {
+		for c, counted := range saved {
+			c.violations = counted.violations
+			c.timeouts = counted.timeouts
+		}
+	}
+}
+
+func (e *engine) SetMoveTimeout(limit time.Duration, fallback source.CharacterInputSource) {
+	e.limit = limit
+	e.fallback = fallback
+}
+
 func (e *engine) Draw() (model.Card, error) {
 	return e.game.Deck().Draw()
 }
@@ -281,138 +572,378 @@ func (e *engine) Discard(card model.Card) error {
 	return e.Game().Deck().Discard(card)
 }
 
-// ConstructLegalMoves Construct the legal moves based on a player view, using the passed-in card if provided.
-func (e *engine) ConstructLegalMoves(view model.PlayerView, card model.Card) (model.Card, []model.Move, error) {
-	if view == nil {
-		return nil, nil, errors.New("view is nil")
+// StartTurn Start a play for a character, drawing a card in standard mode and constructing the legal moves.
+func (e *engine) StartTurn(character Character) (Turn, error) {
+	if character == nil {
+		return nil, errors.New("character is nil")
 	}
 
-	if e.mode == model.StandardMode {
-		if card == nil {
-			drawn, err := e.game.Deck().Draw()
-			if err != nil {
-				return nil, nil, err
-			}
+	if _, exists := e.game.Players()[character.Color()]; !exists {
+		return nil, errors.New("invalid color")
+	}
 
-			card = drawn
+	// every play is a separate turn in the game history, including when a player draws again
+	e.game.StartTurn()
+
+	color := character.Color()
+	e.emit(Event{Type: TurnStarted, Color: &color})
+
+	// in standard mode, the card in play is drawn from the deck; in adult mode, it comes from the player's hand
+	var card model.Card
+	var err error
+	if e.mode == model.StandardMode {
+		card, err = e.Draw()
+		if err != nil {
+			return nil, err
 		}
+
+		e.emit(Event{Type: CardDrawn, Color: &color, Card: card})
 	}
 
-	legalMoves, err := e.evaluator.ConstructLegalMoves(view, card)
+	// the view is built after the draw, since drawing may reshuffle the discard pile into the draw pile
+	view, err := e.game.CreatePlayerView(color)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return card, legalMoves, nil
-}
+	t := newTurn(character, view, card)
 
-// ChooseNextMove Choose the next move for a character based on a player view.
-func (e *engine) ChooseNextMove(character Character, view model.PlayerView) (model.Move, error) {
-	if character == nil {
-		return nil, errors.New("character is nil")
+	t.legalMoves, err = e.ConstructLegalMoves(view, card)
+	if err != nil {
+		return nil, err
 	}
 
+	e.emit(Event{Type: LegalMovesComputed, Color: &color, Card: card, LegalMoves: t.legalMoves})
+	return t, nil
+}
+
+// ConstructLegalMoves Construct the legal moves based on a player view, using the passed-in card if provided.
+// In standard mode, a card is required.  In adult mode, legal moves come from the player's hand if no card is provided.
+func (e *engine) ConstructLegalMoves(view model.PlayerView, card model.Card) ([]model.Move, error) {
 	if view == nil {
 		return nil, errors.New("view is nil")
 	}
 
-	_, legalMoves, err := e.ConstructLegalMoves(view, nil)
-	if err != nil {
-		return nil, err
+	if e.mode == model.StandardMode && card == nil {
+		return nil, errors.New("card is required in standard mode")
+	}
+
+	return e.evaluator.ConstructLegalMoves(view, card)
+}
+
+// ChooseNextMove Choose the next move for the character playing a turn.
+func (e *engine) ChooseNextMove(current Turn) (model.Move, error) {
+	return e.ChooseNextMoveContext(context.Background(), current)
+}
+
+// ChooseNextMoveContext Choose the next move for the character playing a turn, unless the context is done first.
+func (e *engine) ChooseNextMoveContext(ctx context.Context, current Turn) (model.Move, error) {
+	t, ok := current.(*turn)
+	if !ok || t == nil {
+		return nil, errors.New("turn was not started by the engine")
 	}
+	character := t.character
 
-	move, err := character.ChooseMove(e.mode, view, legalMoves)
+	move, timedOut, err := e.chooseMove(ctx, abandonedKey(character), func(ctx context.Context) (model.Move, error) {
+		return character.ChooseMoveContext(ctx, e.mode, t.view, t.legalMoves)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return move, nil
-}
+	if timedOut {
+		e.game.TrackNotice(fmt.Sprintf("Move timed out for %s after %s; %s", character.Name(), e.limit, e.fallbackName()), character.Color())
+		character.IncrementTimeouts()
 
-// ExecuteMove Execute a move for a player, returning true if the player's turn is done.
-func (e *engine) ExecuteMove(color model.PlayerColor, move model.Move) (bool, error) {
-	if move == nil {
-		return false, errors.New("model is nil")
+		move, err = e.chooseFallbackMove(ctx, t)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	player := e.game.Players()[color]
-	if e.mode == model.AdultMode {
-		return e.executeMoveAdult(player, move)
-	} else {
-		return e.executeMoveStandard(player, move)
+	if !isLegal(t.legalMoves, move) {
+		// a misbehaving source (or a source attempting to cheat) does not get an advantage
+		e.game.TrackNotice(fmt.Sprintf("Illegal move chosen by %s; executing a random legal move instead", character.Name()), character.Color())
+		character.IncrementViolations()
+
+		move, err = random.Choice(e.randomizer, t.legalMoves)
+		if err != nil {
+			return nil, err
+		}
 	}
-}
 
-func (e *engine) executeMoveStandard(player model.Player, move model.Move) (bool, error) {
-	if len(move.Actions()) == 0 {
-		e.game.Track(fmt.Sprintf("Turn is forfeit; discarded card %s", move.Card().Type()), player, move.Card())
+	t.move = move
 
-		if err := e.Discard(move.Card()); err != nil {
-			return false, err
+	color := character.Color()
+	e.emit(Event{Type: MoveChosen, Color: &color, Card: move.Card(), Move: move})
+	return move, nil
+}
+
+// chooseMove asks an input source for a move, giving up if it takes longer than the time limit or if the context is done.
+// The context passed to the input source expires along with the time limit, but a source that does not accept a
+// context cannot be interrupted, so an abandoned call keeps running in the background until it returns.  Until it
+// does, the source (identified by its key) is not asked for another move, and the time spent waiting for it counts
+// against the time limit.
+//
+// Without a time limit, the source is called directly rather than in the background, so playing a game with a
+// context doesn't cost a goroutine for every move.  A source that accepts a context still sees it, but a source
+// that does not can't be interrupted, so the context is only checked before and after the call.  Waiting for a call
+// abandoned under an earlier time limit can still be interrupted by the context, though.
+func (e *engine) chooseMove(ctx context.Context, key any, choose func(ctx context.Context) (model.Move, error)) (model.Move, bool, error) {
+	if e.limit <= 0 {
+		if busy, exists := e.abandoned[key]; exists {
+			select {
+			case <-busy:
+				delete(e.abandoned, key)
+			case <-ctx.Done():
+				return nil, false, ctx.Err()
+			}
 		}
 
-		// player's turn is done if they forfeit
-		return true, nil
-	} else {
-		// tracks history, potentially completes game
-		if err := e.evaluator.ExecuteMove(e.game, player, move); err != nil {
-			return false, err
+		move, err := choose(ctx)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, false, ctxErr
 		}
+		return move, false, err
+	}
 
-		if err := e.Discard(move.Card()); err != nil {
-			return false, err
+	limited, cancel := context.WithTimeout(ctx, e.limit)
+	defer cancel()
+
+	if busy, exists := e.abandoned[key]; exists {
+		select {
+		case <-busy:
+			delete(e.abandoned, key) // whatever the abandoned call returned is stale, so it is ignored
+		case <-limited.Done():
+			if err := ctx.Err(); err != nil {
+				return nil, false, err
+			}
+			return nil, true, nil
 		}
+	}
 
-		// player's turn is done unless they can draw again with this card or the game is completed
-		done := e.Completed() || !e.evaluator.DrawAgain(move.Card())
+	type result struct {
+		move model.Move
+		err  error
+	}
 
-		return done, nil
+	// buffered, so an abandoned call can still deliver its result and exit
+	results := make(chan result, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		move, err := choose(limited)
+		results <- result{move, err}
+	}()
+
+	select {
+	case r := <-results:
+		if r.err != nil && ctx.Err() == nil && limited.Err() != nil {
+			return nil, true, nil // the source gave up because the time limit expired
+		}
+		return r.move, false, r.err
+	case <-limited.Done():
+		e.abandoned[key] = done
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+		return nil, true, nil
 	}
 }
 
-func (e *engine) executeMoveAdult(player model.Player, move model.Move) (bool, error) {
-	if len(move.Actions()) == 0 {
-		e.game.Track(fmt.Sprintf("Turn is forfeit; discarded card %s", move.Card().Type()), player, move.Card())
+// abandonedKey identifies the input source that an abandoned call is tracked against, so that a source shared by
+// several characters (or also used as the fallback) is not asked for a move by any of them until the abandoned call
+// returns.  A source whose type can't be used as a map key has no identity to share, so it is tracked against the
+// character instead.
+func abandonedKey(character Character) any {
+	if input := character.Source(); input != nil && reflect.TypeOf(input).Comparable() {
+		return input
+	}
 
-		player.RemoveFromHand(move.Card())
+	return character
+}
 
-		drawn, err := e.Draw()
-		if err != nil {
-			return false, err
+// fallbackKey identifies the fallback source when its type can't be used as a map key
+type fallbackKey struct{}
+
+// chooseFallbackMove chooses a move for a character whose input source timed out.  The fallback is held to the same
+// time limit, and is tracked the same way when it is abandoned, so a slow fallback (or a fallback that is also the
+// character's own source, still working on the move that timed out) falls back to a random legal move in turn.
+func (e *engine) chooseFallbackMove(ctx context.Context, t *turn) (model.Move, error) {
+	if e.fallback != nil {
+		var key any = fallbackKey{}
+		if reflect.TypeOf(e.fallback).Comparable() {
+			key = e.fallback
 		}
 
-		err = e.Discard(move.Card())
+		move, timedOut, err := e.chooseMove(ctx, key, func(ctx context.Context) (model.Move, error) {
+			return source.ChooseMoveContext(ctx, e.fallback, e.mode, t.view, t.legalMoves)
+		})
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 
-		player.AppendToHand(drawn)
+		if timedOut {
+			e.game.TrackNotice(fmt.Sprintf("Move timed out for %s after %s; executing a random legal move instead", e.fallback.Name(), e.limit), t.character.Color())
+		} else if isLegal(t.legalMoves, move) {
+			return move, nil // otherwise, the character is not to blame if the fallback misbehaves
+		}
+	}
+
+	return random.Choice(e.randomizer, t.legalMoves)
+}
+
+// fallbackName describes where the move comes from when a character's input source times out
+func (e *engine) fallbackName() string {
+	if e.fallback == nil {
+		return "executing a random legal move instead"
+	}
+
+	return fmt.Sprintf("executing a move from %s instead", e.fallback.Name())
+}
 
-		// player's turn is done if they forfeit
-		return true, nil
+// ExecuteMove Execute the move chosen for a turn, returning true if the player's turn is done.
+func (e *engine) ExecuteMove(current Turn) (bool, error) {
+	t, ok := current.(*turn)
+	if !ok || t == nil {
+		return false, errors.New("turn was not started by the engine")
+	}
+	if t.move == nil {
+		return false, errors.New("move is nil")
+	}
+
+	player := e.game.Players()[t.character.Color()]
+
+	var err error
+	if e.mode == model.AdultMode {
+		err = e.executeMoveAdult(t, player)
 	} else {
-		// tracks history, potentially completes game
-		if err := e.evaluator.ExecuteMove(e.game, player, move); err != nil {
-			return false, err
-		}
+		err = e.executeMoveStandard(t, player)
+	}
 
-		player.RemoveFromHand(move.Card())
+	if err != nil {
+		return false, err
+	}
 
-		drawn, err := e.Draw()
-		if err != nil {
-			return false, err
+	if err = e.checkDiscards(t); err != nil {
+		return false, err
+	}
+
+	// player's turn is done unless they can draw again with this card or the game is completed
+	t.done = len(t.move.Actions()) == 0 || e.Completed() || !e.evaluator.DrawAgain(t.move.Card())
+
+	color := t.character.Color()
+	e.emitMove(color, t.move.Card(), t.move)
+	if e.Completed() {
+		e.emit(Event{Type: GameCompleted, Color: &color, Card: t.move.Card()})
+	} else if !t.done {
+		e.emit(Event{Type: DrawAgain, Color: &color, Card: t.move.Card()})
+	}
+
+	return t.done, nil
+}
+
+func (e *engine) executeMoveStandard(t *turn, player model.Player) error {
+	// the card actually in play is the one that was drawn, not whatever card is on the move
+	if t.card == nil || t.move.Card() == nil || t.move.Card().Id() != t.card.Id() {
+		return errors.New("move does not use the card drawn for the turn")
+	}
+
+	// tracks history (including a forfeit), potentially completes game
+	if err := e.evaluator.ExecuteMove(e.game, player, t.move); err != nil {
+		return err
+	}
+
+	return e.discard(t, t.card)
+}
+
+func (e *engine) executeMoveAdult(t *turn, player model.Player) error {
+	card := t.move.Card()
+
+	// tracks history (including a forfeit), potentially completes game
+	if err := e.evaluator.ExecuteMove(e.game, player, t.move); err != nil {
+		return err
+	}
+
+	player.RemoveFromHand(card)
+
+	drawn, err := e.Draw()
+	if err != nil {
+		return err
+	}
+
+	color := player.Color()
+	e.emit(Event{Type: CardDrawn, Color: &color, Card: drawn})
+
+	err = e.discard(t, card)
+	if err != nil {
+		return err
+	}
+
+	player.AppendToHand(drawn)
+
+	return nil
+}
+
+// discard discards a card that was played as part of a turn
+func (e *engine) discard(t *turn, card model.Card) error {
+	if err := e.Discard(card); err != nil {
+		return err
+	}
+
+	t.discarded = append(t.discarded, card)
+	return nil
+}
+
+// checkDiscards verifies that the card in play for a turn reached the discard pile exactly once, and that no card anywhere in the game leaked or was duplicated
+func (e *engine) checkDiscards(t *turn) error {
+	played := t.move.Card()
+	if e.mode == model.StandardMode {
+		played = t.card
+	}
+
+	if len(t.discarded) != 1 || t.discarded[0].Id() != played.Id() {
+		return fmt.Errorf("card leak: card %s was played but %d card(s) were discarded", played.Id(), len(t.discarded))
+	}
+
+	return e.checkConservation()
+}
+
+// checkConservation verifies that the draw pile, the discard pile, and all players' hands together hold the full deck, with no duplicate ids
+func (e *engine) checkConservation() error {
+	cards := e.game.Deck().Cards()
+	for _, color := range model.PlayerColors.Members() {
+		if player, exists := e.game.Players()[color]; exists {
+			cards = append(cards, player.Hand()...)
 		}
+	}
 
-		err = e.Discard(move.Card())
-		if err != nil {
-			return false, err
+	seen := make(map[string]bool, len(cards))
+	for _, card := range cards {
+		if seen[card.Id()] {
+			return fmt.Errorf("card leak: card %s is in more than one place", card.Id())
 		}
+		seen[card.Id()] = true
+	}
 
-		player.AppendToHand(drawn)
+	if len(cards) != model.DeckSize {
+		return fmt.Errorf("card leak: expected %d cards but found %d", model.DeckSize, len(cards))
+	}
 
-		// player's turn is done unless they can draw again with this card or the game is completed
-		done := e.Completed() || !e.evaluator.DrawAgain(move.Card())
+	return nil
+}
 
-		return done, nil
+// isLegal checks whether a move is found among a set of legal moves
+func isLegal(legalMoves []model.Move, move model.Move) bool {
+	if move == nil {
+		return false
 	}
+
+	for _, legal := range legalMoves {
+		if legal == move || equality.EqualByValue(legal, move) {
+			return true
+		}
+	}
+
+	return false
 }
@@ -1,9 +1,18 @@
 package engine
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
 	"github.com/pronovic/go-apologies/rules"
 	"github.com/pronovic/go-apologies/source"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +28,7 @@ func TestNewEngine(t *testing.T) {
 	character4 := NewCharacter("character4", &input)
 	characters := []Character{character1, character2, character3, character4}
 
-	e, _ := NewEngine(model.StandardMode, characters, nil)
+	e, _ := NewEngine(model.StandardMode, characters, nil, nil)
 	assert.Equal(t, model.StandardMode, e.Mode())
 	assert.Equal(t, characters, e.Characters())
 	assert.NotNil(t, e.First())
@@ -102,6 +111,22 @@ func TestEngineStartGame(t *testing.T) {
 	assert.True(t, e.Game().Started())
 }
 
+func TestEngineStartGameContext(t *testing.T) {
+	e := createEngine(model.AdultMode, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := e.StartGameContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, e.Started())
+
+	game, err := e.StartGameContext(context.Background())
+	assert.NoError(t, err)
+	assert.Same(t, e.Game(), game)
+	assert.True(t, e.Started())
+}
+
 func TestEngineDrawAndDiscard(t *testing.T) {
 	e := createEngine(model.AdultMode, nil, nil)
 
@@ -134,20 +159,14 @@ func TestEngineConstructLegalMovesStandardNoCard(t *testing.T) {
 
 	view := model.MockPlayerView{}
 	drawcard := model.NewCard("1", model.Card1)
-	movecard := model.NewCard("2", model.Card2)
-	move := model.NewMove(movecard, nil, nil)
-	legalMoves := []model.Move{move}
 
 	configureDrawCards(e, drawcard) // so we know exactly which card will be drawn
-	evaluator.On("ConstructLegalMoves", &view, drawcard).Return(legalMoves, nil)
 
-	c, m, err := e.ConstructLegalMoves(&view, nil)
-	assert.NoError(t, err)
-	assert.Same(t, drawcard, c)
-	assert.Equal(t, legalMoves, m)
+	_, err := e.ConstructLegalMoves(&view, nil)
+	assert.EqualError(t, err, "card is required in standard mode")
 
-	_, err = e.Draw()
-	assert.NotNil(t, err) // confirm that the deck is empty and we did draw the drawcard
+	c, _ := e.Draw()
+	assert.Same(t, drawcard, c) // confirm that the card was not drawn from the deck
 }
 
 func TestEngineConstructLegalMovesStandardCard(t *testing.T) {
@@ -164,12 +183,11 @@ func TestEngineConstructLegalMovesStandardCard(t *testing.T) {
 	configureDrawCards(e, drawcard) // so we know exactly which card will be drawn
 	evaluator.On("ConstructLegalMoves", &view, providedcard).Return(legalMoves, nil)
 
-	c, m, err := e.ConstructLegalMoves(&view, providedcard)
+	m, err := e.ConstructLegalMoves(&view, providedcard)
 	assert.NoError(t, err)
-	assert.Same(t, providedcard, c)
 	assert.Equal(t, legalMoves, m)
 
-	c, _ = e.Draw()
+	c, _ := e.Draw()
 	assert.Same(t, drawcard, c) // confirm that the card was not drawn from the deck
 }
 
@@ -186,12 +204,11 @@ func TestEngineConstructLegalMovesAdultNoCard(t *testing.T) {
 	configureDrawCards(e, drawcard) // so we know exactly which card will be drawn
 	evaluator.On("ConstructLegalMoves", &view, nil).Return(legalMoves, nil)
 
-	c, m, err := e.ConstructLegalMoves(&view, nil)
+	m, err := e.ConstructLegalMoves(&view, nil)
 	assert.NoError(t, err)
-	assert.Nil(t, c)
 	assert.Equal(t, legalMoves, m)
 
-	c, _ = e.Draw()
+	c, _ := e.Draw()
 	assert.Same(t, drawcard, c) // confirm that the card was not drawn from the deck
 }
 
@@ -209,15 +226,662 @@ func TestEngineConstructLegalMovesAdultCard(t *testing.T) {
 	configureDrawCards(e, drawcard) // so we know exactly which card will be drawn
 	evaluator.On("ConstructLegalMoves", &view, providedcard).Return(legalMoves, nil)
 
-	c, m, err := e.ConstructLegalMoves(&view, providedcard)
+	m, err := e.ConstructLegalMoves(&view, providedcard)
 	assert.NoError(t, err)
-	assert.Same(t, providedcard, c)
 	assert.Equal(t, legalMoves, m)
 
-	c, _ = e.Draw()
+	c, _ := e.Draw()
 	assert.Same(t, drawcard, c) // confirm that the card was not drawn from the deck
 }
 
+func TestEngineStartTurnStandard(t *testing.T) {
+	evaluator := rules.MockRules{}
+	e := createEngine(model.StandardMode, &evaluator, nil)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	drawcard := model.NewCard("1", model.Card1)
+	move := model.NewMove(drawcard, nil, nil)
+	legalMoves := []model.Move{move}
+
+	configureDrawCards(e, drawcard) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, drawcard).Return(legalMoves, nil)
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+	assert.Same(t, character, turn.Character())
+	assert.Equal(t, model.Red, turn.View().Player().Color())
+	assert.Same(t, drawcard, turn.Card())
+	assert.Equal(t, legalMoves, turn.LegalMoves())
+	assert.Nil(t, turn.Move())
+	assert.Equal(t, 0, len(turn.Discarded()))
+	assert.False(t, turn.Done())
+
+	_, err = e.Draw()
+	assert.NotNil(t, err) // confirm that the deck is empty and we did draw the drawcard
+}
+
+func TestEngineStartTurnReshuffle(t *testing.T) {
+	evaluator := rules.MockRules{}
+	e := createEngine(model.StandardMode, &evaluator, nil)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	drawcard := model.NewCard("1", model.Card1)
+	move := model.NewMove(drawcard, nil, nil)
+	legalMoves := []model.Move{move}
+
+	configureDrawCards(e, drawcard) // the draw pile is empty, so the discard pile is reshuffled to draw the card
+	evaluator.On("ConstructLegalMoves", mock.Anything, drawcard).Return(legalMoves, nil)
+	assert.Equal(t, []model.Card{drawcard}, e.Game().Deck().DiscardPile())
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+	assert.Same(t, drawcard, turn.Card())
+	assert.NotContains(t, turn.View().DiscardPile(), turn.Card())
+	assert.Empty(t, turn.View().DiscardPile())
+}
+
+func TestEngineStartTurnAdult(t *testing.T) {
+	evaluator := rules.MockRules{}
+	e := createEngine(model.AdultMode, &evaluator, nil)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	drawcard := model.NewCard("1", model.Card1)
+	move := model.NewMove(e.Game().Players()[model.Red].Hand()[0], nil, nil)
+	legalMoves := []model.Move{move}
+
+	configureDrawCards(e, drawcard) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, nil).Return(legalMoves, nil)
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+	assert.Same(t, character, turn.Character())
+	assert.Nil(t, turn.Card())
+	assert.Equal(t, legalMoves, turn.LegalMoves())
+
+	c, _ := e.Draw()
+	assert.Same(t, drawcard, c) // confirm that the card was not drawn from the deck
+}
+
+func TestEngineStartTurnNil(t *testing.T) {
+	e := createEngine(model.StandardMode, nil, nil)
+	_, err := e.StartTurn(nil)
+	assert.EqualError(t, err, "character is nil")
+}
+
+func TestEngineChooseNextMoveInvalidTurn(t *testing.T) {
+	e := createEngine(model.StandardMode, nil, nil)
+
+	_, err := e.ChooseNextMove(nil)
+	assert.EqualError(t, err, "turn was not started by the engine")
+
+	_, err = e.ChooseNextMove(&MockTurn{})
+	assert.EqualError(t, err, "turn was not started by the engine")
+}
+
+func TestEngineChooseNextMoveLegal(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move1 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)
+	move2 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[1])}, nil)
+	equivalent := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[1])}, nil)
+	legalMoves := []model.Move{move1, move2}
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Return(equivalent, nil).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+
+	// a move that is equivalent to a legal move is accepted as-is
+	move, err := e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.Same(t, equivalent, move)
+	assert.Same(t, equivalent, turn.Move())
+	assert.Equal(t, 0, character.Violations())
+}
+
+func TestEngineChooseNextMoveIllegal(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move1 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)
+	move2 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[1])}, nil)
+	illegal := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[2])}, nil)
+	legalMoves := []model.Move{move1, move2}
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Return(illegal, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Return(nil, nil).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+
+	// an illegal move is replaced with a random legal move, and the violation is tracked
+	move, err := e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.True(t, move == move1 || move == move2)
+	assert.Same(t, move, turn.Move())
+	assert.Equal(t, 1, character.Violations())
+	history := e.Game().History()[len(e.Game().History())-1]
+	assert.Equal(t, "Illegal move chosen by character1; executing a random legal move instead", history.Action())
+	assert.Equal(t, model.Red, *history.Color())
+	assert.Equal(t, 0, e.Game().Players()[model.Red].Turns())
+
+	// a nil move is also illegal
+	move, err = e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.True(t, move == move1 || move == move2)
+	assert.Equal(t, 2, character.Violations())
+}
+
+func TestEngineChooseNextMoveWithinTimeout(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockCharacterInputSource{}
+	fallback := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	e.SetMoveTimeout(time.Minute, fallback)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move1 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)
+	move2 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[1])}, nil)
+	legalMoves := []model.Move{move1, move2}
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Return(move2, nil).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+
+	// a source that answers in time is used as-is, and the fallback is never consulted
+	move, err := e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.Same(t, move2, move)
+	assert.Equal(t, 0, character.Timeouts())
+	fallback.AssertNotCalled(t, "ChooseMove", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEngineChooseNextMoveTimeoutFallback(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockCharacterInputSource{}
+	fallback := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	e.SetMoveTimeout(10*time.Millisecond, fallback)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move1 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)
+	move2 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[1])}, nil)
+	legalMoves := []model.Move{move1, move2}
+
+	release := make(chan time.Time)
+	defer close(release)
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).WaitUntil(release).Return(move1, nil).Once()
+	fallback.On("Name").Return("fallback")
+	fallback.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Return(move2, nil).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+
+	// a source that is too slow is abandoned, and the fallback chooses the move instead
+	move, err := e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.Same(t, move2, move)
+	assert.Same(t, move2, turn.Move())
+	assert.Equal(t, 1, character.Timeouts())
+	assert.Equal(t, 0, character.Violations())
+	history := e.Game().History()[len(e.Game().History())-1]
+	assert.Equal(t, "Move timed out for character1 after 10ms; executing a move from fallback instead", history.Action())
+	assert.Equal(t, model.Red, *history.Color())
+	assert.Equal(t, 0, e.Game().Players()[model.Red].Turns())
+}
+
+func TestEngineChooseNextMoveTimeoutFallbackSlow(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockCharacterInputSource{}
+	fallback := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	e.SetMoveTimeout(10*time.Millisecond, fallback)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move1 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)
+	move2 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[1])}, nil)
+	legalMoves := []model.Move{move1, move2}
+
+	release := make(chan time.Time)
+	defer close(release)
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).WaitUntil(release).Return(move1, nil).Once()
+	fallback.On("Name").Return("fallback")
+	fallback.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).WaitUntil(release).Return(move2, nil).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+
+	// a fallback that is too slow is abandoned too, and the move is chosen at random instead
+	move, err := e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.Contains(t, legalMoves, move)
+	assert.Equal(t, 1, character.Timeouts())
+	history := e.Game().History()
+	assert.Equal(t, "Move timed out for character1 after 10ms; executing a move from fallback instead", history[len(history)-2].Action())
+	assert.Equal(t, "Move timed out for fallback after 10ms; executing a random legal move instead", history[len(history)-1].Action())
+
+	// neither source is asked again while it is still working on its abandoned move
+	_, err = e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, character.Timeouts())
+	input.AssertNumberOfCalls(t, "ChooseMove", 1)
+	fallback.AssertNumberOfCalls(t, "ChooseMove", 1)
+}
+
+func TestEngineChooseNextMoveTimeoutFallbackShared(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &blockingSource{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(input.release)
+	e := createEngine(model.StandardMode, &evaluator, input)
+	e.SetMoveTimeout(10*time.Millisecond, input) // the character's own source is also the fallback
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	legalMoves := []model.Move{model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)}
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+
+	// the source is still working on the move that timed out, so it is not asked again as the fallback
+	move, err := e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.Same(t, legalMoves[0], move)
+	assert.Equal(t, int32(1), input.calls.Load())
+	history := e.Game().History()
+	assert.Equal(t, "Move timed out for blocking after 10ms; executing a random legal move instead", history[len(history)-1].Action())
+}
+
+func TestEngineChooseNextMoveAbandonedSource(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	e.SetMoveTimeout(10*time.Millisecond, nil)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move1 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)
+	move2 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[1])}, nil)
+	legalMoves := []model.Move{move1, move2}
+
+	release := make(chan time.Time)
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).WaitUntil(release).Return(move1, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Return(move2, nil).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+
+	// the source can't be interrupted, so it is not asked again while it is still working on the abandoned move
+	for i := 1; i <= 2; i++ {
+		_, err = e.ChooseNextMove(turn)
+		assert.NoError(t, err)
+		assert.Equal(t, i, character.Timeouts())
+		input.AssertNumberOfCalls(t, "ChooseMove", 1)
+	}
+
+	// once it returns, its stale result is ignored, and it is asked for the move again
+	close(release)
+	e.SetMoveTimeout(time.Minute, nil)
+	move, err := e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.Same(t, move2, move)
+	assert.Equal(t, 2, character.Timeouts())
+	input.AssertNumberOfCalls(t, "ChooseMove", 2)
+}
+
+func TestEngineChooseNextMoveAbandonedSharedSource(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &blockingSource{started: make(chan struct{}), release: make(chan struct{})}
+	e := createEngine(model.StandardMode, &evaluator, input) // both characters share the same source
+	e.SetMoveTimeout(time.Minute, nil)                       // so that the source, which does not accept a context, can be interrupted
+	startGame(e)
+
+	red := e.ColorMap()[model.Red]
+	yellow := e.ColorMap()[model.Yellow]
+	card1 := model.NewCard("1", model.Card1)
+	card2 := model.NewCard("2", model.Card1)
+	redMoves := []model.Move{model.NewMove(card1, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)}
+	yellowMoves := []model.Move{model.NewMove(card2, []model.Action{actionStart(e.Game().Players()[model.Yellow].Pawns()[0])}, nil)}
+
+	configureDrawCards(e, card1, card2) // so there is a card to draw for each turn, in either order
+	evaluator.On("ConstructLegalMoves", mock.Anything, mock.Anything).Return(redMoves, nil).Once()
+	evaluator.On("ConstructLegalMoves", mock.Anything, mock.Anything).Return(yellowMoves, nil).Once()
+
+	// the call for the first character is abandoned once the source is working on it
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-input.started
+		cancel()
+	}()
+
+	turn, err := e.StartTurn(red)
+	assert.NoError(t, err)
+	_, err = e.ChooseNextMoveContext(ctx, turn)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), input.calls.Load())
+
+	// the source is still working on the move abandoned for the other character, so it is not asked again
+	e.SetMoveTimeout(10*time.Millisecond, nil)
+	turn, err = e.StartTurn(yellow)
+	assert.NoError(t, err)
+	_, err = e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, yellow.Timeouts())
+	assert.Equal(t, int32(1), input.calls.Load())
+
+	// once the abandoned call returns, the source is asked for the other character's move
+	close(input.release)
+	e.SetMoveTimeout(time.Minute, nil)
+	move, err := e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.Same(t, yellowMoves[0], move)
+	assert.Equal(t, int32(2), input.calls.Load())
+}
+
+func TestEngineChooseNextMoveAbandonedSourceCancelled(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	e.SetMoveTimeout(10*time.Millisecond, nil)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move1 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)
+	move2 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[1])}, nil)
+	legalMoves := []model.Move{move1, move2}
+
+	release := make(chan time.Time)
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).WaitUntil(release).Return(move1, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Return(move2, nil).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+	_, err = e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+
+	// without a time limit, waiting on the abandoned call still gives up once the context is done
+	e.SetMoveTimeout(0, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = e.ChooseNextMoveContext(ctx, turn)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	input.AssertNumberOfCalls(t, "ChooseMove", 1)
+
+	// the abandoned call is still waited on once it returns, before the source is asked again
+	close(release)
+	move, err := e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.Same(t, move2, move)
+	input.AssertNumberOfCalls(t, "ChooseMove", 2)
+}
+
+func TestEngineChooseNextMoveTimeoutRandom(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockCharacterInputSource{}
+	fallback := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move1 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)
+	move2 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[1])}, nil)
+	illegal := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[2])}, nil)
+	legalMoves := []model.Move{move1, move2}
+
+	release := make(chan time.Time)
+	defer close(release)
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).WaitUntil(release).Return(move1, nil)
+	fallback.On("Name").Return("fallback")
+	fallback.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Return(illegal, nil).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+
+	// without a fallback, a random legal move is chosen
+	e.SetMoveTimeout(10*time.Millisecond, nil)
+	move, err := e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.True(t, move == move1 || move == move2)
+	assert.Equal(t, 1, character.Timeouts())
+	history := e.Game().History()[len(e.Game().History())-1]
+	assert.Equal(t, "Move timed out for character1 after 10ms; executing a random legal move instead", history.Action())
+
+	// a fallback that chooses an illegal move is replaced by a random legal move, without blaming the character
+	e.SetMoveTimeout(10*time.Millisecond, fallback)
+	move, err = e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+	assert.True(t, move == move1 || move == move2)
+	assert.Equal(t, 2, character.Timeouts())
+	assert.Equal(t, 0, character.Violations())
+}
+
+func TestEngineChooseNextMoveContextTimeout(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockContextInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	e.SetMoveTimeout(10*time.Millisecond, nil)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move1 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)
+	move2 := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[1])}, nil)
+	legalMoves := []model.Move{move1, move2}
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMoveContext", mock.Anything, model.StandardMode, mock.Anything, legalMoves).
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+		Return(nil, context.DeadlineExceeded).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+
+	// a source that accepts a context gives up when the time limit expires, which counts as a timeout
+	move, err := e.ChooseNextMoveContext(context.Background(), turn)
+	assert.NoError(t, err)
+	assert.True(t, move == move1 || move == move2)
+	assert.Equal(t, 1, character.Timeouts())
+	input.AssertExpectations(t)
+}
+
+func TestEngineChooseNextMoveContextCancelled(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	e.SetMoveTimeout(time.Minute, nil)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)
+	legalMoves := []model.Move{move}
+
+	release := make(chan time.Time)
+	defer close(release)
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).WaitUntil(release).Return(move, nil).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+
+	// cancellation is an error rather than a timeout, and no move is chosen
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = e.ChooseNextMoveContext(ctx, turn)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, turn.Move())
+	assert.Equal(t, 0, character.Timeouts())
+}
+
+func TestEngineChooseNextMoveContextNoTimeout(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	startGame(e)
+
+	character := e.ColorMap()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move := model.NewMove(card, []model.Action{actionStart(e.Game().Players()[model.Red].Pawns()[0])}, nil)
+	legalMoves := []model.Move{move}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Return(move, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Run(func(_ mock.Arguments) { cancel() }).Return(move, nil).Once()
+
+	turn, err := e.StartTurn(character)
+	assert.NoError(t, err)
+
+	// without a time limit, the source is simply called, even though the context could be cancelled
+	result, err := e.ChooseNextMoveContext(ctx, turn)
+	assert.NoError(t, err)
+	assert.Same(t, move, result)
+
+	// and the context is checked once the source returns, so a move chosen after cancellation is not used
+	_, err = e.ChooseNextMoveContext(ctx, turn)
+	assert.ErrorIs(t, err, context.Canceled)
+	input.AssertNumberOfCalls(t, "ChooseMove", 2)
+}
+
+func TestEngineExecuteMoveInvalidTurn(t *testing.T) {
+	e := createEngine(model.StandardMode, nil, nil)
+
+	_, err := e.ExecuteMove(nil)
+	assert.EqualError(t, err, "turn was not started by the engine")
+
+	_, err = e.ExecuteMove(&MockTurn{})
+	assert.EqualError(t, err, "turn was not started by the engine")
+
+	_, err = e.ExecuteMove(newTurn(e.ColorMap()[model.Red], nil, nil))
+	assert.EqualError(t, err, "move is nil")
+}
+
+func TestEngineExecuteMoveStandardWrongCard(t *testing.T) {
+	e := createEngine(model.StandardMode, nil, nil)
+	startGame(e)
+
+	drawcard := model.NewCard("1", model.Card1)
+	othercard := model.NewCard("2", model.Card2)
+
+	// a move that plays a card other than the one that was drawn is rejected, so the drawn card can't leak
+	turn := newTurn(e.ColorMap()[model.Red], nil, drawcard)
+	turn.move = model.NewMove(othercard, nil, nil)
+
+	_, err := e.ExecuteMove(turn)
+	assert.EqualError(t, err, "move does not use the card drawn for the turn")
+	assert.Equal(t, 0, len(turn.Discarded()))
+}
+
+func TestEngineCheckDiscards(t *testing.T) {
+	e := createEngine(model.StandardMode, nil, nil).(*engine)
+
+	drawcard := model.NewCard("1", model.Card1)
+	othercard := model.NewCard("2", model.Card2)
+
+	turn := newTurn(e.ColorMap()[model.Red], nil, drawcard)
+	turn.move = model.NewMove(drawcard, nil, nil)
+	assert.EqualError(t, e.checkDiscards(turn), "card leak: card 1 was played but 0 card(s) were discarded")
+
+	turn.discarded = []model.Card{othercard}
+	assert.EqualError(t, e.checkDiscards(turn), "card leak: card 1 was played but 1 card(s) were discarded")
+
+	turn.discarded = []model.Card{drawcard, drawcard}
+	assert.EqualError(t, e.checkDiscards(turn), "card leak: card 1 was played but 2 card(s) were discarded")
+
+	turn.discarded = []model.Card{drawcard}
+	assert.NoError(t, e.checkDiscards(turn))
+
+	// a card that went missing from the deck is a leak
+	leaked, _ := e.Draw()
+	assert.EqualError(t, e.checkDiscards(turn), "card leak: expected 45 cards but found 44")
+
+	// and so is a card that is in two places at once
+	_ = e.Discard(leaked)
+	e.Game().Players()[model.Yellow].AppendToHand(leaked)
+	assert.EqualError(t, e.checkDiscards(turn), fmt.Sprintf("card leak: card %s is in more than one place", leaked.Id()))
+}
+
+func TestEngineExecuteMoveCorruptedDeck(t *testing.T) {
+	evaluator := rules.MockRules{}
+	input := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, &evaluator, input)
+	startGame(e)
+
+	card := model.NewCard("1", model.Card1)
+	move := model.NewMove(card, nil, nil)
+	legalMoves := []model.Move{move}
+
+	configureDrawCards(e, card) // so we know exactly which card will be drawn
+	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Return(move, nil).Once()
+
+	// rules that lose a card from a player's hand while executing the move are caught, even though the card in play was discarded
+	evaluator.On("ExecuteMove", e.Game(), e.Game().Players()[model.Red], move).Run(func(_ mock.Arguments) {
+		e.Game().Players()[model.Yellow].RemoveFromHand(e.Game().Players()[model.Yellow].Hand()[0])
+	}).Return(nil).Once()
+
+	turn, err := e.StartTurn(e.ColorMap()[model.Red])
+	assert.NoError(t, err)
+	_, err = e.ChooseNextMove(turn)
+	assert.NoError(t, err)
+
+	_, err = e.ExecuteMove(turn)
+	assert.EqualError(t, err, "card leak: expected 45 cards but found 44")
+	assert.Equal(t, []model.Card{card}, turn.Discarded())
+}
+
 func TestEnginePlayNextCompleted(t *testing.T) {
 	e := createEngine(model.AdultMode, nil, nil)
 
@@ -243,11 +907,15 @@ func TestEnginePlayNextStandardForfeit(t *testing.T) {
 	configureDrawCards(e, card) // so we know exactly which card will be drawn
 	evaluator.On("ConstructLegalMoves", mock.Anything, card).Return(legalMoves, nil).Once()
 	input.On("ChooseMove", model.StandardMode, mock.Anything, legalMoves).Return(move, nil).Once()
+	evaluator.On("ExecuteMove", e.Game(), e.Game().Players()[model.Red], move).Return(nil).Once()
 
 	game, err := e.PlayNext()
 	assert.NoError(t, err)
 	assert.Same(t, e.Game(), game)
 
+	// the forfeit is executed (and tracked) by the rules like any other move
+	evaluator.AssertCalled(t, "ExecuteMove", e.Game(), e.Game().Players()[model.Red], move)
+
 	c, _ := e.Draw()
 	assert.Same(t, card, c) // confirm that the card was discarded back to the deck
 }
@@ -326,6 +994,7 @@ func TestEnginePlayNextAdultForfeit(t *testing.T) {
 	configureDrawCards(e, replacementcard) // so we know exactly which card will be drawn
 	evaluator.On("ConstructLegalMoves", mock.Anything, nil).Return(legalMoves, nil).Once()
 	input.On("ChooseMove", model.AdultMode, mock.Anything, legalMoves).Return(move, nil).Once()
+	evaluator.On("ExecuteMove", e.Game(), player, move).Return(nil).Once()
 
 	game, err := e.PlayNext()
 	assert.NoError(t, err)
@@ -366,6 +1035,7 @@ func TestEnginePlayNextAdultNoDrawAgain(t *testing.T) {
 	configureDrawCards(e, replacementcard) // so we know exactly which card will be drawn
 	evaluator.On("ConstructLegalMoves", mock.Anything, nil).Return(legalMoves, nil).Once()
 	input.On("ChooseMove", model.AdultMode, mock.Anything, legalMoves).Return(move, nil).Once()
+	evaluator.On("ExecuteMove", e.Game(), player, move).Return(nil).Once()
 	evaluator.On("DrawAgain", movecard).Return(false).Once()
 	evaluator.On("ExecuteMove", e.Game(), player, move).Return(nil).Once()
 
@@ -428,6 +1098,267 @@ func TestEnginePlayNextAdultDrawAgain(t *testing.T) {
 	evaluator.AssertCalled(t, "ExecuteMove", e.Game(), player, move2)
 }
 
+func TestEnginePlaySeeded(t *testing.T) {
+	for _, mode := range model.GameModes.Members() {
+		// the same seed always results in exactly the same game
+		actions1, winner1 := playSeededGame(t, mode, 42)
+		actions2, winner2 := playSeededGame(t, mode, 42)
+		assert.Equal(t, actions1, actions2)
+		assert.Equal(t, winner1, winner2)
+	}
+}
+
+func TestEnginePlayReshuffledMonteCarlo(t *testing.T) {
+	randomizer := random.NewSeededRandomizer(29)
+	input := source.MonteCarloInputSource(&source.MonteCarloConfig{Iterations: 5, PlayoutTurns: 10}, nil, randomizer)
+	characters := []Character{NewCharacter("character1", input), NewCharacter("character2", input)}
+
+	e, err := NewEngine(model.StandardMode, characters, nil, randomizer)
+	assert.NoError(t, err)
+
+	_, err = e.StartGame()
+	assert.NoError(t, err)
+
+	// the game runs through several reshuffles of the discard pile, each of which draws a card that was discarded
+	for !e.Completed() && e.Game().Turn() <= 5*model.DeckSize {
+		_, err = e.PlayNext()
+		assert.NoError(t, err)
+		if err != nil {
+			break
+		}
+	}
+
+	assert.Greater(t, e.Game().Turn(), model.DeckSize)
+}
+
+func TestEnginePlayNextContextCancelled(t *testing.T) {
+	input := &source.MockCharacterInputSource{}
+	e := createEngine(model.StandardMode, nil, input)
+	e.SetMoveTimeout(time.Minute, nil) // so that the source, which does not accept a context, can be interrupted
+	startGame(e)
+
+	release := make(chan time.Time)
+	defer close(release)
+
+	input.On("ChooseMove", model.StandardMode, mock.Anything, mock.Anything).WaitUntil(release).Return(nil, nil).Once()
+
+	before := gameJSON(t, e.Game())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// the cancelled turn is abandoned, leaving the game as it was before the turn started
+	_, err := e.PlayNextContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, before, gameJSON(t, e.Game()))
+
+	// and the same player is up next when the turn is played again
+	next, _ := e.NextTurn()
+	assert.Equal(t, model.Red, next.Color())
+
+	// a context that is already done abandons the turn before it starts
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	_, err = e.PlayNextContext(cancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, before, gameJSON(t, e.Game()))
+	input.AssertNumberOfCalls(t, "ChooseMove", 1)
+}
+
+func TestEnginePlayNextContextCancelledSeeded(t *testing.T) {
+	// a seeded game with a turn cancelled partway through plays out exactly the same as a game without it
+	expected, expectedCounters := playCancelledGame(t, false)
+	actual, actualCounters := playCancelledGame(t, true)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, expectedCounters, actualCounters)
+}
+
+func TestEnginePlayNextCheckpoint(t *testing.T) {
+	input := &source.MockCharacterInputSource{}
+	evaluator := &checkpointRules{Rules: rules.NewRules(nil)}
+	e := createEngine(model.StandardMode, evaluator, input)
+	_, _ = e.StartGame()
+
+	input.On("ChooseMove", model.StandardMode, mock.Anything, mock.Anything).Return(nil, errors.New("hello")).Once()
+	input.On("ChooseMove", model.StandardMode, mock.Anything, mock.Anything).Return(func(_ model.GameMode, _ model.PlayerView, moves []model.Move) (model.Move, error) {
+		return moves[0], nil
+	})
+
+	// the evaluator's state is restored along with the game when a turn fails, but not otherwise
+	_, err := e.PlayNext()
+	assert.EqualError(t, err, "hello")
+	assert.Equal(t, 1, evaluator.restored)
+
+	_, err = e.PlayNext()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, evaluator.restored)
+}
+
+func TestEnginePlayNextTracksTurns(t *testing.T) {
+	randomizer := random.NewSeededRandomizer(42)
+	input := source.RandomInputSource(randomizer)
+	characters := []Character{NewCharacter("character1", input), NewCharacter("character2", input)}
+
+	e, _ := NewEngine(model.StandardMode, characters, nil, randomizer)
+	_, _ = e.StartGame()
+	for i := 0; i < 20; i++ {
+		_, err := e.PlayNext()
+		assert.NoError(t, err)
+	}
+
+	// each play is its own turn, and every play (including a forfeit) is tracked along with its move
+	assert.GreaterOrEqual(t, e.Game().Turn(), 20)
+	assert.Equal(t, 0, e.Game().History()[0].Turn())
+	played := make(map[int]bool)
+	for _, history := range e.Game().History()[1:] {
+		assert.NotNil(t, history.Move())
+		assert.Equal(t, history.Move().Card().Type(), *history.Card())
+		played[history.Turn()] = true
+	}
+	for turn := 1; turn <= e.Game().Turn(); turn++ {
+		assert.True(t, played[turn])
+	}
+}
+
+func TestEngineSaveAndResume(t *testing.T) {
+	randomizer := random.NewSeededRandomizer(42)
+	input := source.RandomInputSource(randomizer)
+	characters := []Character{NewCharacter("character1", input), NewCharacter("character2", input), NewCharacter("character3", input)}
+
+	e, err := NewEngine(model.AdultMode, characters, nil, randomizer)
+	assert.NoError(t, err)
+	_, err = e.StartGame()
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		_, err = e.PlayNext()
+		assert.NoError(t, err)
+	}
+	characters[1].IncrementViolations()
+	characters[2].IncrementTimeouts()
+
+	marshalled, err := json.Marshal(e)
+	assert.NoError(t, err)
+	restored, err := NewEngineFromJSON(bytes.NewReader(marshalled), nil, nil, random.NewSeededRandomizer(42))
+	assert.NoError(t, err)
+
+	assert.Equal(t, e.Mode(), restored.Mode())
+	assert.Equal(t, e.First(), restored.First())
+	assert.Equal(t, e.Players(), restored.Players())
+	assert.Equal(t, e.State(), restored.State())
+	assert.Equal(t, gameJSON(t, e.Game()), gameJSON(t, restored.Game()))
+
+	for i, c := range restored.Characters() {
+		assert.Equal(t, characters[i].Name(), c.Name())
+		assert.Equal(t, characters[i].Color(), c.Color())
+		assert.Equal(t, characters[i].Violations(), c.Violations())
+		assert.Equal(t, characters[i].Timeouts(), c.Timeouts())
+		assert.Equal(t, "RandomInputSource", c.Source().Name())
+		assert.Same(t, c, restored.ColorMap()[c.Color()])
+	}
+
+	// the restored engine resumes exactly at the next player's turn
+	next, _ := e.NextTurn()
+	resumed, _ := restored.NextTurn()
+	assert.Equal(t, next.Color(), resumed.Color())
+
+	for !restored.Completed() {
+		_, err = restored.PlayNext()
+		assert.NoError(t, err)
+		assert.NoError(t, model.ValidateGame(restored.Game()))
+	}
+}
+
+func TestEngineSaveSourceSettings(t *testing.T) {
+	configured := source.ExpectimaxInputSource(&source.ExpectimaxConfig{Depth: 1}, nil)
+	e := createEngine(model.StandardMode, nil, configured)
+	marshalled, err := json.Marshal(e)
+	assert.NoError(t, err)
+
+	// only the name of the source is saved, so the resumed source comes from the registry without the saved settings
+	resumed := source.ExpectimaxInputSource(nil, nil)
+	registry := &source.MockRegistry{}
+	registry.On("Lookup", "ExpectimaxInputSource", mock.Anything).Return(resumed, nil)
+
+	restored, err := NewEngineFromJSON(bytes.NewReader(marshalled), registry, nil, nil)
+	assert.NoError(t, err)
+	for _, c := range restored.Characters() {
+		assert.Same(t, resumed, c.Source())
+	}
+}
+
+func TestEngineSaveNoSource(t *testing.T) {
+	characters := []Character{NewCharacter("character1", nil), NewCharacter("character2", nil)}
+	e, _ := NewEngine(model.StandardMode, characters, nil, nil)
+	_, err := json.Marshal(e)
+	assert.ErrorContains(t, err, "character character1 has no input source")
+}
+
+func TestNewEngineFromJSONErrors(t *testing.T) {
+	e := createEngine(model.StandardMode, nil, source.RandomInputSource(nil))
+	marshalled, _ := json.Marshal(e)
+
+	_, err := NewEngineFromJSON(bytes.NewReader([]byte("{")), nil, nil, nil)
+	assert.Error(t, err)
+
+	registry := &source.MockRegistry{}
+	registry.On("Lookup", "RandomInputSource", mock.Anything).Return(nil, errors.New("unknown source RandomInputSource"))
+	_, err = NewEngineFromJSON(bytes.NewReader(marshalled), registry, nil, nil)
+	assert.EqualError(t, err, "unknown source RandomInputSource")
+
+	edited := editSnapshot(t, marshalled, func(raw map[string]any) {
+		raw["characters"] = raw["characters"].([]any)[0:1]
+	})
+	_, err = NewEngineFromJSON(bytes.NewReader(edited), nil, nil, nil)
+	assert.EqualError(t, err, "expected 2 characters but found 1")
+
+	edited = editSnapshot(t, marshalled, func(raw map[string]any) {
+		raw["characters"].([]any)[1].(map[string]any)["color"] = "Blue"
+	})
+	_, err = NewEngineFromJSON(bytes.NewReader(edited), nil, nil, nil)
+	assert.EqualError(t, err, "character character2 has unexpected color Blue")
+
+	edited = editSnapshot(t, marshalled, func(raw map[string]any) {
+		raw["next"] = "Blue"
+	})
+	_, err = NewEngineFromJSON(bytes.NewReader(edited), nil, nil, nil)
+	assert.EqualError(t, err, "entry not found")
+
+	edited = editSnapshot(t, marshalled, func(raw map[string]any) {
+		raw["game"].(map[string]any)["playercount"] = 3
+	})
+	_, err = NewEngineFromJSON(bytes.NewReader(edited), nil, nil, nil)
+	assert.ErrorContains(t, err, "invalid game: InvalidPlayers: expected 3 players but found 2")
+
+	edited = editSnapshot(t, marshalled, func(raw map[string]any) {
+		delete(raw, "mode")
+	})
+	_, err = NewEngineFromJSON(bytes.NewReader(edited), nil, nil, nil)
+	assert.EqualError(t, err, "saved engine has no mode")
+
+	edited = editSnapshot(t, marshalled, func(raw map[string]any) {
+		raw["mode"] = "Silly"
+	})
+	_, err = NewEngineFromJSON(bytes.NewReader(edited), nil, nil, nil)
+	assert.Error(t, err)
+
+	edited = editSnapshot(t, marshalled, func(raw map[string]any) {
+		delete(raw, "first")
+	})
+	_, err = NewEngineFromJSON(bytes.NewReader(edited), nil, nil, nil)
+	assert.EqualError(t, err, `saved engine has invalid first player ""`)
+
+	edited = editSnapshot(t, marshalled, func(raw map[string]any) {
+		raw["first"] = "Blue"
+	})
+	_, err = NewEngineFromJSON(bytes.NewReader(edited), nil, nil, nil)
+	assert.EqualError(t, err, `saved engine has invalid first player "Blue"`)
+
+	edited = editSnapshot(t, marshalled, func(raw map[string]any) {
+		raw["first"] = "Purple"
+	})
+	_, err = NewEngineFromJSON(bytes.NewReader(edited), nil, nil, nil)
+	assert.Error(t, err)
+}
+
 // createEngine creates an engine for testing, to avoid boilerplate in other methods
 // a nil evaluator gets you a real rule.Rules implementation, otherwise pass in a rules.MockRules
 // a nil input source gets you an unreachable mock input source, otherwise pass in a source of your choice
@@ -440,27 +1371,198 @@ func createEngine(mode model.GameMode, evaluator rules.Rules, input source.Chara
 	character2 := NewCharacter("character2", input)
 	characters := []Character{character1, character2}
 
-	e, _ := NewEngine(mode, characters, evaluator)
+	e, _ := NewEngine(mode, characters, evaluator, nil)
 	_ = e.SetFirst(model.Red)
 
 	return e
 }
 
-// configureDrawCards configures the deck with one or more cards in it to be drawn
+// playSeededGame plays a complete game with a seeded randomizer, returning the history actions and the winner
+func playSeededGame(t *testing.T, mode model.GameMode, seed int64) ([]string, model.PlayerColor) {
+	randomizer := random.NewSeededRandomizer(seed)
+	input := source.RandomInputSource(randomizer)
+	characters := []Character{NewCharacter("character1", input), NewCharacter("character2", input), NewCharacter("character3", input)}
+
+	e, err := NewEngine(mode, characters, nil, randomizer)
+	assert.NoError(t, err)
+
+	_, err = e.StartGame()
+	assert.NoError(t, err)
+
+	for !e.Completed() {
+		_, err = e.PlayNext()
+		assert.NoError(t, err)
+		assert.NoError(t, model.ValidateGame(e.Game())) // invariants hold after every turn
+	}
+
+	actions := make([]string, 0, len(e.Game().History()))
+	for _, history := range e.Game().History() {
+		actions = append(actions, history.Action())
+	}
+
+	return actions, e.Winner().Color()
+}
+
+// playCancelledGame plays a seeded game, optionally cancelling one turn after a character has timed out and the
+// fallback has used the randomizer, returning the history actions along with each character's violations and timeouts
+func playCancelledGame(t *testing.T, cancelTurn bool) ([]string, []int) {
+	randomizer := random.NewSeededRandomizer(17)
+	input := &stallingSource{CharacterInputSource: source.RandomInputSource(randomizer)}
+	characters := []Character{NewCharacter("character1", input), NewCharacter("character2", input)}
+
+	e, err := NewEngine(model.StandardMode, characters, nil, randomizer)
+	assert.NoError(t, err)
+	_, err = e.StartGame()
+	assert.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err = e.PlayNext()
+		assert.NoError(t, err)
+	}
+
+	if cancelTurn {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		input.stall.Store(true)
+		e.SetMoveTimeout(time.Millisecond, &cancellingSource{randomizer: randomizer, cancel: cancel})
+		_, err = e.PlayNextContext(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		input.stall.Store(false)
+		e.SetMoveTimeout(0, nil)
+	}
+
+	for !e.Completed() {
+		_, err = e.PlayNext()
+		assert.NoError(t, err)
+	}
+
+	actions := make([]string, 0, len(e.Game().History()))
+	for _, history := range e.Game().History() {
+		actions = append(actions, history.Action())
+	}
+
+	counters := make([]int, 0, 2*len(characters))
+	for _, c := range characters {
+		counters = append(counters, c.Violations(), c.Timeouts())
+	}
+
+	return actions, counters
+}
+
+// blockingSource chooses the first legal move, blocking on its first call until it is released
+type blockingSource struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSource) Name() string {
+	return "blocking"
+}
+
+func (s *blockingSource) ChooseMove(_ model.GameMode, _ model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	if s.calls.Add(1) == 1 {
+		close(s.started)
+		<-s.release
+	}
+
+	return legalMoves[0], nil
+}
+
+// stallingSource wraps an input source, optionally stalling until its context is done instead of choosing a move
+type stallingSource struct {
+	source.CharacterInputSource
+	stall atomic.Bool
+}
+
+func (s *stallingSource) ChooseMoveContext(ctx context.Context, mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	if s.stall.Load() {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	return s.ChooseMove(mode, view, legalMoves)
+}
+
+// cancellingSource uses the randomizer and then cancels a context, like a fallback that is interrupted partway through
+type cancellingSource struct {
+	randomizer random.Randomizer
+	cancel     context.CancelFunc
+}
+
+func (s *cancellingSource) Name() string {
+	return "cancelling"
+}
+
+func (s *cancellingSource) ChooseMove(_ model.GameMode, _ model.PlayerView, _ []model.Move) (model.Move, error) {
+	for i := 0; i < 5; i++ {
+		_, _ = s.randomizer.Int(100)
+	}
+
+	s.cancel()
+	return nil, context.Canceled
+}
+
+// gameJSON serializes a game, for comparing games whose decks use different randomizers
+func gameJSON(t *testing.T, game model.Game) string {
+	marshalled, err := json.Marshal(game)
+	assert.NoError(t, err)
+	return string(marshalled)
+}
+
+// editSnapshot edits a saved engine as generic JSON, to simulate a corrupted or hand-edited save file
+func editSnapshot(t *testing.T, marshalled []byte, edit func(raw map[string]any)) []byte {
+	var raw map[string]any
+	assert.NoError(t, json.Unmarshal(marshalled, &raw))
+	edit(raw)
+	edited, err := json.Marshal(raw)
+	assert.NoError(t, err)
+	return edited
+}
+
+// configureDrawCards configures the deck with one or more cards in it to be drawn.
+// Each card replaces the deck's card with the same id (or some other card if there is none), and the cards that
+// are not to be drawn are held in Yellow's hand, so the full deck is still accounted for once a move is executed.
 func configureDrawCards(e Engine, drawcards ...model.Card) {
-	configureEmptyDeck(e)
+	held := make([]model.Card, 0, model.DeckSize)
+	for e.Game().Deck().DrawPileSize() > 0 {
+		card, _ := e.Draw()
+		held = append(held, card)
+	}
+
+	replaced := make(map[string]bool)
 	for _, drawcard := range drawcards {
+		if replaced[drawcard.Id()] {
+			continue
+		}
+		replaced[drawcard.Id()] = true
+
+		index := slices.IndexFunc(held, func(card model.Card) bool { return card.Id() == drawcard.Id() })
+		if index < 0 {
+			index = 0
+		}
+
+		held = slices.Delete(held, index, index+1)
 		_ = e.Discard(drawcard)
 	}
-}
 
-// configureEmptyDeck configures the deck with a single card in it to be drawn
-func configureEmptyDeck(e Engine) {
-	for i := 0; i < model.DeckSize; i++ {
-		_, _ = e.Draw()
+	for _, card := range held {
+		e.Game().Players()[model.Yellow].AppendToHand(card)
 	}
 }
 
+// checkpointRules is a rules evaluator that counts the number of times that its state is restored
+type checkpointRules struct {
+	rules.Rules
+	restored int
+}
+
+func (r *checkpointRules) Checkpoint() func() {
+	return func() { r.restored += 1 }
+}
+
 // Start a game using the real rules evaluator, for times when we can't call e.Start() because a mock is in use
 func startGame(e Engine) {
 	realRules := rules.NewRules(nil)
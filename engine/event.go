@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"github.com/pronovic/go-apologies/internal/enum"
+	"github.com/pronovic/go-apologies/model"
+)
+
+// EventType defines the types of events emitted by the engine
+type EventType struct{ value string }
+
+func (e EventType) Value() string                         { return e.value }
+func (e EventType) MarshalText() (text []byte, err error) { return enum.Marshal(e) }
+func (e *EventType) UnmarshalText(text []byte) error      { return enum.Unmarshal(e, text, EventTypes) }
+
+var (
+	EventTypes = enum.NewValues[EventType](
+		GameStarted, TurnStarted, CardDrawn, LegalMovesComputed, MoveChosen, ActionApplied,
+		PawnBumped, SlideTaken, TurnForfeited, DrawAgain, GameCompleted,
+	)
+	GameStarted        = EventType{"GameStarted"}
+	TurnStarted        = EventType{"TurnStarted"}
+	CardDrawn          = EventType{"CardDrawn"}
+	LegalMovesComputed = EventType{"LegalMovesComputed"}
+	MoveChosen         = EventType{"MoveChosen"}
+	ActionApplied      = EventType{"ActionApplied"}
+	PawnBumped         = EventType{"PawnBumped"}
+	SlideTaken         = EventType{"SlideTaken"}
+	TurnForfeited      = EventType{"TurnForfeited"}
+	DrawAgain          = EventType{"DrawAgain"}
+	GameCompleted      = EventType{"GameCompleted"}
+)
+
+// Event Something that happened in a game, delivered to listeners as soon as it happens.
+//
+// Which of the optional fields are filled in depends on the type of the event.  Every event except
+// GameStarted carries the color of the player whose turn it is, or the winner for GameCompleted.  Events
+// within a turn carry the card in play once it is known, and events about a move carry the move.
+// ActionApplied, PawnBumped and SlideTaken also carry the action, where the action's pawn is the pawn
+// that moved.  Every action in a move is reported as ActionApplied, and is then reported again as
+// PawnBumped or SlideTaken if that is what happened.  CardDrawn is emitted for the card drawn at the
+// start of a turn in standard mode, and for the replacement card drawn into the player's hand in adult
+// mode, so a listener that shares events with other players must take care not to reveal it.
+type Event struct {
+	// Type The type of the event
+	Type EventType
+
+	// Color The color of the player the event is about
+	Color *model.PlayerColor // optional
+
+	// Card The card in play, or the card that was drawn
+	Card model.Card // optional
+
+	// LegalMoves The legal moves, for LegalMovesComputed
+	LegalMoves []model.Move // optional
+
+	// Move The move that was chosen or executed
+	Move model.Move // optional
+
+	// Action The action that was applied
+	Action model.Action // optional
+
+	// Game The game, as of the event
+	Game model.Game
+}
+
+// Listener receives events from an engine.
+//
+// Listeners are called synchronously, in the order they subscribed, on the goroutine that is playing the
+// game, so a listener must not block and must not call back into the engine.  A turn that fails or is
+// cancelled is rolled back, but the events already emitted for it are not withdrawn; listeners will
+// simply see TurnStarted again when the turn is played again.
+type Listener func(event Event)
+
+// subscription is a listener registered with an engine
+type subscription struct {
+	id       int
+	listener Listener
+}
+
+func (e *engine) Subscribe(listener Listener) func() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.subscribed += 1
+	id := e.subscribed
+	e.listeners = append(e.listeners, subscription{id, listener})
+
+	return func() {
+		e.lock.Lock()
+		defer e.lock.Unlock()
+
+		for i, s := range e.listeners {
+			if s.id == id {
+				e.listeners = append(e.listeners[:i:i], e.listeners[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// emit delivers an event to every listener
+func (e *engine) emit(event Event) {
+	e.lock.Lock()
+	listeners := e.listeners
+	e.lock.Unlock()
+
+	event.Game = e.game
+	for _, s := range listeners {
+		s.listener(event)
+	}
+}
+
+// emitMove delivers the events for a move that was just executed
+func (e *engine) emitMove(color model.PlayerColor, card model.Card, move model.Move) {
+	if len(move.Actions()) == 0 {
+		e.emit(Event{Type: TurnForfeited, Color: &color, Card: card, Move: move})
+		return
+	}
+
+	for _, action := range move.MergedActions() {
+		e.emit(Event{Type: ActionApplied, Color: &color, Card: card, Move: move, Action: action})
+
+		// no card lets a player move their own pawn back to start, so that only happens by being bumped
+		if action.Type() == model.MoveToStart {
+			e.emit(Event{Type: PawnBumped, Color: &color, Card: card, Move: move, Action: action})
+		} else if action.Slid() {
+			e.emit(Event{Type: SlideTaken, Color: &color, Card: card, Move: move, Action: action})
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEventTypeJSON(t *testing.T) {
+	for _, eventType := range EventTypes.Members() {
+		marshalled, err := json.Marshal(eventType)
+		assert.NoError(t, err)
+
+		var unmarshalled EventType
+		err = json.Unmarshal(marshalled, &unmarshalled)
+		assert.NoError(t, err)
+		assert.Equal(t, eventType, unmarshalled)
+	}
+}
+
+func TestEngineSubscribeStandard(t *testing.T) {
+	randomizer := random.NewSeededRandomizer(42)
+	input := source.RandomInputSource(randomizer)
+	characters := []Character{NewCharacter("character1", input), NewCharacter("character2", input)}
+	e, _ := NewEngine(model.StandardMode, characters, nil, randomizer)
+
+	events := make([]Event, 0)
+	e.Subscribe(func(event Event) { events = append(events, event) })
+
+	_, err := e.PlayToCompletion(CompletionOptions{})
+	assert.NoError(t, err)
+
+	counts := make(map[EventType]int)
+	for _, event := range events {
+		counts[event.Type] += 1
+		assert.Same(t, e.Game(), event.Game)
+		if event.Type != GameStarted {
+			assert.NotNil(t, event.Color)
+		}
+	}
+
+	// every play is a turn that starts with a card being drawn, and every turn ends with exactly one outcome
+	assert.Equal(t, GameStarted, events[0].Type)
+	assert.Equal(t, GameCompleted, events[len(events)-1].Type)
+	assert.Equal(t, *e.Game().Winner(), e.Game().Players()[*events[len(events)-1].Color])
+	assert.Equal(t, 1, counts[GameStarted])
+	assert.Equal(t, 1, counts[GameCompleted])
+	assert.Equal(t, e.Game().Turn(), counts[TurnStarted])
+	assert.Equal(t, counts[TurnStarted], counts[CardDrawn])
+	assert.Equal(t, counts[TurnStarted], counts[LegalMovesComputed])
+	assert.Equal(t, counts[TurnStarted], counts[MoveChosen])
+	assert.Greater(t, counts[ActionApplied], 0)
+	assert.Greater(t, counts[PawnBumped], 0)
+	assert.Greater(t, counts[SlideTaken], 0)
+	assert.Greater(t, counts[DrawAgain], 0)
+
+	// each turn follows the same sequence of steps
+	assert.Equal(t, TurnStarted, events[1].Type)
+	assert.Equal(t, CardDrawn, events[2].Type)
+	assert.Equal(t, LegalMovesComputed, events[3].Type)
+	assert.Same(t, events[2].Card, events[3].Card)
+	assert.NotEmpty(t, events[3].LegalMoves)
+	assert.Equal(t, MoveChosen, events[4].Type)
+	assert.Contains(t, events[3].LegalMoves, events[4].Move)
+}
+
+func TestEngineSubscribeAdult(t *testing.T) {
+	randomizer := random.NewSeededRandomizer(42)
+	input := source.RandomInputSource(randomizer)
+	characters := []Character{NewCharacter("character1", input), NewCharacter("character2", input)}
+	e, _ := NewEngine(model.AdultMode, characters, nil, randomizer)
+	_, _ = e.StartGame()
+
+	events := make([]Event, 0)
+	e.Subscribe(func(event Event) { events = append(events, event) })
+
+	_, err := e.PlayNext()
+	assert.NoError(t, err)
+
+	// in adult mode, the card in play comes from the hand and a replacement card is drawn after the move
+	assert.Equal(t, TurnStarted, events[0].Type)
+	assert.Equal(t, LegalMovesComputed, events[1].Type)
+	assert.Nil(t, events[1].Card)
+	assert.Equal(t, MoveChosen, events[2].Type)
+
+	drawn := 0
+	for _, event := range events {
+		if event.Type == CardDrawn {
+			drawn += 1
+			assert.Contains(t, e.Game().Players()[*event.Color].Hand(), event.Card)
+		}
+	}
+	assert.Equal(t, e.Game().Turn(), drawn)
+}
+
+func TestEngineUnsubscribe(t *testing.T) {
+	e := createEngine(model.AdultMode, nil, nil)
+
+	first := &MockListener{}
+	second := &MockListener{}
+	first.On("Execute", mock.Anything).Return().Once()
+	second.On("Execute", mock.Anything).Return()
+
+	unsubscribe := e.Subscribe(first.Execute)
+	e.Subscribe(second.Execute)
+
+	_, _ = e.StartGame()
+	unsubscribe()
+	unsubscribe() // a second call is harmless
+	_, _ = e.Reset()
+	_, _ = e.StartGame()
+
+	first.AssertNumberOfCalls(t, "Execute", 1)
+	second.AssertNumberOfCalls(t, "Execute", 2)
+}
+
+func TestEngineEmitMove(t *testing.T) {
+	e := createEngine(model.StandardMode, nil, nil)
+
+	events := make([]Event, 0)
+	e.Subscribe(func(event Event) { events = append(events, event) })
+
+	red := e.Game().Players()[model.Red].Pawns()
+	yellow := e.Game().Players()[model.Yellow].Pawns()
+	card := model.NewCard("1", model.Card1)
+	impl := e.(*engine)
+
+	// a forfeit is reported on its own
+	forfeit := model.NewMove(card, nil, nil)
+	impl.emitMove(model.Red, card, forfeit)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, TurnForfeited, events[0].Type)
+	assert.Equal(t, model.Red, *events[0].Color)
+	assert.Same(t, forfeit, events[0].Move)
+
+	// every action is applied, and bumps and slides are reported as well
+	slide := actionPosition(red[0])
+	slide.SetSlid(true)
+	bump := actionStart(yellow[1])
+	move := model.NewMove(card, []model.Action{slide}, []model.Action{bump})
+	events = events[:0]
+	impl.emitMove(model.Red, card, move)
+	types := make([]EventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+		assert.Same(t, move, event.Move)
+		assert.Same(t, card, event.Card)
+	}
+	assert.Equal(t, []EventType{ActionApplied, SlideTaken, ActionApplied, PawnBumped}, types)
+	assert.Same(t, slide, events[1].Action)
+	assert.Same(t, bump, events[3].Action)
+}
@@ -3,6 +3,8 @@
 package engine
 
 import (
+	context "context"
+
 	model "github.com/pronovic/go-apologies/model"
 	mock "github.com/stretchr/testify/mock"
 
@@ -44,6 +46,36 @@ func (_m *MockCharacter) ChooseMove(mode model.GameMode, view model.PlayerView,
 	return r0, r1
 }
 
+// ChooseMoveContext provides a mock function with given fields: ctx, mode, view, legalMoves
+func (_m *MockCharacter) ChooseMoveContext(ctx context.Context, mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	ret := _m.Called(ctx, mode, view, legalMoves)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChooseMoveContext")
+	}
+
+	var r0 model.Move
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.GameMode, model.PlayerView, []model.Move) (model.Move, error)); ok {
+		return rf(ctx, mode, view, legalMoves)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.GameMode, model.PlayerView, []model.Move) model.Move); ok {
+		r0 = rf(ctx, mode, view, legalMoves)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.Move)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.GameMode, model.PlayerView, []model.Move) error); ok {
+		r1 = rf(ctx, mode, view, legalMoves)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Color provides a mock function with given fields:
 func (_m *MockCharacter) Color() model.PlayerColor {
 	ret := _m.Called()
@@ -62,6 +94,16 @@ func (_m *MockCharacter) Color() model.PlayerColor {
 	return r0
 }
 
+// IncrementTimeouts provides a mock function with given fields:
+func (_m *MockCharacter) IncrementTimeouts() {
+	_m.Called()
+}
+
+// IncrementViolations provides a mock function with given fields:
+func (_m *MockCharacter) IncrementViolations() {
+	_m.Called()
+}
+
 // Name provides a mock function with given fields:
 func (_m *MockCharacter) Name() string {
 	ret := _m.Called()
@@ -105,6 +147,42 @@ func (_m *MockCharacter) Source() source.CharacterInputSource {
 	return r0
 }
 
+// Timeouts provides a mock function with given fields:
+func (_m *MockCharacter) Timeouts() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Timeouts")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// Violations provides a mock function with given fields:
+func (_m *MockCharacter) Violations() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Violations")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // NewMockCharacter creates a new instance of MockCharacter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockCharacter(t interface {
@@ -3,8 +3,14 @@
 package engine
 
 import (
+	context "context"
+
 	model "github.com/pronovic/go-apologies/model"
 	mock "github.com/stretchr/testify/mock"
+
+	source "github.com/pronovic/go-apologies/source"
+
+	time "time"
 )
 
 // MockEngine is an autogenerated mock type for the Engine type
@@ -32,9 +38,9 @@ func (_m *MockEngine) Characters() []Character {
 	return r0
 }
 
-// ChooseNextMove provides a mock function with given fields: character, view
-func (_m *MockEngine) ChooseNextMove(character Character, view model.PlayerView) (model.Move, error) {
-	ret := _m.Called(character, view)
+// ChooseNextMove provides a mock function with given fields: current
+func (_m *MockEngine) ChooseNextMove(current Turn) (model.Move, error) {
+	ret := _m.Called(current)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ChooseNextMove")
@@ -42,19 +48,49 @@ func (_m *MockEngine) ChooseNextMove(character Character, view model.PlayerView)
 
 	var r0 model.Move
 	var r1 error
-	if rf, ok := ret.Get(0).(func(Character, model.PlayerView) (model.Move, error)); ok {
-		return rf(character, view)
+	if rf, ok := ret.Get(0).(func(Turn) (model.Move, error)); ok {
+		return rf(current)
+	}
+	if rf, ok := ret.Get(0).(func(Turn) model.Move); ok {
+		r0 = rf(current)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.Move)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(Turn) error); ok {
+		r1 = rf(current)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChooseNextMoveContext provides a mock function with given fields: ctx, current
+func (_m *MockEngine) ChooseNextMoveContext(ctx context.Context, current Turn) (model.Move, error) {
+	ret := _m.Called(ctx, current)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChooseNextMoveContext")
+	}
+
+	var r0 model.Move
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Turn) (model.Move, error)); ok {
+		return rf(ctx, current)
 	}
-	if rf, ok := ret.Get(0).(func(Character, model.PlayerView) model.Move); ok {
-		r0 = rf(character, view)
+	if rf, ok := ret.Get(0).(func(context.Context, Turn) model.Move); ok {
+		r0 = rf(ctx, current)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(model.Move)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(Character, model.PlayerView) error); ok {
-		r1 = rf(character, view)
+	if rf, ok := ret.Get(1).(func(context.Context, Turn) error); ok {
+		r1 = rf(ctx, current)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -101,42 +137,33 @@ func (_m *MockEngine) Completed() bool {
 }
 
 // ConstructLegalMoves provides a mock function with given fields: view, card
-func (_m *MockEngine) ConstructLegalMoves(view model.PlayerView, card model.Card) (model.Card, []model.Move, error) {
+func (_m *MockEngine) ConstructLegalMoves(view model.PlayerView, card model.Card) ([]model.Move, error) {
 	ret := _m.Called(view, card)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ConstructLegalMoves")
 	}
 
-	var r0 model.Card
-	var r1 []model.Move
-	var r2 error
-	if rf, ok := ret.Get(0).(func(model.PlayerView, model.Card) (model.Card, []model.Move, error)); ok {
+	var r0 []model.Move
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.PlayerView, model.Card) ([]model.Move, error)); ok {
 		return rf(view, card)
 	}
-	if rf, ok := ret.Get(0).(func(model.PlayerView, model.Card) model.Card); ok {
+	if rf, ok := ret.Get(0).(func(model.PlayerView, model.Card) []model.Move); ok {
 		r0 = rf(view, card)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(model.Card)
+			r0 = ret.Get(0).([]model.Move)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(model.PlayerView, model.Card) []model.Move); ok {
+	if rf, ok := ret.Get(1).(func(model.PlayerView, model.Card) error); ok {
 		r1 = rf(view, card)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]model.Move)
-		}
-	}
-
-	if rf, ok := ret.Get(2).(func(model.PlayerView, model.Card) error); ok {
-		r2 = rf(view, card)
-	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
 // Discard provides a mock function with given fields: card
@@ -187,9 +214,9 @@ func (_m *MockEngine) Draw() (model.Card, error) {
 	return r0, r1
 }
 
-// ExecuteMove provides a mock function with given fields: color, move
-func (_m *MockEngine) ExecuteMove(color model.PlayerColor, move model.Move) (bool, error) {
-	ret := _m.Called(color, move)
+// ExecuteMove provides a mock function with given fields: current
+func (_m *MockEngine) ExecuteMove(current Turn) (bool, error) {
+	ret := _m.Called(current)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExecuteMove")
@@ -197,17 +224,17 @@ func (_m *MockEngine) ExecuteMove(color model.PlayerColor, move model.Move) (boo
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(model.PlayerColor, model.Move) (bool, error)); ok {
-		return rf(color, move)
+	if rf, ok := ret.Get(0).(func(Turn) (bool, error)); ok {
+		return rf(current)
 	}
-	if rf, ok := ret.Get(0).(func(model.PlayerColor, model.Move) bool); ok {
-		r0 = rf(color, move)
+	if rf, ok := ret.Get(0).(func(Turn) bool); ok {
+		r0 = rf(current)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(model.PlayerColor, model.Move) error); ok {
-		r1 = rf(color, move)
+	if rf, ok := ret.Get(1).(func(Turn) error); ok {
+		r1 = rf(current)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -331,6 +358,92 @@ func (_m *MockEngine) PlayNext() (model.Game, error) {
 	return r0, r1
 }
 
+// PlayNextContext provides a mock function with given fields: ctx
+func (_m *MockEngine) PlayNextContext(ctx context.Context) (model.Game, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlayNextContext")
+	}
+
+	var r0 model.Game
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (model.Game, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) model.Game); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.Game)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlayToCompletion provides a mock function with given fields: options
+func (_m *MockEngine) PlayToCompletion(options CompletionOptions) (Completion, error) {
+	ret := _m.Called(options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlayToCompletion")
+	}
+
+	var r0 Completion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(CompletionOptions) (Completion, error)); ok {
+		return rf(options)
+	}
+	if rf, ok := ret.Get(0).(func(CompletionOptions) Completion); ok {
+		r0 = rf(options)
+	} else {
+		r0 = ret.Get(0).(Completion)
+	}
+
+	if rf, ok := ret.Get(1).(func(CompletionOptions) error); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlayToCompletionContext provides a mock function with given fields: ctx, options
+func (_m *MockEngine) PlayToCompletionContext(ctx context.Context, options CompletionOptions) (Completion, error) {
+	ret := _m.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlayToCompletionContext")
+	}
+
+	var r0 Completion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, CompletionOptions) (Completion, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, CompletionOptions) Completion); ok {
+		r0 = rf(ctx, options)
+	} else {
+		r0 = ret.Get(0).(Completion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, CompletionOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Players provides a mock function with given fields:
 func (_m *MockEngine) Players() int {
 	ret := _m.Called()
@@ -397,6 +510,11 @@ func (_m *MockEngine) SetFirst(first model.PlayerColor) error {
 	return r0
 }
 
+// SetMoveTimeout provides a mock function with given fields: limit, fallback
+func (_m *MockEngine) SetMoveTimeout(limit time.Duration, fallback source.CharacterInputSource) {
+	_m.Called(limit, fallback)
+}
+
 // StartGame provides a mock function with given fields:
 func (_m *MockEngine) StartGame() (model.Game, error) {
 	ret := _m.Called()
@@ -427,6 +545,66 @@ func (_m *MockEngine) StartGame() (model.Game, error) {
 	return r0, r1
 }
 
+// StartGameContext provides a mock function with given fields: ctx
+func (_m *MockEngine) StartGameContext(ctx context.Context) (model.Game, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartGameContext")
+	}
+
+	var r0 model.Game
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (model.Game, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) model.Game); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.Game)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartTurn provides a mock function with given fields: character
+func (_m *MockEngine) StartTurn(character Character) (Turn, error) {
+	ret := _m.Called(character)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartTurn")
+	}
+
+	var r0 Turn
+	var r1 error
+	if rf, ok := ret.Get(0).(func(Character) (Turn, error)); ok {
+		return rf(character)
+	}
+	if rf, ok := ret.Get(0).(func(Character) Turn); ok {
+		r0 = rf(character)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Turn)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(Character) error); ok {
+		r1 = rf(character)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Started provides a mock function with given fields:
 func (_m *MockEngine) Started() bool {
 	ret := _m.Called()
@@ -463,6 +641,26 @@ func (_m *MockEngine) State() string {
 	return r0
 }
 
+// Subscribe provides a mock function with given fields: listener
+func (_m *MockEngine) Subscribe(listener Listener) func() {
+	ret := _m.Called(listener)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(Listener) func()); ok {
+		r0 = rf(listener)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
 // Winner provides a mock function with given fields:
 func (_m *MockEngine) Winner() Character {
 	ret := _m.Called()
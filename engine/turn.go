@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"github.com/pronovic/go-apologies/model"
+)
+
+// Turn A single play within a player's turn.
+//
+// Each play follows the same steps: draw a card (in standard mode), construct the legal moves,
+// choose a move, execute the move, and discard the card that was played.  A player's turn consists
+// of one or more plays, since some cards let the player draw again.  The turn carries the card that
+// is actually in play, so the engine can guarantee that every drawn card reaches the discard pile
+// exactly once.
+type Turn interface {
+	// Character The character that is playing
+	Character() Character
+
+	// View The player view that legal moves were constructed from
+	View() model.PlayerView
+
+	// Card The card drawn for this play in standard mode, or nil in adult mode where cards come from the player's hand
+	Card() model.Card // optional
+
+	// LegalMoves The legal moves available for this play
+	LegalMoves() []model.Move
+
+	// Move The move chosen for this play, or nil if no move has been chosen yet
+	Move() model.Move // optional
+
+	// Discarded The cards discarded as a result of this play
+	Discarded() []model.Card
+
+	// Done Whether the player's turn is done after this play
+	Done() bool
+}
+
+type turn struct {
+	character  Character
+	view       model.PlayerView
+	card       model.Card
+	legalMoves []model.Move
+	move       model.Move
+	discarded  []model.Card
+	done       bool
+}
+
+// newTurn constructs a new Turn for a character and view, with the card drawn for the play (if any)
+func newTurn(character Character, view model.PlayerView, card model.Card) *turn {
+	return &turn{
+		character:  character,
+		view:       view,
+		card:       card,
+		legalMoves: make([]model.Move, 0),
+		move:       nil,
+		discarded:  make([]model.Card, 0),
+		done:       false,
+	}
+}
+
+func (t *turn) Character() Character {
+	return t.character
+}
+
+func (t *turn) View() model.PlayerView {
+	return t.view
+}
+
+func (t *turn) Card() model.Card {
+	return t.card
+}
+
+func (t *turn) LegalMoves() []model.Move {
+	return t.legalMoves
+}
+
+func (t *turn) Move() model.Move {
+	return t.move
+}
+
+func (t *turn) Discarded() []model.Card {
+	return t.discarded
+}
+
+func (t *turn) Done() bool {
+	return t.done
+}
@@ -0,0 +1,28 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/source"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTurn(t *testing.T) {
+	input := source.MockCharacterInputSource{}
+	character := NewCharacter("character", &input)
+	view := model.MockPlayerView{}
+	card := model.NewCard("1", model.Card1)
+
+	obj := newTurn(character, &view, card)
+	assert.Same(t, character, obj.Character())
+	assert.Same(t, &view, obj.View())
+	assert.Same(t, card, obj.Card())
+	assert.Equal(t, []model.Move{}, obj.LegalMoves())
+	assert.Nil(t, obj.Move())
+	assert.Equal(t, []model.Card{}, obj.Discarded())
+	assert.False(t, obj.Done())
+
+	obj = newTurn(character, &view, nil)
+	assert.Nil(t, obj.Card())
+}
@@ -214,7 +214,8 @@ func (g *moveGenerator) moveSimple(moves *[]model.Move, color model.PlayerColor,
 func (g *moveGenerator) moveSplit(moves *[]model.Move, color model.PlayerColor, card model.Card, pawn model.Pawn, allPawns []model.Pawn) {
 	// For the 7 card, we can split up the move between two different pawns.
 	// Any combination of 7 forward moves is legal, as long as the resulting position
-	// is not occupied by another pawn of the same color.
+	// is not occupied by another pawn of the same color, including the other half of
+	// the split itself.
 
 	for _, other := range allPawns {
 		if !equality.EqualByValue(other, pawn) && other.Color() == color && !other.Position().Home() && !other.Position().Start() {
@@ -234,7 +235,7 @@ func (g *moveGenerator) moveSplit(moves *[]model.Move, color model.PlayerColor,
 				right := make([]model.Move, 0)
 				g.moveSimple(&right, color, card, other, filtered, legal.right)
 
-				if len(left) > 0 && len(right) > 0 {
+				if len(left) > 0 && len(right) > 0 && !sameTarget(left[0], right[0]) {
 					actions := make([]model.Action, 0)
 					sideEffects := make([]model.Action, 0)
 
@@ -251,6 +252,19 @@ func (g *moveGenerator) moveSplit(moves *[]model.Move, color model.PlayerColor,
 	}
 }
 
+// sameTarget checks whether two simple moves would leave their pawns on the same non-home position
+func sameTarget(left model.Move, right model.Move) bool {
+	for _, l := range left.Actions() {
+		for _, r := range right.Actions() {
+			if l.Type() == model.MoveToPosition && r.Type() == model.MoveToPosition && !l.Position().Home() && equality.EqualByValue(l.Position(), r.Position()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (g *moveGenerator) moveSwap(moves *[]model.Move, color model.PlayerColor, card model.Card, pawn model.Pawn, allPawns []model.Pawn) {
 	// For the 11 card, a pawn on the board can swap with another pawn of a different
 	// color, as long as that pawn is outside of the start area, safe area, or home area.
@@ -297,6 +311,7 @@ func (g *moveGenerator) augmentWithSlides(allPawns []model.Pawn, moves []model.M
 						for _, slide := range model.Slides[color] { // # look at all model.Slides with this color
 							if action.Position() != nil && action.Position().Square() != nil && *action.Position().Square() == slide.Start() {
 								_ = action.Position().MoveToSquare(slide.End()) // if the pawn landed on the start of the slide, move the pawn to the end of the slide
+								action.SetSlid(true)
 								for square := slide.Start() + 1; square <= slide.End(); square++ {
 									// Note: in this one case, a pawn can bump another pawn of the same color
 									tmp := model.NewPosition(false, false, nil, &square)
@@ -424,6 +424,23 @@ func TestLegalMovesCard7(t *testing.T) {
 	)
 	assert.Equal(t, expected, moves)
 
+	// The two halves of a split may not leave both pawns on the same square
+	game = setupGame()
+	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToSquare(6)
+	_ = game.Players()[model.Red].Pawns()[2].Position().MoveToSquare(7)
+	card, pawn, view, moves = buildMoves(model.Red, game, 0, model.Card7)
+	other = view.Player().Pawns()[2]
+	expected = moveSlice(
+		move(card, actionSlice(square(pawn, 13)), nil),                   // move our pawn 7
+		move(card, actionSlice(square(pawn, 7), square(other, 13)), nil), // split (1, 6)
+		move(card, actionSlice(square(pawn, 8), square(other, 12)), nil), // split (2, 5)
+		move(card, actionSlice(square(pawn, 9), square(other, 11)), nil), // split (3, 4)
+		// the split (4, 3) is disallowed because both pawns would end up on square 10
+		move(card, actionSlice(square(pawn, 11), square(other, 9)), nil), // split (5, 2)
+		move(card, actionSlice(square(pawn, 12), square(other, 8)), nil), // split (6, 1)
+	)
+	assert.Equal(t, expected, moves)
+
 	// If either half of the move has a conflict with another pawn of the same color, the entire move is invalidated
 	game = setupGame()
 	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToSquare(6)
@@ -438,7 +455,7 @@ func TestLegalMovesCard7(t *testing.T) {
 		move(card, actionSlice(square(pawn, 8), square(other1, 14)), nil),  // split (2, 5)
 		move(card, actionSlice(square(pawn, 9), square(other1, 13)), nil),  // split (3, 4)
 		move(card, actionSlice(square(pawn, 10), square(other1, 12)), nil), // split (4, 3)
-		move(card, actionSlice(square(pawn, 11), square(other1, 11)), nil), // split (5, 2)
+		// the split (5, 2) is disallowed because both pawns would end up on square 11
 		move(card, actionSlice(square(pawn, 12), square(other1, 10)), nil), // split (6, 1)
 		move(card, actionSlice(square(pawn, 7), square(other2, 1)), nil),   // split (1, 6)
 		move(card, actionSlice(square(pawn, 8), square(other2, 0)), nil),   // split (2, 5)
@@ -723,12 +740,13 @@ func TestLegalMovesSpecial(t *testing.T) {
 	_ = game.Players()[model.Red].Pawns()[1].Position().MoveToSquare(17)
 	_ = game.Players()[model.Yellow].Pawns()[2].Position().MoveToSquare(18)
 	card, pawn, view, moves = buildMoves(model.Red, game, 0, model.Card1)
-	expected = moveSlice(move(card, actionSlice(square(pawn, 19)), actionSlice(bump(view, model.Red, 1), bump(view, model.Yellow, 2))))
+	expected = moveSlice(move(card, actionSlice(slid(square(pawn, 19))), actionSlice(bump(view, model.Red, 1), bump(view, model.Yellow, 2))))
 	assert.Equal(t, expected, moves)
+	assert.True(t, moves[0].Actions()[0].Slid())
 }
 
 func setupGame() model.Game {
-	game, _ := model.NewGame(4, nil)
+	game, _ := model.NewGame(4, nil, nil)
 
 	for _, color := range model.PlayerColors.Members() {
 		for pawn := 0; pawn < model.Pawns; pawn++ {
@@ -770,6 +788,11 @@ func safe(pawn model.Pawn, square int) model.Action {
 	return model.NewAction(model.MoveToPosition, pawn, model.NewPosition(false, false, &square, nil))
 }
 
+func slid(action model.Action) model.Action {
+	action.SetSlid(true)
+	return action
+}
+
 func start(pawn model.Pawn) model.Action {
 	return model.NewAction(model.MoveToStart, pawn, nil)
 }
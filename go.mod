@@ -4,14 +4,21 @@ go 1.21.6
 
 require (
 	github.com/golang-ds/queue v1.0.0
+	github.com/gorilla/websocket v1.5.3
+	github.com/rthornton128/goncurses v0.0.0-20231014161942-82671379df88
 	github.com/stretchr/testify v1.8.4
+	google.golang.org/grpc v1.66.2
+	google.golang.org/protobuf v1.34.2
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/golang-ds/linkedlist v1.0.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/rthornton128/goncurses v0.0.0-20231014161942-82671379df88 // indirect
 	github.com/stretchr/objx v0.5.1 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240604185151-ef581f913117 // indirect
 )
@@ -11,6 +11,7 @@ import (
 type CircularQueue[T any] interface {
 	SetFirst(entry T) error
 	Next() (T, error)
+	Peek() (T, error)
 }
 
 type circularQueue[T comparable] struct {
@@ -63,3 +64,17 @@ func (q *circularQueue[T]) Next() (T, error) {
 
 	return first, nil
 }
+
+// Peek gets the next entry in the queue without advancing past it
+func (q *circularQueue[T]) Peek() (T, error) {
+	if q.wrapped.IsEmpty() {
+		return *new(T), errors.New("queue is empty")
+	}
+
+	first, ok := q.wrapped.First()
+	if !ok {
+		return *new(T), errors.New("entry not found")
+	}
+
+	return first, nil
+}
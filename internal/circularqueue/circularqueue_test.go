@@ -20,6 +20,9 @@ func TestEmpty(t *testing.T) {
 
 	_, err = queue.Next()
 	assert.EqualError(t, err, "queue is empty")
+
+	_, err = queue.Peek()
+	assert.EqualError(t, err, "queue is empty")
 }
 
 func TestSingle(t *testing.T) {
@@ -118,3 +121,33 @@ func TestMultiple(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "a", entry) // this is the same place we would otherwise have been
 }
+
+func TestPeek(t *testing.T) {
+	var entry string
+	var err error
+	entries := []string{"a", "b", "c"}
+
+	queue := NewCircularQueue[string](entries)
+
+	entry, err = queue.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, "a", entry)
+
+	entry, err = queue.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, "a", entry) // peeking does not advance the queue
+
+	entry, err = queue.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "a", entry)
+
+	entry, err = queue.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, "b", entry)
+
+	err = queue.SetFirst("c")
+	assert.NoError(t, err)
+	entry, err = queue.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, "c", entry)
+}
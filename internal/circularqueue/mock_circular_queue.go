@@ -37,6 +37,34 @@ func (_m *MockCircularQueue[T]) Next() (T, error) {
 	return r0, r1
 }
 
+// Peek provides a mock function with given fields:
+func (_m *MockCircularQueue[T]) Peek() (T, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Peek")
+	}
+
+	var r0 T
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (T, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() T); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(T)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetFirst provides a mock function with given fields: entry
 func (_m *MockCircularQueue[T]) SetFirst(entry T) error {
 	ret := _m.Called(entry)
@@ -1,14 +1,16 @@
 package model
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"io"
+	"slices"
 	"strconv"
 
 	"github.com/pronovic/go-apologies/internal/enum"
 	"github.com/pronovic/go-apologies/internal/jsonutil"
-	"github.com/pronovic/go-apologies/internal/randomutil"
+	"github.com/pronovic/go-apologies/random"
 )
 
 // AdultHand for an adult-mode game, we deal out 5 cards
@@ -137,15 +139,41 @@ type Deck interface {
 
 	// Discard a card to the discard pile
 	Discard(card Card) error
+
+	// Remove a particular card from the draw pile, as when setting up a deck to match cards known to be elsewhere
+	Remove(card Card) error
+
+	// DrawPileSize The number of cards in the draw pile
+	DrawPileSize() int
+
+	// DiscardPileSize The number of cards in the discard pile
+	DiscardPileSize() int
+
+	// Cards All cards in the draw pile and the discard pile, sorted by id
+	Cards() []Card
+
+	// DiscardPile The cards in the discard pile, which are face up and visible to every player, sorted by id
+	DiscardPile() []Card
+
+	// Randomizer The randomizer used to draw cards
+	Randomizer() random.Randomizer
+
+	// SetRandomizer Replace the randomizer used to draw cards, for instance after loading a deck from JSON
+	SetRandomizer(randomizer random.Randomizer)
 }
 
 type deck struct {
 	XdrawPile    map[string]Card `json:"draw"`
 	XdiscardPile map[string]Card `json:"discard"`
+	randomizer   random.Randomizer
 }
 
-// NewDeck constructs a new Deck
-func NewDeck() Deck {
+// NewDeck constructs a new Deck, optionally accepting a randomizer
+func NewDeck(randomizer random.Randomizer) Deck {
+	if randomizer == nil {
+		randomizer = random.NewRandomizer()
+	}
+
 	drawPile := make(map[string]Card, DeckSize)
 	discardPile := make(map[string]Card, DeckSize)
 
@@ -161,6 +189,7 @@ func NewDeck() Deck {
 	return &deck{
 		XdrawPile:    drawPile,
 		XdiscardPile: discardPile,
+		randomizer:   randomizer,
 	}
 }
 
@@ -192,6 +221,7 @@ func NewDeckFromJSON(reader io.Reader) (Deck, error) {
 	obj := deck{
 		XdrawPile:    XdrawPile,
 		XdiscardPile: XdiscardPile,
+		randomizer:   random.NewRandomizer(),
 	}
 
 	return &obj, nil
@@ -212,6 +242,7 @@ func (d *deck) Copy() Deck {
 	return &deck{
 		XdrawPile:    drawPileCopy,
 		XdiscardPile: discardPileCopy,
+		randomizer:   d.randomizer,
 	}
 }
 
@@ -229,13 +260,14 @@ func (d *deck) Draw() (Card, error) {
 		return (Card)(nil), errors.New("no cards available in deck")
 	}
 
-	// because range on a map is not stable, the order of keys will vary
+	// because range on a map is not stable, we sort the keys so a seeded randomizer always draws the same card
 	keys := make([]string, 0, len(d.XdrawPile))
 	for k := range d.XdrawPile {
 		keys = append(keys, k)
 	}
+	slices.Sort(keys)
 
-	index, err := randomutil.RandomInt(len(keys))
+	index, err := d.randomizer.Int(len(keys))
 	if err != nil {
 		return (Card)(nil), err
 	}
@@ -258,3 +290,76 @@ func (d *deck) Discard(card Card) error {
 	d.XdiscardPile[card.Id()] = card
 	return nil
 }
+
+func (d *deck) Remove(card Card) error {
+	if _, inDrawPile := d.XdrawPile[card.Id()]; !inDrawPile {
+		return errors.New("card is not in draw pile")
+	}
+
+	delete(d.XdrawPile, card.Id())
+	return nil
+}
+
+func (d *deck) Randomizer() random.Randomizer {
+	return d.randomizer
+}
+
+func (d *deck) SetRandomizer(randomizer random.Randomizer) {
+	if randomizer == nil {
+		randomizer = random.NewRandomizer()
+	}
+
+	d.randomizer = randomizer
+}
+
+func (d *deck) DrawPileSize() int {
+	return len(d.XdrawPile)
+}
+
+func (d *deck) DiscardPileSize() int {
+	return len(d.XdiscardPile)
+}
+
+func (d *deck) Cards() []Card {
+	return sortCards(d.XdrawPile, d.XdiscardPile)
+}
+
+func (d *deck) DiscardPile() []Card {
+	return sortCards(d.XdiscardPile)
+}
+
+// sortCards returns the cards from a set of piles, sorted by id
+func sortCards(piles ...map[string]Card) []Card {
+	cards := make([]Card, 0)
+
+	// a deck loaded from corrupted JSON might contain empty entries, which don't count as cards
+	for _, pile := range piles {
+		for _, card := range pile {
+			if card != nil {
+				cards = append(cards, card)
+			}
+		}
+	}
+
+	// range on a map explicitly does *not* return keys in a stable order, so we sort the result
+	slices.SortStableFunc(cards, func(i, j Card) int {
+		return compareIds(i.Id(), j.Id())
+	})
+
+	return cards
+}
+
+// compareIds compares card ids, ordering numeric ids numerically and other ids lexically after them
+func compareIds(i string, j string) int {
+	x, xerr := strconv.Atoi(i)
+	y, yerr := strconv.Atoi(j)
+	if xerr == nil && yerr == nil {
+		return cmp.Compare(x, y)
+	} else if xerr == nil {
+		return -1
+	} else if yerr == nil {
+		return 1
+	} else {
+		return cmp.Compare(i, j)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"slices"
+	"strconv"
 	"testing"
 
+	"github.com/pronovic/go-apologies/random"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,7 +41,7 @@ func TestCardCopy(t *testing.T) {
 }
 
 func TestNewDeck(t *testing.T) {
-	obj := NewDeck()
+	obj := NewDeck(nil)
 	underlying := obj.(*deck)
 
 	assert.Equal(t, DeckSize, len(underlying.XdrawPile))
@@ -68,7 +70,7 @@ func TestNewDeckFromJSON(t *testing.T) {
 	var marshalled []byte
 	var unmarshalled Deck
 
-	obj = NewDeck()
+	obj = NewDeck(nil)
 	marshalled, err = json.Marshal(obj)
 	assert.NoError(t, err)
 	unmarshalled, err = NewDeckFromJSON(bytes.NewReader(marshalled))
@@ -77,7 +79,7 @@ func TestNewDeckFromJSON(t *testing.T) {
 }
 
 func TestDeckCopy(t *testing.T) {
-	obj := NewDeck()
+	obj := NewDeck(nil)
 	copied := obj.Copy()
 	assert.Equal(t, obj, copied)
 	assert.NotSame(t, obj, copied)
@@ -89,7 +91,7 @@ func TestDeckDrawAndDiscard(t *testing.T) {
 	var card3 Card
 	var err error
 
-	obj := NewDeck()
+	obj := NewDeck(nil)
 	underlying := obj.(*deck)
 
 	// Check that we can draw the entire deck
@@ -131,7 +133,7 @@ func TestDeckDrawAndDiscard(t *testing.T) {
 	card2 = drawn[0]
 	drawn = slices.Delete(drawn, 0, 1)
 	card3 = drawn[0]
-	slices.Delete(drawn, 0, 1)
+	drawn = slices.Delete(drawn, 0, 1)
 	err = obj.Discard(card1)
 	assert.NoError(t, err)
 	err = obj.Discard(card2)
@@ -161,3 +163,95 @@ func TestDeckDrawAndDiscard(t *testing.T) {
 	_, err = obj.Draw()
 	assert.EqualError(t, err, "no cards available in deck")
 }
+
+func TestDeckRemove(t *testing.T) {
+	obj := NewDeck(nil)
+
+	// a card can be removed by id, even if it's a different instance
+	assert.NoError(t, obj.Remove(NewCard("7", Card1)))
+	assert.Equal(t, DeckSize-1, obj.DrawPileSize())
+	for _, card := range obj.Cards() {
+		assert.NotEqual(t, "7", card.Id())
+	}
+
+	// but only while it's in the draw pile
+	assert.EqualError(t, obj.Remove(NewCard("7", Card1)), "card is not in draw pile")
+	card, _ := obj.Draw()
+	_ = obj.Discard(card)
+	assert.EqualError(t, obj.Remove(card), "card is not in draw pile")
+	assert.EqualError(t, obj.Remove(NewCard("bogus", Card1)), "card is not in draw pile")
+}
+
+func TestDeckDrawSeeded(t *testing.T) {
+	first := NewDeck(random.NewSeededRandomizer(42))
+	second := NewDeck(random.NewSeededRandomizer(42))
+
+	// the same seed always draws the cards in the same order
+	for i := 0; i < DeckSize; i++ {
+		card1, err := first.Draw()
+		assert.NoError(t, err)
+		card2, err := second.Draw()
+		assert.NoError(t, err)
+		assert.Equal(t, card1, card2)
+	}
+}
+
+func TestDeckSetRandomizer(t *testing.T) {
+	seeded := NewDeck(random.NewSeededRandomizer(42))
+
+	// a deck loaded from JSON can be made to draw the same way as the deck it was saved from
+	marshalled, err := json.Marshal(seeded)
+	assert.NoError(t, err)
+	loaded, err := NewDeckFromJSON(bytes.NewReader(marshalled))
+	assert.NoError(t, err)
+	randomizer := random.NewSeededRandomizer(42)
+	loaded.SetRandomizer(randomizer)
+	assert.Same(t, randomizer, loaded.Randomizer())
+
+	for i := 0; i < DeckSize; i++ {
+		card1, err := seeded.Draw()
+		assert.NoError(t, err)
+		card2, err := loaded.Draw()
+		assert.NoError(t, err)
+		assert.Equal(t, card1, card2)
+	}
+}
+
+func TestDeckSizesAndCards(t *testing.T) {
+	obj := NewDeck(nil)
+	assert.Equal(t, DeckSize, obj.DrawPileSize())
+	assert.Equal(t, 0, obj.DiscardPileSize())
+	assert.Equal(t, DeckSize, len(obj.Cards()))
+
+	card1, _ := obj.Draw()
+	card2, _ := obj.Draw()
+	_, _ = obj.Draw()
+	_ = obj.Discard(card1)
+	_ = obj.Discard(card2)
+	assert.Equal(t, DeckSize-3, obj.DrawPileSize())
+	assert.Equal(t, 2, obj.DiscardPileSize())
+
+	// cards from both piles are returned, sorted by id
+	cards := obj.Cards()
+	assert.Equal(t, DeckSize-1, len(cards))
+	for i := 1; i < len(cards); i++ {
+		previous, _ := strconv.Atoi(cards[i-1].Id())
+		current, _ := strconv.Atoi(cards[i].Id())
+		assert.Less(t, previous, current)
+	}
+
+	// only the discarded cards are in the discard pile
+	discardPile := obj.DiscardPile()
+	assert.Equal(t, 2, len(discardPile))
+	assert.Contains(t, discardPile, card1)
+	assert.Contains(t, discardPile, card2)
+}
+
+func TestCompareIds(t *testing.T) {
+	assert.Equal(t, -1, compareIds("2", "10"))
+	assert.Equal(t, 1, compareIds("10", "2"))
+	assert.Equal(t, 0, compareIds("10", "10"))
+	assert.Equal(t, -1, compareIds("10", "a"))
+	assert.Equal(t, 1, compareIds("a", "10"))
+	assert.Equal(t, -1, compareIds("a", "b"))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/pronovic/go-apologies/internal/enum"
 	"github.com/pronovic/go-apologies/internal/jsonutil"
 	"github.com/pronovic/go-apologies/internal/timestamp"
+	"github.com/pronovic/go-apologies/random"
 )
 
 // GameMode defines legal game modes
@@ -35,9 +36,19 @@ type History interface {
 	// Card Card associated with the action
 	Card() *CardType // optional
 
+	// Move The complete move being played when the action was taken, including side effects
+	// Every history entry tracked for the same move carries the same move.
+	Move() Move // optional
+
+	// Turn The number of the turn during which the action was taken, or 0 before the first turn
+	Turn() int
+
 	// Timestamp Timestamp tied to the action (defaults to current time)
 	Timestamp() timestamp.Timestamp
 
+	// Notice Whether this is a notice about something that happened to a player, rather than an action taken in the game
+	Notice() bool
+
 	// Copy Return a fully-independent copy of the history.
 	Copy() History
 }
@@ -46,11 +57,14 @@ type history struct {
 	Xaction    string              `json:"action"`
 	Xcolor     *PlayerColor        `json:"color"`
 	Xcard      *CardType           `json:"card"`
+	Xmove      Move                `json:"move"`
+	Xturn      int                 `json:"turn"`
 	Xtimestamp timestamp.Timestamp `json:"timestamp"`
+	Xnotice    bool                `json:"notice"`
 }
 
-// NewHistory constructs a new History, optionally accepting a timestamp factory
-func NewHistory(action string, color *PlayerColor, card *CardType, factory timestamp.Factory) History {
+// NewHistory constructs a new History, optionally accepting a move and a timestamp factory
+func NewHistory(action string, color *PlayerColor, card *CardType, move Move, turn int, factory timestamp.Factory) History {
 	if factory == nil {
 		factory = timestamp.NewFactory()
 	}
@@ -59,13 +73,54 @@ func NewHistory(action string, color *PlayerColor, card *CardType, factory times
 		Xaction:    action,
 		Xcolor:     color,
 		Xcard:      card,
+		Xmove:      move,
+		Xturn:      turn,
 		Xtimestamp: factory.CurrentTime(),
 	}
 }
 
+// NewNotice constructs a new History for a notice about a player, optionally accepting a timestamp factory
+func NewNotice(action string, color PlayerColor, turn int, factory timestamp.Factory) History {
+	notice := NewHistory(action, &color, nil, nil, turn, factory).(*history)
+	notice.Xnotice = true
+	return notice
+}
+
 // NewHistoryFromJSON constructs a new object from JSON in an io.Reader
 func NewHistoryFromJSON(reader io.Reader) (History, error) {
-	return jsonutil.DecodeSimpleJSON[history](reader)
+	type raw struct {
+		Xaction    string              `json:"action"`
+		Xcolor     *PlayerColor        `json:"color"`
+		Xcard      *CardType           `json:"card"`
+		Xmove      json.RawMessage     `json:"move"`
+		Xturn      int                 `json:"turn"`
+		Xtimestamp timestamp.Timestamp `json:"timestamp"`
+		Xnotice    bool                `json:"notice"`
+	}
+
+	var temp raw
+	err := json.NewDecoder(reader).Decode(&temp)
+	if err != nil {
+		return nil, err
+	}
+
+	var Xmove Move
+	Xmove, err = jsonutil.DecodeInterfaceJSON(temp.Xmove, NewMoveFromJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := history{
+		Xaction:    temp.Xaction,
+		Xcolor:     temp.Xcolor,
+		Xcard:      temp.Xcard,
+		Xmove:      Xmove,
+		Xturn:      temp.Xturn,
+		Xtimestamp: temp.Xtimestamp,
+		Xnotice:    temp.Xnotice,
+	}
+
+	return &obj, nil
 }
 
 func (h *history) Action() string {
@@ -80,16 +135,48 @@ func (h *history) Card() *CardType { // optional
 	return h.Xcard
 }
 
+func (h *history) Move() Move { // optional
+	return h.Xmove
+}
+
+func (h *history) Turn() int {
+	return h.Xturn
+}
+
 func (h *history) Timestamp() timestamp.Timestamp {
 	return h.Xtimestamp
 }
 
+func (h *history) Notice() bool {
+	return h.Xnotice
+}
+
 func (h *history) Copy() History {
+	var color *PlayerColor
+	if h.Xcolor != nil {
+		value := *h.Xcolor
+		color = &value
+	}
+
+	var card *CardType
+	if h.Xcard != nil {
+		value := *h.Xcard
+		card = &value
+	}
+
+	var move Move
+	if h.Xmove != nil {
+		move = h.Xmove.Copy()
+	}
+
 	return &history{
 		Xaction:    h.Xaction,
-		Xcolor:     h.Xcolor,
-		Xcard:      h.Xcard,
+		Xcolor:     color,
+		Xcard:      card,
+		Xmove:      move,
+		Xturn:      h.Xturn,
 		Xtimestamp: h.Xtimestamp,
+		Xnotice:    h.Xnotice,
 	}
 }
 
@@ -129,9 +216,21 @@ type Game interface {
 	// Winner The winner of the game, if any.
 	Winner() *Player
 
+	// Turn The number of the turn currently being played, or 0 before the first turn
+	Turn() int
+
+	// StartTurn Advance to the next turn, so that history tracked from now on is associated with it
+	StartTurn()
+
 	// Track Tracks an action taken during the game, optionally tracking player and/or card
 	Track(action string, player Player, card Card)
 
+	// TrackMove Tracks an action taken as part of a move, tracking the player, the move, and the card played
+	TrackMove(action string, player Player, move Move)
+
+	// TrackNotice Tracks something that happened to a player without being an action the player took, so it does not count as a turn
+	TrackNotice(action string, color PlayerColor)
+
 	// CreatePlayerView Return a player-specific view of the game, showing only the information a player would have available on their turn.
 	CreatePlayerView(color PlayerColor) (PlayerView, error)
 }
@@ -141,11 +240,12 @@ type game struct {
 	Xplayers     map[PlayerColor]Player `json:"players"`
 	Xdeck        Deck                   `json:"deck"`
 	Xhistory     []History              `json:"history"`
+	Xturn        int                    `json:"turn"`
 	factory      timestamp.Factory
 }
 
-// NewGame constructs a new Game, optionally accepting a timestamp factory
-func NewGame(playerCount int, factory timestamp.Factory) (Game, error) {
+// NewGame constructs a new Game, optionally accepting a timestamp factory and a randomizer
+func NewGame(playerCount int, factory timestamp.Factory, randomizer random.Randomizer) (Game, error) {
 	if factory == nil {
 		factory = timestamp.NewFactory()
 	}
@@ -163,7 +263,7 @@ func NewGame(playerCount int, factory timestamp.Factory) (Game, error) {
 	game := &game{
 		XplayerCount: playerCount,
 		Xplayers:     players,
-		Xdeck:        NewDeck(),
+		Xdeck:        NewDeck(randomizer),
 		Xhistory:     make([]History, 0),
 		factory:      factory,
 	}
@@ -178,6 +278,7 @@ func NewGameFromJSON(reader io.Reader) (Game, error) {
 		Xplayers     map[PlayerColor]json.RawMessage `json:"players"`
 		Xdeck        json.RawMessage                 `json:"deck"`
 		Xhistory     []json.RawMessage               `json:"history"`
+		Xturn        int                             `json:"turn"`
 	}
 
 	var temp raw
@@ -209,12 +310,29 @@ func NewGameFromJSON(reader io.Reader) (Game, error) {
 		Xplayers:     Xplayers,
 		Xdeck:        Xdeck,
 		Xhistory:     Xhistory,
+		Xturn:        temp.Xturn,
 		factory:      timestamp.NewFactory(),
 	}
 
 	return &obj, nil
 }
 
+// NewGameFromJSONStrict constructs a new object from JSON in an io.Reader, like NewGameFromJSON,
+// but also runs ValidateGame and rejects the game with a *ValidationError if it is inconsistent.
+func NewGameFromJSONStrict(reader io.Reader) (Game, error) {
+	game, err := NewGameFromJSON(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateGame(game)
+	if err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
 func (g *game) PlayerCount() int {
 	return g.XplayerCount
 }
@@ -250,6 +368,7 @@ func (g *game) Copy() Game {
 		Xplayers:     playersCopy,
 		Xdeck:        g.Xdeck.Copy(),
 		Xhistory:     historyCopy,
+		Xturn:        g.Xturn,
 		factory:      g.factory,
 	}
 }
@@ -278,7 +397,32 @@ func (g *game) Winner() *Player {
 	return nil
 }
 
+func (g *game) Turn() int {
+	return g.Xturn
+}
+
+func (g *game) StartTurn() {
+	g.Xturn += 1
+}
+
 func (g *game) Track(action string, player Player, card Card) {
+	g.track(action, player, card, nil)
+}
+
+func (g *game) TrackMove(action string, player Player, move Move) {
+	var card Card = nil
+	if move != nil {
+		card = move.Card()
+	}
+
+	g.track(action, player, card, move)
+}
+
+func (g *game) TrackNotice(action string, color PlayerColor) {
+	g.Xhistory = append(g.Xhistory, NewNotice(action, color, g.Xturn, g.factory))
+}
+
+func (g *game) track(action string, player Player, card Card, move Move) {
 	var color *PlayerColor = nil
 	if player != nil {
 		x := player.Color()
@@ -291,7 +435,7 @@ func (g *game) Track(action string, player Player, card Card) {
 		cardtype = &tmp
 	}
 
-	history := NewHistory(action, color, cardtype, g.factory)
+	history := NewHistory(action, color, cardtype, move, g.Xturn, g.factory)
 	g.Xhistory = append(g.Xhistory, history)
 
 	if player != nil {
@@ -319,5 +463,12 @@ func (g *game) CreatePlayerView(color PlayerColor) (PlayerView, error) {
 		}
 	}
 
-	return NewPlayerView(copied, opponents), nil
+	discardPile := make([]Card, 0)
+	if g.Xdeck != nil {
+		for _, card := range g.Xdeck.DiscardPile() {
+			discardPile = append(discardPile, card.Copy())
+		}
+	}
+
+	return NewPlayerView(copied, opponents, discardPile), nil
 }
@@ -23,7 +23,7 @@ func init() {
 func TestNewHistory(t *testing.T) {
 	var obj History
 
-	obj = NewHistory("action", nil, nil, &factory)
+	obj = NewHistory("action", nil, nil, nil, 0, &factory)
 	assert.Equal(t, "action", obj.Action())
 	assert.Nil(t, obj.Color())
 	assert.Nil(t, obj.Card())
@@ -31,18 +31,41 @@ func TestNewHistory(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("[%s] General - action", stubbedString), fmt.Sprintf("%s", obj))
 
 	color := Blue
-	obj = NewHistory("action", &color, nil, &factory)
+	obj = NewHistory("action", &color, nil, nil, 0, &factory)
 	assert.Equal(t, &color, obj.Color())
 	assert.Nil(t, obj.Card())
 	assert.Equal(t, stubbedTimestamp, obj.Timestamp())
 	assert.Equal(t, fmt.Sprintf("[%s] Blue - action", stubbedString), fmt.Sprintf("%s", obj))
 
 	card1 := Card12
-	obj = NewHistory("action", nil, &card1, &factory)
+	obj = NewHistory("action", nil, &card1, nil, 0, &factory)
 	assert.Nil(t, obj.Color())
 	assert.Equal(t, &card1, obj.Card())
+	assert.Nil(t, obj.Move())
+	assert.Equal(t, 0, obj.Turn())
 	assert.Equal(t, stubbedTimestamp, obj.Timestamp())
 	assert.Equal(t, fmt.Sprintf("[%s] General - action", stubbedString), fmt.Sprintf("%s", obj))
+
+	move := createHistoryMove()
+	obj = NewHistory("action", &color, &card1, move, 3, &factory)
+	assert.Equal(t, &color, obj.Color())
+	assert.Equal(t, &card1, obj.Card())
+	assert.Same(t, move, obj.Move())
+	assert.Equal(t, 3, obj.Turn())
+	assert.False(t, obj.Notice())
+	assert.Equal(t, fmt.Sprintf("[%s] Blue - action", stubbedString), fmt.Sprintf("%s", obj))
+}
+
+func TestNewNotice(t *testing.T) {
+	obj := NewNotice("notice", Green, 4, &factory)
+	assert.Equal(t, "notice", obj.Action())
+	assert.Equal(t, Green, *obj.Color())
+	assert.Nil(t, obj.Card())
+	assert.Nil(t, obj.Move())
+	assert.Equal(t, 4, obj.Turn())
+	assert.Equal(t, stubbedTimestamp, obj.Timestamp())
+	assert.True(t, obj.Notice())
+	assert.Equal(t, fmt.Sprintf("[%s] Green - notice", stubbedString), fmt.Sprintf("%s", obj))
 }
 
 func TestNewHistoryFromJSON(t *testing.T) {
@@ -51,7 +74,7 @@ func TestNewHistoryFromJSON(t *testing.T) {
 	var marshalled []byte
 	var unmarshalled History
 
-	obj = NewHistory("something", nil, nil, nil)
+	obj = NewHistory("something", nil, nil, nil, 0, nil)
 	marshalled, err = json.Marshal(obj)
 	assert.NoError(t, err)
 	unmarshalled, err = NewHistoryFromJSON(bytes.NewReader(marshalled))
@@ -59,7 +82,7 @@ func TestNewHistoryFromJSON(t *testing.T) {
 	assert.Equal(t, obj, unmarshalled)
 
 	color := Blue
-	obj = NewHistory("something", &color, nil, nil)
+	obj = NewHistory("something", &color, nil, nil, 0, nil)
 	marshalled, err = json.Marshal(obj)
 	assert.NoError(t, err)
 	unmarshalled, err = NewHistoryFromJSON(bytes.NewReader(marshalled))
@@ -67,21 +90,46 @@ func TestNewHistoryFromJSON(t *testing.T) {
 	assert.Equal(t, obj, unmarshalled)
 
 	card1 := Card12
-	obj = NewHistory("something", nil, &card1, nil)
+	obj = NewHistory("something", nil, &card1, nil, 0, nil)
 	marshalled, err = json.Marshal(obj)
 	assert.NoError(t, err)
 	unmarshalled, err = NewHistoryFromJSON(bytes.NewReader(marshalled))
 	assert.NoError(t, err)
 	assert.Equal(t, obj, unmarshalled)
+
+	obj = NewHistory("something", &color, &card1, createHistoryMove(), 7, nil)
+	marshalled, err = json.Marshal(obj)
+	assert.NoError(t, err)
+	unmarshalled, err = NewHistoryFromJSON(bytes.NewReader(marshalled))
+	assert.NoError(t, err)
+	assert.Equal(t, obj, unmarshalled)
+
+	obj = NewNotice("something", color, 7, nil)
+	marshalled, err = json.Marshal(obj)
+	assert.NoError(t, err)
+	unmarshalled, err = NewHistoryFromJSON(bytes.NewReader(marshalled))
+	assert.NoError(t, err)
+	assert.Equal(t, obj, unmarshalled)
+	assert.True(t, unmarshalled.Notice())
 }
 
 func TestHistoryCopy(t *testing.T) {
 	color := Blue
 	card1 := Card12
-	obj := NewHistory("action", &color, &card1, nil)
+	obj := NewHistory("action", &color, &card1, createHistoryMove(), 2, nil)
 	copied := obj.Copy()
 	assert.Equal(t, obj, copied)
 	assert.NotSame(t, obj, copied)
+	assert.NotSame(t, obj.Color(), copied.Color())
+	assert.NotSame(t, obj.Card(), copied.Card())
+	assert.NotSame(t, obj.Move(), copied.Move())
+
+	// the recorded move belongs to the copy, so changing the original's move does not change it
+	_ = obj.Move().Actions()[0].Position().MoveToSquare(33)
+	assert.Equal(t, 32, *copied.Move().Actions()[0].Position().Square())
+
+	obj = NewNotice("notice", color, 2, nil)
+	assert.True(t, obj.Copy().Notice())
 }
 
 func TestNewGameFromJSON(t *testing.T) {
@@ -98,8 +146,78 @@ func TestNewGameFromJSON(t *testing.T) {
 	assert.Equal(t, obj, unmarshalled)
 }
 
+func TestNewGameFromJSONStrict(t *testing.T) {
+	var err error
+	var marshalled []byte
+	var unmarshalled Game
+
+	// a consistent game round-trips exactly like it does for non-strict loading
+	obj, _ := NewGame(4, nil, nil)
+	card, _ := obj.Deck().Draw()
+	obj.Players()[Blue].AppendToHand(card)
+	_ = obj.Players()[Red].Pawns()[0].Position().MoveToSquare(32)
+	_ = obj.Players()[Yellow].Pawns()[3].Position().MoveToSafe(1)
+	marshalled, err = json.Marshal(obj)
+	assert.NoError(t, err)
+	unmarshalled, err = NewGameFromJSONStrict(bytes.NewReader(marshalled))
+	assert.NoError(t, err)
+	assert.Equal(t, obj, unmarshalled)
+
+	// structurally invalid JSON is still rejected as a decoding error
+	unmarshalled, err = NewGameFromJSONStrict(bytes.NewReader([]byte("{")))
+	assert.Error(t, err)
+	assert.Nil(t, unmarshalled)
+
+	// a hand-edited game is rejected with the specific violations that were found
+	edited := editJSON(t, marshalled, func(raw map[string]any) {
+		raw["playercount"] = 3
+		players := raw["players"].(map[string]any)
+		red := players["Red"].(map[string]any)
+		pawns := red["pawns"].([]any)
+		pawns[1].(map[string]any)["position"] = map[string]any{"start": true, "home": false, "safe": nil, "square": 32}
+		pawns[2].(map[string]any)["position"] = map[string]any{"start": false, "home": false, "safe": nil, "square": 60}
+		blue := players["Blue"].(map[string]any)
+		blue["hand"] = append(blue["hand"].([]any), blue["hand"].([]any)[0])
+	})
+	unmarshalled, err = NewGameFromJSONStrict(bytes.NewReader(edited))
+	assert.Nil(t, unmarshalled)
+	var validationError *ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Equal(t, []Violation{
+		{InvalidPlayers, "expected 3 players but found 4"},
+		{InvalidPosition, "pawn Red1 has 2 position fields set"},
+		{InvalidPosition, "pawn Red2 is on invalid square 60"},
+		{CardConservation, "expected 45 cards but found 46"},
+		{CardConservation, fmt.Sprintf("expected %d cards of type %s but found %d", DeckCounts[card.Type()], card.Type().Value(), DeckCounts[card.Type()]+1)},
+		{DuplicateCard, fmt.Sprintf("card id %s is used more than once", card.Id())},
+		{PawnOverlap, "pawns Red0 and Red1 are both on square 32"},
+	}, validationError.Violations)
+
+	// empty entries decode without error, but would break the game later, so they are rejected too
+	for _, c := range []struct {
+		edit      func(raw map[string]any)
+		violation Violation
+	}{
+		{func(raw map[string]any) {
+			raw["players"].(map[string]any)["Blue"].(map[string]any)["hand"] = []any{nil}
+		}, Violation{MissingCard, "hand for Blue has an empty card at index 0"}},
+		{func(raw map[string]any) {
+			raw["history"] = []any{nil}
+		}, Violation{MissingHistory, "history entry 0 is empty"}},
+		{func(raw map[string]any) {
+			raw["deck"].(map[string]any)["draw"].(map[string]any)["99"] = nil
+		}, Violation{MissingCard, "draw pile entry 99 is empty"}},
+	} {
+		edited = editJSON(t, marshalled, c.edit)
+		unmarshalled, err = NewGameFromJSONStrict(bytes.NewReader(edited))
+		assert.Nil(t, unmarshalled)
+		assert.ErrorAs(t, err, &validationError)
+		assert.Contains(t, validationError.Violations, c.violation)
+	}
+}
+
 func TestNewGame2Players(t *testing.T) {
-	game, err := NewGame(2, nil)
+	game, err := NewGame(2, nil, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(game.Players()))
 	assert.Equal(t, 0, len(game.History()))
@@ -110,7 +228,7 @@ func TestNewGame2Players(t *testing.T) {
 }
 
 func TestNewGame3Players(t *testing.T) {
-	game, err := NewGame(3, nil)
+	game, err := NewGame(3, nil, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 3, len(game.Players()))
 	assert.Equal(t, 0, len(game.History()))
@@ -121,7 +239,7 @@ func TestNewGame3Players(t *testing.T) {
 }
 
 func TestNewGame4Players(t *testing.T) {
-	game, err := NewGame(4, nil)
+	game, err := NewGame(4, nil, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 4, len(game.Players()))
 	assert.Equal(t, 0, len(game.History()))
@@ -133,7 +251,7 @@ func TestNewGame4Players(t *testing.T) {
 
 func TestNewGameInvalidPlayers(t *testing.T) {
 	for _, playerCount := range []int{-2, -1, 0, 1, 5, 6} {
-		_, err := NewGame(playerCount, nil)
+		_, err := NewGame(playerCount, nil, nil)
 		assert.EqualError(t, err, "invalid number of players")
 	}
 }
@@ -146,14 +264,14 @@ func TestGameCopy(t *testing.T) {
 }
 
 func TestGameStarted(t *testing.T) {
-	game, _ := NewGame(4, nil)
+	game, _ := NewGame(4, nil, nil)
 	assert.False(t, game.Started())
 	game.Track("whatever", nil, nil)
 	assert.True(t, game.Started())
 }
 
 func TestGameCompletedAndWinner(t *testing.T) {
-	game, _ := NewGame(4, nil)
+	game, _ := NewGame(4, nil, nil)
 
 	// move all but last pawn into home for all of the players; the game is not complete
 	for _, value := range game.Players() {
@@ -170,10 +288,51 @@ func TestGameCompletedAndWinner(t *testing.T) {
 	assert.Equal(t, &expected, game.Winner())
 }
 
+func TestGameStartTurn(t *testing.T) {
+	game, _ := NewGame(4, &factory, nil)
+	assert.Equal(t, 0, game.Turn())
+
+	game.Track("before", nil, nil)
+	game.StartTurn()
+	game.StartTurn()
+	game.Track("during", nil, nil)
+	assert.Equal(t, 2, game.Turn())
+
+	// history is associated with whatever turn was in progress when it was tracked
+	assert.Equal(t, 0, game.History()[0].Turn())
+	assert.Equal(t, 2, game.History()[1].Turn())
+
+	copied := game.Copy()
+	assert.Equal(t, 2, copied.Turn())
+	copied.StartTurn()
+	assert.Equal(t, 3, copied.Turn())
+	assert.Equal(t, 2, game.Turn())
+}
+
+func TestGameTrackMove(t *testing.T) {
+	game, _ := NewGame(4, &factory, nil)
+	player := NewPlayer(Red)
+	move := createHistoryMove()
+	game.StartTurn()
+	game.TrackMove("action", player, move)
+	assert.Equal(t, NewHistory("action", &Red, &Card12, move, 1, &factory), game.History()[0])
+	assert.Equal(t, 1, game.Players()[Red].Turns())
+	assert.Equal(t, 0, game.Players()[Yellow].Turns())
+}
+
+func TestGameTrackNotice(t *testing.T) {
+	game, _ := NewGame(4, &factory, nil)
+	game.StartTurn()
+	game.TrackNotice("notice", Blue)
+	assert.Equal(t, NewNotice("notice", Blue, 1, &factory), game.History()[0])
+	assert.True(t, game.History()[0].Notice())
+	assert.Equal(t, 0, game.Players()[Blue].Turns())
+}
+
 func TestGameTrackNoPlayer(t *testing.T) {
-	game, _ := NewGame(4, &factory)
+	game, _ := NewGame(4, &factory, nil)
 	game.Track("action", nil, nil)
-	assert.Equal(t, NewHistory("action", nil, nil, &factory), game.History()[0])
+	assert.Equal(t, NewHistory("action", nil, nil, nil, 0, &factory), game.History()[0])
 	assert.Equal(t, 0, game.Players()[Red].Turns())
 	assert.Equal(t, 0, game.Players()[Yellow].Turns())
 	assert.Equal(t, 0, game.Players()[Blue].Turns())
@@ -181,11 +340,11 @@ func TestGameTrackNoPlayer(t *testing.T) {
 }
 
 func TestGameTrackWithColor(t *testing.T) {
-	game, _ := NewGame(4, &factory)
+	game, _ := NewGame(4, &factory, nil)
 	player := NewPlayer(Red)
 	card := NewCard("x", Card12)
 	game.Track("action", player, card)
-	assert.Equal(t, NewHistory("action", &Red, &Card12, &factory), game.History()[0])
+	assert.Equal(t, NewHistory("action", &Red, &Card12, nil, 0, &factory), game.History()[0])
 	assert.Equal(t, 1, game.Players()[Red].Turns())
 	assert.Equal(t, 0, game.Players()[Yellow].Turns())
 	assert.Equal(t, 0, game.Players()[Blue].Turns())
@@ -193,7 +352,7 @@ func TestGameTrackWithColor(t *testing.T) {
 }
 
 func TestGameCreatePlayerViewInvalid(t *testing.T) {
-	game, _ := NewGame(2, nil)
+	game, _ := NewGame(2, nil, nil)
 	_, err := game.CreatePlayerView(Blue) // no blue player in 2-player game
 	assert.EqualError(t, err, "invalid color")
 }
@@ -202,7 +361,7 @@ func TestGameCreatePlayerView(t *testing.T) {
 	var card Card
 	var err error
 
-	game, _ := NewGame(4, nil)
+	game, _ := NewGame(4, nil, nil)
 
 	card, err = game.Deck().Draw()
 	assert.NoError(t, err)
@@ -220,9 +379,17 @@ func TestGameCreatePlayerView(t *testing.T) {
 	assert.NoError(t, err)
 	game.Players()[Blue].AppendToHand(card)
 
+	card, err = game.Deck().Draw()
+	assert.NoError(t, err)
+	assert.NoError(t, game.Deck().Discard(card))
+
 	view, err := game.CreatePlayerView(Red)
 	assert.NoError(t, err)
 
+	// the discard pile is public, so it's part of every view
+	assert.Equal(t, []Card{card}, view.DiscardPile())
+	assert.NotSame(t, card, view.DiscardPile()[0])
+
 	assert.NotSame(t, game.Players()[Red], view.Player())
 	assert.NotSame(t, game.Players()[Yellow], view.Opponents()[Yellow])
 
@@ -235,11 +402,30 @@ func TestGameCreatePlayerView(t *testing.T) {
 	}
 }
 
+func editJSON(t *testing.T, marshalled []byte, edit func(raw map[string]any)) []byte {
+	var raw map[string]any
+	assert.NoError(t, json.Unmarshal(marshalled, &raw))
+	edit(raw)
+	edited, err := json.Marshal(raw)
+	assert.NoError(t, err)
+	return edited
+}
+
+func createHistoryMove() Move {
+	pawn := NewPawn(Red, 1)
+	square := 32
+	actions := []Action{NewAction(MoveToPosition, pawn, NewPosition(false, false, nil, &square))}
+	sideEffects := []Action{NewAction(MoveToStart, NewPawn(Blue, 2), nil)}
+	return NewMove(NewCard("x", Card12), actions, sideEffects)
+}
+
 func createRealisticGame() Game {
 	// creates a realistic game with changes to the defaults for all types of values
-	game, _ := NewGame(4, nil)
+	game, _ := NewGame(4, nil, nil)
 	game.Track("this happened", nil, nil)
+	game.StartTurn()
 	game.Track("another thing", game.Players()[Red], nil)
+	game.TrackMove("moved", game.Players()[Red], createHistoryMove())
 	card1, _ := game.Deck().Draw()
 	card2, _ := game.Deck().Draw()
 	_, _ = game.Deck().Draw() // just throw it away
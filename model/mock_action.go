@@ -9,6 +9,26 @@ type MockAction struct {
 	mock.Mock
 }
 
+// Copy provides a mock function with given fields:
+func (_m *MockAction) Copy() Action {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Copy")
+	}
+
+	var r0 Action
+	if rf, ok := ret.Get(0).(func() Action); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Action)
+		}
+	}
+
+	return r0
+}
+
 // Pawn provides a mock function with given fields:
 func (_m *MockAction) Pawn() Pawn {
 	ret := _m.Called()
@@ -54,6 +74,29 @@ func (_m *MockAction) SetPosition(position Position) {
 	_m.Called(position)
 }
 
+// SetSlid provides a mock function with given fields: slid
+func (_m *MockAction) SetSlid(slid bool) {
+	_m.Called(slid)
+}
+
+// Slid provides a mock function with given fields:
+func (_m *MockAction) Slid() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Slid")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Type provides a mock function with given fields:
 func (_m *MockAction) Type() ActionType {
 	ret := _m.Called()
@@ -2,13 +2,36 @@
 
 package model
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	random "github.com/pronovic/go-apologies/random"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockDeck is an autogenerated mock type for the Deck type
 type MockDeck struct {
 	mock.Mock
 }
 
+// Cards provides a mock function with given fields:
+func (_m *MockDeck) Cards() []Card {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cards")
+	}
+
+	var r0 []Card
+	if rf, ok := ret.Get(0).(func() []Card); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Card)
+		}
+	}
+
+	return r0
+}
+
 // Copy provides a mock function with given fields:
 func (_m *MockDeck) Copy() Deck {
 	ret := _m.Called()
@@ -47,6 +70,44 @@ func (_m *MockDeck) Discard(card Card) error {
 	return r0
 }
 
+// DiscardPile provides a mock function with given fields:
+func (_m *MockDeck) DiscardPile() []Card {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiscardPile")
+	}
+
+	var r0 []Card
+	if rf, ok := ret.Get(0).(func() []Card); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Card)
+		}
+	}
+
+	return r0
+}
+
+// DiscardPileSize provides a mock function with given fields:
+func (_m *MockDeck) DiscardPileSize() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiscardPileSize")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // Draw provides a mock function with given fields:
 func (_m *MockDeck) Draw() (Card, error) {
 	ret := _m.Called()
@@ -77,6 +138,67 @@ func (_m *MockDeck) Draw() (Card, error) {
 	return r0, r1
 }
 
+// DrawPileSize provides a mock function with given fields:
+func (_m *MockDeck) DrawPileSize() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DrawPileSize")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// Randomizer provides a mock function with given fields:
+func (_m *MockDeck) Randomizer() random.Randomizer {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Randomizer")
+	}
+
+	var r0 random.Randomizer
+	if rf, ok := ret.Get(0).(func() random.Randomizer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(random.Randomizer)
+		}
+	}
+
+	return r0
+}
+
+// Remove provides a mock function with given fields: card
+func (_m *MockDeck) Remove(card Card) error {
+	ret := _m.Called(card)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(Card) error); ok {
+		r0 = rf(card)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetRandomizer provides a mock function with given fields: randomizer
+func (_m *MockDeck) SetRandomizer(randomizer random.Randomizer) {
+	_m.Called(randomizer)
+}
+
 // NewMockDeck creates a new instance of MockDeck. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockDeck(t interface {
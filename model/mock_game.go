@@ -155,6 +155,11 @@ func (_m *MockGame) Players() map[PlayerColor]Player {
 	return r0
 }
 
+// StartTurn provides a mock function with given fields:
+func (_m *MockGame) StartTurn() {
+	_m.Called()
+}
+
 // Started provides a mock function with given fields:
 func (_m *MockGame) Started() bool {
 	ret := _m.Called()
@@ -178,6 +183,34 @@ func (_m *MockGame) Track(action string, player Player, card Card) {
 	_m.Called(action, player, card)
 }
 
+// TrackMove provides a mock function with given fields: action, player, move
+func (_m *MockGame) TrackMove(action string, player Player, move Move) {
+	_m.Called(action, player, move)
+}
+
+// TrackNotice provides a mock function with given fields: action, color
+func (_m *MockGame) TrackNotice(action string, color PlayerColor) {
+	_m.Called(action, color)
+}
+
+// Turn provides a mock function with given fields:
+func (_m *MockGame) Turn() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Turn")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // Winner provides a mock function with given fields:
 func (_m *MockGame) Winner() *Player {
 	ret := _m.Called()
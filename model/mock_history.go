@@ -90,6 +90,44 @@ func (_m *MockHistory) Copy() History {
 	return r0
 }
 
+// Move provides a mock function with given fields:
+func (_m *MockHistory) Move() Move {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Move")
+	}
+
+	var r0 Move
+	if rf, ok := ret.Get(0).(func() Move); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Move)
+		}
+	}
+
+	return r0
+}
+
+// Notice provides a mock function with given fields:
+func (_m *MockHistory) Notice() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Notice")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Timestamp provides a mock function with given fields:
 func (_m *MockHistory) Timestamp() timestamp.Timestamp {
 	ret := _m.Called()
@@ -108,6 +146,24 @@ func (_m *MockHistory) Timestamp() timestamp.Timestamp {
 	return r0
 }
 
+// Turn provides a mock function with given fields:
+func (_m *MockHistory) Turn() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Turn")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // NewMockHistory creates a new instance of MockHistory. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockHistory(t interface {
@@ -54,6 +54,26 @@ func (_m *MockMove) Card() Card {
 	return r0
 }
 
+// Copy provides a mock function with given fields:
+func (_m *MockMove) Copy() Move {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Copy")
+	}
+
+	var r0 Move
+	if rf, ok := ret.Get(0).(func() Move); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Move)
+		}
+	}
+
+	return r0
+}
+
 // MergedActions provides a mock function with given fields:
 func (_m *MockMove) MergedActions() []Action {
 	ret := _m.Called()
@@ -49,6 +49,26 @@ func (_m *MockPlayerView) Copy() PlayerView {
 	return r0
 }
 
+// DiscardPile provides a mock function with given fields:
+func (_m *MockPlayerView) DiscardPile() []Card {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiscardPile")
+	}
+
+	var r0 []Card
+	if rf, ok := ret.Get(0).(func() []Card); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Card)
+		}
+	}
+
+	return r0
+}
+
 // GetPawn provides a mock function with given fields: prototype
 func (_m *MockPlayerView) GetPawn(prototype Pawn) Pawn {
 	ret := _m.Called(prototype)
@@ -35,12 +35,22 @@ type Action interface {
 
 	// SetPosition Set the position on the action (can be nil)
 	SetPosition(position Position)
+
+	// Slid Whether the pawn reached its position by taking a slide
+	Slid() bool
+
+	// SetSlid Set whether the pawn reached its position by taking a slide
+	SetSlid(slid bool)
+
+	// Copy Return a fully-independent copy of the action.
+	Copy() Action
 }
 
 type action struct {
 	XactionType ActionType `json:"type"`
 	Xpawn       Pawn       `json:"pawn"`
 	Xposition   Position   `json:"position"`
+	Xslid       bool       `json:"slid"`
 }
 
 // NewAction constructs a new Action
@@ -58,6 +68,7 @@ func NewActionFromJSON(reader io.Reader) (Action, error) {
 		XactionType ActionType      `json:"type"`
 		Xpawn       json.RawMessage `json:"pawn"`
 		Xposition   json.RawMessage `json:"position"`
+		Xslid       bool            `json:"slid"`
 	}
 
 	var temp raw
@@ -82,6 +93,7 @@ func NewActionFromJSON(reader io.Reader) (Action, error) {
 		XactionType: temp.XactionType,
 		Xpawn:       Xpawn,
 		Xposition:   Xposition,
+		Xslid:       temp.Xslid,
 	}
 
 	return &obj, nil
@@ -103,6 +115,33 @@ func (a *action) SetPosition(position Position) {
 	a.Xposition = position
 }
 
+func (a *action) Slid() bool {
+	return a.Xslid
+}
+
+func (a *action) SetSlid(slid bool) {
+	a.Xslid = slid
+}
+
+func (a *action) Copy() Action {
+	var pawn Pawn
+	if a.Xpawn != nil {
+		pawn = a.Xpawn.Copy()
+	}
+
+	var position Position
+	if a.Xposition != nil {
+		position = a.Xposition.Copy()
+	}
+
+	return &action{
+		XactionType: a.XactionType,
+		Xpawn:       pawn,
+		Xposition:   position,
+		Xslid:       a.Xslid,
+	}
+}
+
 // Move is a player's move on the board, which consists of one or more actions
 //
 // Note that the actions associated with a move include both the immediate actions that the player
@@ -116,6 +155,7 @@ type Move interface {
 	SideEffects() []Action
 	AddSideEffect(action Action)
 	MergedActions() []Action
+	Copy() Move
 }
 
 type move struct {
@@ -216,9 +256,33 @@ func (m *move) AddSideEffect(action Action) {
 	}
 }
 
+func (m *move) Copy() Move {
+	var card Card
+	if m.Xcard != nil {
+		card = m.Xcard.Copy()
+	}
+
+	return &move{
+		Xid:          m.Xid,
+		Xcard:        card,
+		Xactions:     copyActions(m.Xactions),
+		XsideEffects: copyActions(m.XsideEffects),
+	}
+}
+
 func (m *move) MergedActions() []Action {
 	merged := make([]Action, 0, len(m.Xactions)+len(m.XsideEffects))
 	merged = append(merged, m.Xactions...)
 	merged = append(merged, m.XsideEffects...)
 	return merged
 }
+
+// copyActions returns a fully-independent copy of a list of actions
+func copyActions(actions []Action) []Action {
+	copied := make([]Action, 0, len(actions))
+	for _, a := range actions {
+		copied = append(copied, a.Copy())
+	}
+
+	return copied
+}
@@ -15,6 +15,7 @@ func TestNewAction(t *testing.T) {
 	assert.Equal(t, MoveToPosition, obj.Type())
 	assert.Same(t, pawn, obj.Pawn())
 	assert.Same(t, position, obj.Position())
+	assert.False(t, obj.Slid())
 }
 
 func TestNewActionFromJSON(t *testing.T) {
@@ -31,6 +32,17 @@ func TestNewActionFromJSON(t *testing.T) {
 	unmarshalled, err = NewActionFromJSON(bytes.NewReader(marshalled))
 	assert.NoError(t, err)
 	assert.Equal(t, obj, unmarshalled)
+
+	position2 := NewPosition(false, false, nil, nil)
+	_ = position2.MoveToSquare(19)
+	obj = NewAction(MoveToPosition, pawn1, position2)
+	obj.SetSlid(true)
+	marshalled, err = json.Marshal(obj)
+	assert.NoError(t, err)
+	unmarshalled, err = NewActionFromJSON(bytes.NewReader(marshalled))
+	assert.NoError(t, err)
+	assert.Equal(t, obj, unmarshalled)
+	assert.True(t, unmarshalled.Slid())
 }
 
 func TestActionSetPosition(t *testing.T) {
@@ -42,6 +54,28 @@ func TestActionSetPosition(t *testing.T) {
 	assert.Same(t, position2, obj.Position())
 }
 
+func TestActionSetSlid(t *testing.T) {
+	obj := NewAction(MoveToPosition, NewPawn(Red, 0), NewPosition(false, false, nil, nil))
+	obj.SetSlid(true)
+	assert.True(t, obj.Slid())
+	obj.SetSlid(false)
+	assert.False(t, obj.Slid())
+}
+
+func TestActionCopy(t *testing.T) {
+	square := 32
+	obj := NewAction(MoveToPosition, NewPawn(Red, 0), NewPosition(false, false, nil, &square))
+	obj.SetSlid(true)
+	copied := obj.Copy()
+	assert.Equal(t, obj, copied)
+	assert.NotSame(t, obj, copied)
+	assert.NotSame(t, obj.Pawn(), copied.Pawn())
+	assert.NotSame(t, obj.Position(), copied.Position())
+
+	obj = NewAction(MoveToStart, nil, nil)
+	assert.Equal(t, obj, obj.Copy())
+}
+
 func TestNewMove(t *testing.T) {
 	card := NewCard("1", Card1)
 	actions := make([]Action, 1, 2)
@@ -92,6 +126,25 @@ func TestNewMoveNilSlice(t *testing.T) {
 	assert.Equal(t, sideEffects, obj.SideEffects()) // nil is converted to a newly-allocated empty slice
 }
 
+func TestMoveCopy(t *testing.T) {
+	square := 32
+	action := NewAction(MoveToPosition, NewPawn(Red, 0), NewPosition(false, false, nil, &square))
+	sideEffect := NewAction(MoveToStart, NewPawn(Blue, 2), nil)
+	obj := NewMove(NewCard("1", Card1), []Action{action}, []Action{sideEffect})
+	copied := obj.Copy()
+	assert.Equal(t, obj, copied)
+	assert.NotSame(t, obj, copied)
+	assert.NotSame(t, obj.Card(), copied.Card())
+	assert.NotSame(t, obj.Actions()[0], copied.Actions()[0])
+	assert.NotSame(t, obj.SideEffects()[0], copied.SideEffects()[0])
+
+	// changing the original does not change the copy
+	obj.AddSideEffect(NewAction(MoveToStart, NewPawn(Green, 1), nil))
+	_ = obj.Actions()[0].Position().MoveToSquare(33)
+	assert.Equal(t, 1, len(copied.SideEffects()))
+	assert.Equal(t, 32, *copied.Actions()[0].Position().Square())
+}
+
 func TestMoveAddSideEffect(t *testing.T) {
 	card := NewCard("1", Card1)
 	actions := make([]Action, 0)
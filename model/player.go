@@ -226,6 +226,9 @@ type PlayerView interface {
 	// Opponents The player's opponents, with private information stripped
 	Opponents() map[PlayerColor]Player
 
+	// DiscardPile The cards in the discard pile, which are visible to every player
+	DiscardPile() []Card
+
 	// Copy Return a fully-independent copy of the player view.
 	Copy() PlayerView
 
@@ -237,23 +240,30 @@ type PlayerView interface {
 }
 
 type playerView struct {
-	Xplayer    Player                 `json:"player"`
-	Xopponents map[PlayerColor]Player `json:"opponents"`
+	Xplayer      Player                 `json:"player"`
+	Xopponents   map[PlayerColor]Player `json:"opponents"`
+	XdiscardPile []Card                 `json:"discard"`
 }
 
-// NewPlayerView contructs a new PlayerView
-func NewPlayerView(player Player, opponents map[PlayerColor]Player) PlayerView {
+// NewPlayerView contructs a new PlayerView, optionally accepting the cards in the discard pile
+func NewPlayerView(player Player, opponents map[PlayerColor]Player, discardPile []Card) PlayerView {
+	if discardPile == nil {
+		discardPile = make([]Card, 0)
+	}
+
 	return &playerView{
-		Xplayer:    player,
-		Xopponents: opponents,
+		Xplayer:      player,
+		Xopponents:   opponents,
+		XdiscardPile: discardPile,
 	}
 }
 
 // NewPlayerViewFromJSON constructs a new object from JSON in an io.Reader
 func NewPlayerViewFromJSON(reader io.Reader) (PlayerView, error) {
 	type raw struct {
-		Xplayer    json.RawMessage                 `json:"player"`
-		Xopponents map[PlayerColor]json.RawMessage `json:"opponents"`
+		Xplayer      json.RawMessage                 `json:"player"`
+		Xopponents   map[PlayerColor]json.RawMessage `json:"opponents"`
+		XdiscardPile []json.RawMessage               `json:"discard"`
 	}
 
 	var temp raw
@@ -274,9 +284,16 @@ func NewPlayerViewFromJSON(reader io.Reader) (PlayerView, error) {
 		return nil, err
 	}
 
+	var XdiscardPile []Card
+	XdiscardPile, err = jsonutil.DecodeSliceJSON(temp.XdiscardPile, NewCardFromJSON)
+	if err != nil {
+		return nil, err
+	}
+
 	obj := playerView{
-		Xplayer:    Xplayer,
-		Xopponents: Xopponents,
+		Xplayer:      Xplayer,
+		Xopponents:   Xopponents,
+		XdiscardPile: XdiscardPile,
 	}
 
 	return &obj, nil
@@ -290,6 +307,10 @@ func (v *playerView) Opponents() map[PlayerColor]Player {
 	return v.Xopponents
 }
 
+func (v *playerView) DiscardPile() []Card {
+	return v.XdiscardPile
+}
+
 func (v *playerView) Copy() PlayerView {
 	opponentsCopy := make(map[PlayerColor]Player, len(v.Xopponents))
 
@@ -301,9 +322,15 @@ func (v *playerView) Copy() PlayerView {
 		}
 	}
 
+	discardPileCopy := make([]Card, 0, len(v.XdiscardPile))
+	for _, card := range v.XdiscardPile {
+		discardPileCopy = append(discardPileCopy, card.Copy())
+	}
+
 	return &playerView{
-		Xplayer:    v.Xplayer.Copy(),
-		Xopponents: opponentsCopy,
+		Xplayer:      v.Xplayer.Copy(),
+		Xopponents:   opponentsCopy,
+		XdiscardPile: discardPileCopy,
 	}
 }
 
@@ -149,10 +149,15 @@ func TestNewPlayerView(t *testing.T) {
 	opponents := make(map[PlayerColor]Player, 1)
 	opponents[Red] = player2
 
-	obj := NewPlayerView(player1, opponents)
+	obj := NewPlayerView(player1, opponents, nil)
 	assert.Equal(t, player1, obj.Player())
 	assert.Equal(t, opponents, obj.Opponents())
 	assert.Equal(t, player2, obj.Opponents()[Red])
+	assert.Equal(t, []Card{}, obj.DiscardPile())
+
+	discardPile := []Card{NewCard("1", Card1), NewCard("2", Card12)}
+	obj = NewPlayerView(player1, opponents, discardPile)
+	assert.Equal(t, discardPile, obj.DiscardPile())
 }
 
 func TestNewPlayerViewFromJSON(t *testing.T) {
@@ -165,7 +170,7 @@ func TestNewPlayerViewFromJSON(t *testing.T) {
 	player2 := NewPlayer(Red)
 	opponents := make(map[PlayerColor]Player, 1)
 	opponents[Red] = player2
-	obj = NewPlayerView(player1, opponents)
+	obj = NewPlayerView(player1, opponents, []Card{NewCard("1", Card1)})
 
 	marshalled, err = json.Marshal(obj)
 	assert.NoError(t, err)
@@ -181,16 +186,17 @@ func TestPlayerViewCopy(t *testing.T) {
 	opponents := make(map[PlayerColor]Player, 1)
 	opponents[Red] = player2
 
-	obj := NewPlayerView(player1, opponents)
+	obj := NewPlayerView(player1, opponents, []Card{NewCard("1", Card1)})
 	copied := obj.Copy()
 	assert.Equal(t, obj, copied)
 	assert.NotSame(t, obj, copied)
+	assert.NotSame(t, obj.DiscardPile()[0], copied.DiscardPile()[0])
 }
 
 func TestPlayerViewGetPawn(t *testing.T) {
 	player1 := NewPlayer(Red)
 	opponents := map[PlayerColor]Player{Green: NewPlayer(Green)}
-	view := NewPlayerView(player1, opponents)
+	view := NewPlayerView(player1, opponents, nil)
 	assert.Equal(t, view.Player().Pawns()[3], view.GetPawn(NewPawn(Red, 3)))
 	assert.Equal(t, view.Opponents()[Green].Pawns()[1], view.GetPawn(NewPawn(Green, 1)))
 	assert.Nil(t, view.GetPawn(NewPawn(Yellow, 0)))
@@ -199,7 +205,7 @@ func TestPlayerViewGetPawn(t *testing.T) {
 func TestPlayerViewAllPawns(t *testing.T) {
 	player1 := NewPlayer(Red)
 	opponents := map[PlayerColor]Player{Green: NewPlayer(Green)}
-	view := NewPlayerView(player1, opponents)
+	view := NewPlayerView(player1, opponents, nil)
 	pawns := view.AllPawns()
 	assert.Equal(t, 2*Pawns, len(pawns))
 	for i := 0; i < Pawns; i++ {
@@ -0,0 +1,340 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pronovic/go-apologies/internal/enum"
+)
+
+// ViolationType defines the kinds of problems that can be found when validating a game
+type ViolationType struct{ value string }
+
+func (e ViolationType) Value() string                         { return e.value }
+func (e ViolationType) MarshalText() (text []byte, err error) { return enum.Marshal(e) }
+func (e *ViolationType) UnmarshalText(text []byte) error {
+	return enum.Unmarshal(e, text, ViolationTypes)
+}
+
+var (
+	ViolationTypes   = enum.NewValues[ViolationType](CardConservation, DuplicateCard, MissingCard, MissingHistory, InvalidPlayers, InvalidPosition, PawnCount, PawnOverlap)
+	CardConservation = ViolationType{"CardConservation"}
+	DuplicateCard    = ViolationType{"DuplicateCard"}
+	MissingCard      = ViolationType{"MissingCard"}
+	MissingHistory   = ViolationType{"MissingHistory"}
+	InvalidPlayers   = ViolationType{"InvalidPlayers"}
+	InvalidPosition  = ViolationType{"InvalidPosition"}
+	PawnCount        = ViolationType{"PawnCount"}
+	PawnOverlap      = ViolationType{"PawnOverlap"}
+)
+
+// Violation A single problem found when validating a game
+type Violation struct {
+	Type    ViolationType `json:"type"`
+	Message string        `json:"message"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Type.Value(), v.Message)
+}
+
+// ValidationError An error returned when a game fails validation, listing every violation that was found
+type ValidationError struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.String())
+	}
+
+	return fmt.Sprintf("invalid game: %s", strings.Join(messages, "; "))
+}
+
+// ValidateGame checks the invariants that should hold for any game at the end of a turn, returning
+// a *ValidationError listing all violations if any are found.
+//
+// The player count must be legal and must match the players in the game, and every pawn must have
+// exactly one valid position.  Every card must be accounted for: the draw pile, the discard pile,
+// and all players' hands together must contain exactly DeckSize cards with unique ids, with exactly
+// DeckCounts cards of each type, and no hand, pile, or history may contain an empty entry.  Every
+// player must have exactly one of each of its pawns, and no two pawns may occupy the same square.
+func ValidateGame(game Game) error {
+	if game == nil {
+		return errors.New("game is nil")
+	}
+
+	violations := make([]Violation, 0)
+	violations = append(violations, validatePlayers(game)...)
+	violations = append(violations, validatePositions(game)...)
+	violations = append(violations, validateCards(game)...)
+	violations = append(violations, validateCardIds(game)...)
+	violations = append(violations, validateEntries(game)...)
+	violations = append(violations, validatePawnCounts(game)...)
+	violations = append(violations, validatePawnOverlap(game)...)
+
+	if len(violations) > 0 {
+		return &ValidationError{violations}
+	}
+
+	return nil
+}
+
+// allPlayers returns all of the players in a game, in color order, ignoring any that are missing
+func allPlayers(game Game) []Player {
+	players := make([]Player, 0, MaxPlayers)
+
+	// range on a map explicitly does *not* return keys in a stable order, so we iterate on colors instead
+	for _, color := range PlayerColors.Members() {
+		player := game.Players()[color]
+		if player != nil {
+			players = append(players, player)
+		}
+	}
+
+	return players
+}
+
+// allCards returns all of the cards in a game, from the deck and from every player's hand
+func allCards(game Game) []Card {
+	cards := make([]Card, 0, DeckSize)
+
+	if game.Deck() != nil {
+		cards = append(cards, game.Deck().Cards()...)
+	}
+
+	for _, player := range allPlayers(game) {
+		for _, card := range player.Hand() {
+			if card != nil {
+				cards = append(cards, card)
+			}
+		}
+	}
+
+	return cards
+}
+
+func validatePlayers(game Game) []Violation {
+	violations := make([]Violation, 0)
+
+	if game.PlayerCount() < MinPlayers || game.PlayerCount() > MaxPlayers {
+		message := fmt.Sprintf("invalid player count %d", game.PlayerCount())
+		violations = append(violations, Violation{InvalidPlayers, message})
+	}
+
+	if len(game.Players()) != game.PlayerCount() {
+		message := fmt.Sprintf("expected %d players but found %d", game.PlayerCount(), len(game.Players()))
+		violations = append(violations, Violation{InvalidPlayers, message})
+	}
+
+	for _, color := range PlayerColors.Members() {
+		player, exists := game.Players()[color]
+		if !exists {
+			continue
+		}
+
+		if player == nil {
+			message := fmt.Sprintf("player for %s is missing", color.Value())
+			violations = append(violations, Violation{InvalidPlayers, message})
+		} else if player.Color() != color {
+			message := fmt.Sprintf("player for %s has color %s", color.Value(), player.Color().Value())
+			violations = append(violations, Violation{InvalidPlayers, message})
+		}
+	}
+
+	return violations
+}
+
+func validatePositions(game Game) []Violation {
+	violations := make([]Violation, 0)
+
+	for _, player := range allPlayers(game) {
+		for _, pawn := range player.Pawns() {
+			if pawn == nil {
+				continue
+			}
+
+			position := pawn.Position()
+			if position == nil {
+				message := fmt.Sprintf("pawn %s has no position", pawn.Name())
+				violations = append(violations, Violation{InvalidPosition, message})
+				continue
+			}
+
+			fields := 0
+			for _, set := range []bool{position.Start(), position.Home(), position.Safe() != nil, position.Square() != nil} {
+				if set {
+					fields += 1
+				}
+			}
+
+			if fields != 1 {
+				message := fmt.Sprintf("pawn %s has %d position fields set", pawn.Name(), fields)
+				violations = append(violations, Violation{InvalidPosition, message})
+			} else if position.Safe() != nil && (*position.Safe() < 0 || *position.Safe() >= SafeSquares) {
+				message := fmt.Sprintf("pawn %s is on invalid safe square %d", pawn.Name(), *position.Safe())
+				violations = append(violations, Violation{InvalidPosition, message})
+			} else if position.Square() != nil && (*position.Square() < 0 || *position.Square() >= BoardSquares) {
+				message := fmt.Sprintf("pawn %s is on invalid square %d", pawn.Name(), *position.Square())
+				violations = append(violations, Violation{InvalidPosition, message})
+			}
+		}
+	}
+
+	return violations
+}
+
+func validateCards(game Game) []Violation {
+	violations := make([]Violation, 0)
+
+	if game.Deck() == nil {
+		violations = append(violations, Violation{CardConservation, "deck is missing"})
+	}
+
+	cards := allCards(game)
+	if len(cards) != DeckSize {
+		message := fmt.Sprintf("expected %d cards but found %d", DeckSize, len(cards))
+		violations = append(violations, Violation{CardConservation, message})
+	}
+
+	counts := make(map[CardType]int, len(CardTypes.Members()))
+	for _, card := range cards {
+		counts[card.Type()] += 1
+	}
+
+	for _, cardType := range CardTypes.Members() {
+		if counts[cardType] != DeckCounts[cardType] {
+			message := fmt.Sprintf("expected %d cards of type %s but found %d", DeckCounts[cardType], cardType.Value(), counts[cardType])
+			violations = append(violations, Violation{CardConservation, message})
+		}
+	}
+
+	return violations
+}
+
+func validateCardIds(game Game) []Violation {
+	violations := make([]Violation, 0)
+
+	seen := make(map[string]int, DeckSize)
+	for _, card := range allCards(game) {
+		seen[card.Id()] += 1
+		if seen[card.Id()] == 2 {
+			message := fmt.Sprintf("card id %s is used more than once", card.Id())
+			violations = append(violations, Violation{DuplicateCard, message})
+		}
+	}
+
+	return violations
+}
+
+// validateEntries finds empty entries, which a game loaded from corrupted JSON might contain, and which the other checks skip
+func validateEntries(game Game) []Violation {
+	violations := make([]Violation, 0)
+
+	if d, ok := game.Deck().(*deck); ok {
+		for _, pile := range []struct {
+			name  string
+			cards map[string]Card
+		}{{"draw pile", d.XdrawPile}, {"discard pile", d.XdiscardPile}} {
+			ids := make([]string, 0)
+			for id, card := range pile.cards {
+				if card == nil {
+					ids = append(ids, id)
+				}
+			}
+
+			slices.SortFunc(ids, compareIds)
+			for _, id := range ids {
+				message := fmt.Sprintf("%s entry %s is empty", pile.name, id)
+				violations = append(violations, Violation{MissingCard, message})
+			}
+		}
+	}
+
+	for _, player := range allPlayers(game) {
+		for i, card := range player.Hand() {
+			if card == nil {
+				message := fmt.Sprintf("hand for %s has an empty card at index %d", player.Color().Value(), i)
+				violations = append(violations, Violation{MissingCard, message})
+			}
+		}
+	}
+
+	for i, history := range game.History() {
+		if history == nil {
+			message := fmt.Sprintf("history entry %d is empty", i)
+			violations = append(violations, Violation{MissingHistory, message})
+		}
+	}
+
+	return violations
+}
+
+func validatePawnCounts(game Game) []Violation {
+	violations := make([]Violation, 0)
+
+	for _, player := range allPlayers(game) {
+		color := player.Color()
+
+		if len(player.Pawns()) != Pawns {
+			message := fmt.Sprintf("expected %d pawns for %s but found %d", Pawns, color.Value(), len(player.Pawns()))
+			violations = append(violations, Violation{PawnCount, message})
+		}
+
+		seen := make(map[int]bool, Pawns)
+		for _, pawn := range player.Pawns() {
+			if pawn == nil {
+				message := fmt.Sprintf("missing pawn for %s", color.Value())
+				violations = append(violations, Violation{PawnCount, message})
+				continue
+			}
+
+			if pawn.Color() != color || pawn.Index() < 0 || pawn.Index() >= Pawns || seen[pawn.Index()] {
+				message := fmt.Sprintf("unexpected pawn %s for %s", pawn.Name(), color.Value())
+				violations = append(violations, Violation{PawnCount, message})
+			}
+			seen[pawn.Index()] = true
+		}
+	}
+
+	return violations
+}
+
+func validatePawnOverlap(game Game) []Violation {
+	violations := make([]Violation, 0)
+
+	squares := make(map[int]Pawn)
+	safes := make(map[string]Pawn)
+
+	for _, player := range allPlayers(game) {
+		for _, pawn := range player.Pawns() {
+			if pawn == nil || pawn.Position() == nil {
+				continue
+			}
+
+			position := pawn.Position()
+
+			// any number of pawns may be in start or home, but squares are exclusive, and each color has its own safe zone
+			if position.Square() != nil {
+				square := *position.Square()
+				if other, found := squares[square]; found {
+					message := fmt.Sprintf("pawns %s and %s are both on square %d", other.Name(), pawn.Name(), square)
+					violations = append(violations, Violation{PawnOverlap, message})
+				}
+				squares[square] = pawn
+			} else if position.Safe() != nil {
+				key := fmt.Sprintf("%s-%d", pawn.Color().Value(), *position.Safe())
+				if other, found := safes[key]; found {
+					message := fmt.Sprintf("pawns %s and %s are both on safe square %d", other.Name(), pawn.Name(), *position.Safe())
+					violations = append(violations, Violation{PawnOverlap, message})
+				}
+				safes[key] = pawn
+			}
+		}
+	}
+
+	return violations
+}
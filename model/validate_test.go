@@ -0,0 +1,172 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViolationString(t *testing.T) {
+	obj := Violation{PawnOverlap, "message"}
+	assert.Equal(t, "PawnOverlap: message", obj.String())
+}
+
+func TestValidationError(t *testing.T) {
+	obj := &ValidationError{[]Violation{{CardConservation, "one"}, {PawnCount, "two"}}}
+	assert.EqualError(t, obj, "invalid game: CardConservation: one; PawnCount: two")
+}
+
+func TestValidateGameNil(t *testing.T) {
+	assert.EqualError(t, ValidateGame(nil), "game is nil")
+}
+
+func TestValidateGameValid(t *testing.T) {
+	game, _ := NewGame(4, nil, nil)
+	assert.NoError(t, ValidateGame(game))
+
+	// cards can move freely between the draw pile, the discard pile, and the players' hands
+	card1, _ := game.Deck().Draw()
+	card2, _ := game.Deck().Draw()
+	card3, _ := game.Deck().Draw()
+	_ = game.Deck().Discard(card1)
+	game.Players()[Red].AppendToHand(card2)
+	game.Players()[Blue].AppendToHand(card3)
+
+	// any number of pawns can share start and home, and each color has its own safe zone
+	_ = game.Players()[Red].Pawns()[0].Position().MoveToHome()
+	_ = game.Players()[Red].Pawns()[1].Position().MoveToHome()
+	_ = game.Players()[Red].Pawns()[2].Position().MoveToSafe(2)
+	_ = game.Players()[Blue].Pawns()[0].Position().MoveToSafe(2)
+	_ = game.Players()[Yellow].Pawns()[0].Position().MoveToSquare(10)
+	_ = game.Players()[Green].Pawns()[0].Position().MoveToSquare(11)
+
+	assert.NoError(t, ValidateGame(game))
+}
+
+func TestValidateGameCardConservation(t *testing.T) {
+	game, _ := NewGame(2, nil, nil)
+
+	// a card that was drawn and never returned has leaked
+	leaked, _ := game.Deck().Draw()
+	expected := DeckCounts[leaked.Type()]
+	assert.Equal(t, []Violation{
+		{CardConservation, "expected 45 cards but found 44"},
+		{CardConservation, fmt.Sprintf("expected %d cards of type %s but found %d", expected, leaked.Type().Value(), expected-1)},
+	}, violationsFor(t, game))
+
+	// a card in two places at once has been duplicated
+	_ = game.Deck().Discard(leaked)
+	game.Players()[Red].AppendToHand(leaked)
+	assert.Equal(t, []Violation{
+		{CardConservation, "expected 45 cards but found 46"},
+		{CardConservation, fmt.Sprintf("expected %d cards of type %s but found %d", expected, leaked.Type().Value(), expected+1)},
+		{DuplicateCard, fmt.Sprintf("card id %s is used more than once", leaked.Id())},
+	}, violationsFor(t, game))
+}
+
+func TestValidateGamePawnCount(t *testing.T) {
+	game, _ := NewGame(2, nil, nil)
+	player := game.Players()[Red].(*player)
+
+	player.Xpawns = player.Xpawns[0:3]
+	assert.Equal(t, []Violation{
+		{PawnCount, "expected 4 pawns for Red but found 3"},
+	}, violationsFor(t, game))
+
+	player.Xpawns = append(player.Xpawns, NewPawn(Red, 1))
+	assert.Equal(t, []Violation{
+		{PawnCount, "unexpected pawn Red1 for Red"},
+	}, violationsFor(t, game))
+
+	player.Xpawns[3] = NewPawn(Yellow, 3)
+	assert.Equal(t, []Violation{
+		{PawnCount, "unexpected pawn Yellow3 for Red"},
+	}, violationsFor(t, game))
+}
+
+func TestValidateGamePawnOverlap(t *testing.T) {
+	game, _ := NewGame(4, nil, nil)
+
+	_ = game.Players()[Red].Pawns()[0].Position().MoveToSquare(10)
+	_ = game.Players()[Blue].Pawns()[3].Position().MoveToSquare(10)
+	_ = game.Players()[Green].Pawns()[1].Position().MoveToSafe(3)
+	_ = game.Players()[Green].Pawns()[2].Position().MoveToSafe(3)
+
+	assert.Equal(t, []Violation{
+		{PawnOverlap, "pawns Green1 and Green2 are both on safe square 3"},
+		{PawnOverlap, "pawns Red0 and Blue3 are both on square 10"},
+	}, violationsFor(t, game))
+}
+
+func TestValidateGamePlayers(t *testing.T) {
+	obj, _ := NewGame(3, nil, nil)
+	g := obj.(*game)
+
+	g.XplayerCount = 5
+	g.Xplayers[Blue] = g.Xplayers[Green]
+	assert.Equal(t, []Violation{
+		{InvalidPlayers, "invalid player count 5"},
+		{InvalidPlayers, "expected 5 players but found 4"},
+		{InvalidPlayers, "player for Blue has color Green"},
+	}, violationsFor(t, obj))
+
+	g.XplayerCount = 4
+	g.Xplayers[Blue] = nil
+	assert.Equal(t, []Violation{
+		{InvalidPlayers, "player for Blue is missing"},
+	}, violationsFor(t, obj))
+}
+
+func TestValidateGameMissingValues(t *testing.T) {
+	obj, _ := NewGame(2, nil, nil)
+	g := obj.(*game)
+	player := obj.Players()[Red].(*player)
+
+	g.Xdeck = nil
+	player.Xpawns[1] = nil
+	player.Xpawns[2].(*pawn).Xposition = nil
+	assert.Equal(t, []Violation{
+		{InvalidPosition, "pawn Red2 has no position"},
+		{CardConservation, "deck is missing"},
+		{CardConservation, "expected 45 cards but found 0"},
+		{CardConservation, "expected 5 cards of type 1 but found 0"},
+		{CardConservation, "expected 4 cards of type 2 but found 0"},
+		{CardConservation, "expected 4 cards of type 3 but found 0"},
+		{CardConservation, "expected 4 cards of type 4 but found 0"},
+		{CardConservation, "expected 4 cards of type 5 but found 0"},
+		{CardConservation, "expected 4 cards of type 7 but found 0"},
+		{CardConservation, "expected 4 cards of type 8 but found 0"},
+		{CardConservation, "expected 4 cards of type 10 but found 0"},
+		{CardConservation, "expected 4 cards of type 11 but found 0"},
+		{CardConservation, "expected 4 cards of type 12 but found 0"},
+		{CardConservation, "expected 4 cards of type A but found 0"},
+		{PawnCount, "missing pawn for Red"},
+	}, violationsFor(t, obj))
+}
+
+func TestValidateGameEmptyEntries(t *testing.T) {
+	obj, _ := NewGame(2, nil, nil)
+	g := obj.(*game)
+	d := g.Xdeck.(*deck)
+
+	// the other checks skip empty entries, so they are only reported here
+	d.XdrawPile["99"] = nil
+	d.XdiscardPile["100"] = nil
+	g.Xplayers[Yellow].AppendToHand(nil)
+	g.Xhistory = append(g.Xhistory, NewHistory("Turn started", nil, nil, nil, 0, nil), nil)
+	assert.Equal(t, []Violation{
+		{MissingCard, "draw pile entry 99 is empty"},
+		{MissingCard, "discard pile entry 100 is empty"},
+		{MissingCard, "hand for Yellow has an empty card at index 0"},
+		{MissingHistory, "history entry 1 is empty"},
+	}, violationsFor(t, obj))
+}
+
+func violationsFor(t *testing.T, game Game) []Violation {
+	var validationError *ValidationError
+	err := ValidateGame(game)
+	assert.True(t, errors.As(err, &validationError))
+	return validationError.Violations
+}
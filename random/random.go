@@ -0,0 +1,115 @@
+package random
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+
+	"github.com/pronovic/go-apologies/internal/randomutil"
+)
+
+// Randomizer is a source of random numbers, used everywhere the game needs to make a random choice.
+//
+// By default, randomness comes from crypto/rand, so it can never be replayed.  A seeded randomizer
+// is deterministic, so if the same seed is passed into the deck, the engine, and any random input
+// sources, an entire game can be reproduced exactly, which is useful for bug reports and for
+// regression tests.
+type Randomizer interface {
+	// Int returns a random integer between zero (inclusive) and a max value (exclusive)
+	Int(max int) (int, error)
+}
+
+type cryptoRandomizer struct{}
+
+// NewRandomizer constructs a new Randomizer backed by crypto/rand, which cannot be replayed
+func NewRandomizer() Randomizer {
+	return &cryptoRandomizer{}
+}
+
+func (r *cryptoRandomizer) Int(max int) (int, error) {
+	if max < 1 {
+		return 0, errors.New("max must be positive")
+	}
+
+	return randomutil.RandomInt(max)
+}
+
+type seededRandomizer struct {
+	lock   sync.Mutex
+	seed   int64
+	source *countingSource
+	rand   *rand.Rand
+}
+
+// countingSource counts the values taken from a generator, so the generator can be put back to an earlier state
+type countingSource struct {
+	rand.Source
+	count int
+}
+
+func (s *countingSource) Int63() int64 {
+	s.count += 1
+	return s.Source.Int63()
+}
+
+// NewSeededRandomizer constructs a new Randomizer backed by a pseudo-random generator with a fixed seed.
+// The randomizer also has a Checkpoint method, which the engine uses to put back its state when a turn is abandoned.
+func NewSeededRandomizer(seed int64) Randomizer {
+	source := &countingSource{Source: rand.NewSource(seed)}
+	return &seededRandomizer{
+		seed:   seed,
+		source: source,
+		rand:   rand.New(source),
+	}
+}
+
+func (r *seededRandomizer) Int(max int) (int, error) {
+	if max < 1 {
+		return 0, errors.New("max must be positive")
+	}
+
+	// the underlying generator is not safe for concurrent use
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.rand.Intn(max), nil
+}
+
+// Checkpoint saves the state of the generator, returning a function that restores it.  The generator's state
+// can't be copied, so it is restored by replaying the seed up to the same point.
+func (r *seededRandomizer) Checkpoint() func() {
+	r.lock.Lock()
+	count := r.source.count
+	r.lock.Unlock()
+
+	return func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		if r.source.count == count {
+			return
+		}
+
+		source := &countingSource{Source: rand.NewSource(r.seed)}
+		for source.count < count {
+			source.Int63()
+		}
+
+		r.source = source
+		r.rand = rand.New(source)
+	}
+}
+
+// Choice returns a random choice from a slice, using the passed-in randomizer
+func Choice[T any](randomizer Randomizer, slice []T) (T, error) {
+	if len(slice) < 1 {
+		return *new(T), errors.New("slice is empty")
+	}
+
+	index, err := randomizer.Int(len(slice))
+	if err != nil {
+		return *new(T), err
+	}
+
+	return slice[index], nil
+}
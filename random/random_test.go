@@ -0,0 +1,98 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomizerInt(t *testing.T) {
+	obj := NewRandomizer()
+	for i := 0; i < 10000; i++ {
+		r, err := obj.Int(100)
+		assert.NoError(t, err)
+		assert.True(t, r >= 0 && r < 100)
+	}
+
+	_, err := obj.Int(0)
+	assert.EqualError(t, err, "max must be positive")
+}
+
+func TestSeededRandomizerInt(t *testing.T) {
+	obj := NewSeededRandomizer(42)
+	for i := 0; i < 10000; i++ {
+		r, err := obj.Int(100)
+		assert.NoError(t, err)
+		assert.True(t, r >= 0 && r < 100)
+	}
+
+	_, err := obj.Int(0)
+	assert.EqualError(t, err, "max must be positive")
+}
+
+func TestSeededRandomizerRepeatable(t *testing.T) {
+	first := NewSeededRandomizer(42)
+	second := NewSeededRandomizer(42)
+	for i := 0; i < 1000; i++ {
+		r1, _ := first.Int(1000)
+		r2, _ := second.Int(1000)
+		assert.Equal(t, r1, r2)
+	}
+}
+
+func TestChoice(t *testing.T) {
+	slice := []string{"one", "two", "three", "four", "five"}
+	for i := 0; i < 10000; i++ {
+		c, err := Choice(NewRandomizer(), slice)
+		assert.NoError(t, err)
+		assert.True(t, c == "one" || c == "two" || c == "three" || c == "four" || c == "five")
+	}
+
+	_, err := Choice(NewRandomizer(), []string{})
+	assert.EqualError(t, err, "slice is empty")
+}
+
+func TestChoiceSeeded(t *testing.T) {
+	slice := []string{"one", "two", "three", "four", "five"}
+	first := NewSeededRandomizer(99)
+	second := NewSeededRandomizer(99)
+	for i := 0; i < 1000; i++ {
+		c1, _ := Choice(first, slice)
+		c2, _ := Choice(second, slice)
+		assert.Equal(t, c1, c2)
+	}
+}
+
+func TestSeededRandomizerCheckpoint(t *testing.T) {
+	obj := NewSeededRandomizer(42).(*seededRandomizer)
+	for i := 0; i < 100; i++ {
+		_, _ = obj.Int(1000)
+	}
+
+	restore := obj.Checkpoint()
+	expected := make([]int, 0, 100)
+	for i := 0; i < 100; i++ {
+		r, _ := obj.Int(1000)
+		expected = append(expected, r)
+	}
+
+	// after restoring, the generator produces the same values again, as many times as it is restored
+	for j := 0; j < 2; j++ {
+		restore()
+		for i := 0; i < 100; i++ {
+			r, _ := obj.Int(1000)
+			assert.Equal(t, expected[i], r)
+		}
+	}
+
+	// restoring without taking any values leaves the generator where it was
+	restore = obj.Checkpoint()
+	restore()
+	r, _ := obj.Int(1000)
+	second := NewSeededRandomizer(42)
+	for i := 0; i < 200; i++ {
+		_, _ = second.Int(1000)
+	}
+	s, _ := second.Int(1000)
+	assert.Equal(t, s, r)
+}
@@ -70,7 +70,7 @@ func executeTest(t *testing.T, game model.Game, testdata string) {
 
 // empty Create an empty game with some number of players
 func empty(players int) model.Game {
-	game, _ := model.NewGame(players, nil)
+	game, _ := model.NewGame(players, nil, nil)
 	return game
 }
 
@@ -0,0 +1,272 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/pronovic/go-apologies/internal/jsonutil"
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/rules"
+)
+
+// Step A single move played during a recorded game
+type Step interface {
+	// Color The color of the player that played the move
+	Color() model.PlayerColor
+
+	// Move The move that was played, possibly a forfeit
+	Move() model.Move
+}
+
+type step struct {
+	Xcolor model.PlayerColor `json:"color"`
+	Xmove  model.Move        `json:"move"`
+}
+
+// NewStep constructs a new Step
+func NewStep(color model.PlayerColor, move model.Move) Step {
+	return &step{
+		Xcolor: color,
+		Xmove:  move,
+	}
+}
+
+// NewStepFromJSON constructs a new object from JSON in an io.Reader
+func NewStepFromJSON(reader io.Reader) (Step, error) {
+	type raw struct {
+		Xcolor model.PlayerColor `json:"color"`
+		Xmove  json.RawMessage   `json:"move"`
+	}
+
+	var temp raw
+	err := json.NewDecoder(reader).Decode(&temp)
+	if err != nil {
+		return nil, err
+	}
+
+	var Xmove model.Move
+	Xmove, err = jsonutil.DecodeInterfaceJSON(temp.Xmove, model.NewMoveFromJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := step{
+		Xcolor: temp.Xcolor,
+		Xmove:  Xmove,
+	}
+
+	return &obj, nil
+}
+
+func (s *step) Color() model.PlayerColor {
+	return s.Xcolor
+}
+
+func (s *step) Move() model.Move {
+	return s.Xmove
+}
+
+// Recording A recorded game, which contains everything needed to replay the game from the start.
+type Recording interface {
+	// Mode The game mode
+	Mode() model.GameMode
+
+	// Initial The state of the game before it was started
+	Initial() model.Game
+
+	// Draws The choices made by the deck's randomizer for every card drawn, in order
+	Draws() []int
+
+	// Steps The moves played in the game, in order
+	Steps() []Step
+}
+
+type recording struct {
+	Xmode    model.GameMode `json:"mode"`
+	Xinitial model.Game     `json:"initial"`
+	Xdraws   []int          `json:"draws"`
+	Xsteps   []Step         `json:"steps"`
+}
+
+// NewRecording constructs a new Recording
+func NewRecording(mode model.GameMode, initial model.Game, draws []int, steps []Step) Recording {
+	if draws == nil {
+		draws = make([]int, 0)
+	}
+
+	if steps == nil {
+		steps = make([]Step, 0)
+	}
+
+	return &recording{
+		Xmode:    mode,
+		Xinitial: initial,
+		Xdraws:   draws,
+		Xsteps:   steps,
+	}
+}
+
+// NewRecordingFromJSON constructs a new object from JSON in an io.Reader
+func NewRecordingFromJSON(reader io.Reader) (Recording, error) {
+	type raw struct {
+		Xmode    model.GameMode    `json:"mode"`
+		Xinitial json.RawMessage   `json:"initial"`
+		Xdraws   []int             `json:"draws"`
+		Xsteps   []json.RawMessage `json:"steps"`
+	}
+
+	var temp raw
+	err := json.NewDecoder(reader).Decode(&temp)
+	if err != nil {
+		return nil, err
+	}
+
+	var Xinitial model.Game
+	Xinitial, err = jsonutil.DecodeInterfaceJSON(temp.Xinitial, model.NewGameFromJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var Xsteps []Step
+	Xsteps, err = jsonutil.DecodeSliceJSON(temp.Xsteps, NewStepFromJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRecording(temp.Xmode, Xinitial, temp.Xdraws, Xsteps), nil
+}
+
+func (r *recording) Mode() model.GameMode {
+	return r.Xmode
+}
+
+func (r *recording) Initial() model.Game {
+	return r.Xinitial
+}
+
+func (r *recording) Draws() []int {
+	return r.Xdraws
+}
+
+func (r *recording) Steps() []Step {
+	return r.Xsteps
+}
+
+// Recorder A rules evaluator that records every move applied through ExecuteMove, along with the initial
+// game state and the deck draw order.  Pass a recorder to engine.NewEngine in place of the normal rules
+// evaluator, and then retrieve the recording at any point once the game has been started.  If a turn
+// fails partway through, the engine rolls back the recording along with the game, using Checkpoint.
+type Recorder interface {
+	rules.Rules
+
+	// Recording A snapshot of the recording for the game most recently started through this recorder
+	Recording() (Recording, error)
+
+	// Checkpoint Save the position in the recording, returning a function that discards anything recorded after it
+	Checkpoint() func()
+}
+
+type recorder struct {
+	evaluator rules.Rules
+	mode      model.GameMode
+	initial   model.Game
+	draws     []int
+	steps     []Step
+}
+
+// NewRecorder constructs a new Recorder, optionally accepting the rules evaluator to delegate to
+func NewRecorder(evaluator rules.Rules) Recorder {
+	if evaluator == nil {
+		evaluator = rules.NewRules(nil)
+	}
+
+	return &recorder{
+		evaluator: evaluator,
+		draws:     make([]int, 0),
+		steps:     make([]Step, 0),
+	}
+}
+
+func (r *recorder) Recording() (Recording, error) {
+	if r.initial == nil {
+		return nil, errors.New("no game has been started")
+	}
+
+	draws := make([]int, 0, len(r.draws))
+	draws = append(draws, r.draws...)
+
+	steps := make([]Step, 0, len(r.steps))
+	steps = append(steps, r.steps...)
+
+	return NewRecording(r.mode, r.initial.Copy(), draws, steps), nil
+}
+
+func (r *recorder) Checkpoint() func() {
+	draws := len(r.draws)
+	steps := len(r.steps)
+
+	return func() {
+		// starting a new game in the meantime starts a new recording, which might be shorter
+		r.draws = r.draws[:min(draws, len(r.draws))]
+		r.steps = r.steps[:min(steps, len(r.steps))]
+	}
+}
+
+func (r *recorder) StartGame(game model.Game, mode model.GameMode) error {
+	if game == nil {
+		return errors.New("game is nil")
+	}
+
+	// a new game starts a new recording, including the cards that are dealt when the game starts
+	r.mode = mode
+	r.initial = game.Copy()
+	r.draws = make([]int, 0)
+	r.steps = make([]Step, 0)
+	game.Deck().SetRandomizer(&recordingRandomizer{game.Deck().Randomizer(), r})
+
+	if err := r.evaluator.StartGame(game, mode); err != nil {
+		r.initial = nil
+		return err
+	}
+
+	return nil
+}
+
+func (r *recorder) ExecuteMove(game model.Game, player model.Player, move model.Move) error {
+	if err := r.evaluator.ExecuteMove(game, player, move); err != nil {
+		return err
+	}
+
+	r.steps = append(r.steps, NewStep(player.Color(), move))
+	return nil
+}
+
+func (r *recorder) EvaluateMove(view model.PlayerView, move model.Move) (model.PlayerView, error) {
+	return r.evaluator.EvaluateMove(view, move)
+}
+
+func (r *recorder) ConstructLegalMoves(view model.PlayerView, card model.Card) ([]model.Move, error) {
+	return r.evaluator.ConstructLegalMoves(view, card)
+}
+
+func (r *recorder) DrawAgain(card model.Card) bool {
+	return r.evaluator.DrawAgain(card)
+}
+
+// recordingRandomizer wraps a deck's randomizer, recording every choice it makes
+type recordingRandomizer struct {
+	wrapped  random.Randomizer
+	recorder *recorder
+}
+
+func (r *recordingRandomizer) Int(max int) (int, error) {
+	value, err := r.wrapped.Int(max)
+	if err != nil {
+		return 0, err
+	}
+
+	r.recorder.draws = append(r.recorder.draws, value)
+	return value, nil
+}
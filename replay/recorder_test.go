@@ -0,0 +1,189 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/pronovic/go-apologies/engine"
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/rules"
+	"github.com/pronovic/go-apologies/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewStep(t *testing.T) {
+	move := model.NewMove(model.NewCard("1", model.Card1), nil, nil)
+	obj := NewStep(model.Blue, move)
+	assert.Equal(t, model.Blue, obj.Color())
+	assert.Same(t, move, obj.Move())
+}
+
+func TestNewStepFromJSON(t *testing.T) {
+	square := 10
+	actions := []model.Action{model.NewAction(model.MoveToPosition, model.NewPawn(model.Red, 1), model.NewPosition(false, false, nil, &square))}
+	obj := NewStep(model.Red, model.NewMove(model.NewCard("1", model.Card1), actions, nil))
+	marshalled, err := json.Marshal(obj)
+	assert.NoError(t, err)
+	unmarshalled, err := NewStepFromJSON(bytes.NewReader(marshalled))
+	assert.NoError(t, err)
+	assert.Equal(t, obj, unmarshalled)
+}
+
+func TestNewRecording(t *testing.T) {
+	game, _ := model.NewGame(2, nil, nil)
+	obj := NewRecording(model.AdultMode, game, nil, nil)
+	assert.Equal(t, model.AdultMode, obj.Mode())
+	assert.Same(t, game, obj.Initial())
+	assert.Equal(t, []int{}, obj.Draws())
+	assert.Equal(t, []Step{}, obj.Steps())
+}
+
+func TestNewRecordingFromJSON(t *testing.T) {
+	_, recording := playRecordedGame(t, model.StandardMode, 42)
+
+	marshalled, err := json.Marshal(recording)
+	assert.NoError(t, err)
+	unmarshalled, err := NewRecordingFromJSON(bytes.NewReader(marshalled))
+	assert.NoError(t, err)
+
+	assert.Equal(t, recording.Mode(), unmarshalled.Mode())
+	assert.Equal(t, recording.Draws(), unmarshalled.Draws())
+	assert.Equal(t, recording.Steps(), unmarshalled.Steps())
+	remarshalled, err := json.Marshal(unmarshalled)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(marshalled), string(remarshalled))
+}
+
+func TestRecorderNotStarted(t *testing.T) {
+	obj := NewRecorder(nil)
+	recording, err := obj.Recording()
+	assert.EqualError(t, err, "no game has been started")
+	assert.Nil(t, recording)
+	assert.EqualError(t, obj.StartGame(nil, model.StandardMode), "game is nil")
+}
+
+func TestRecorderDelegates(t *testing.T) {
+	evaluator := &rules.MockRules{}
+	obj := NewRecorder(evaluator)
+
+	game, _ := model.NewGame(2, nil, random.NewSeededRandomizer(42))
+	player := game.Players()[model.Red]
+	card := model.NewCard("1", model.Card1)
+	move := model.NewMove(card, nil, nil)
+	view, _ := game.CreatePlayerView(model.Red)
+	moves := []model.Move{move}
+
+	evaluator.On("StartGame", game, model.StandardMode).Return(nil).Once()
+	evaluator.On("ExecuteMove", game, player, move).Return(nil).Once()
+	evaluator.On("EvaluateMove", view, move).Return(view, nil).Once()
+	evaluator.On("ConstructLegalMoves", view, card).Return(moves, nil).Once()
+	evaluator.On("DrawAgain", card).Return(true).Once()
+
+	assert.NoError(t, obj.StartGame(game, model.StandardMode))
+	assert.NoError(t, obj.ExecuteMove(game, player, move))
+	result, _ := obj.EvaluateMove(view, move)
+	assert.Same(t, view, result)
+	legal, _ := obj.ConstructLegalMoves(view, card)
+	assert.Equal(t, moves, legal)
+	assert.True(t, obj.DrawAgain(card))
+
+	// the deck's draws are recorded along with the moves
+	_, _ = game.Deck().Draw()
+	_, _ = game.Deck().Draw()
+	recording, err := obj.Recording()
+	assert.NoError(t, err)
+	assert.Equal(t, model.StandardMode, recording.Mode())
+	assert.Equal(t, 2, len(recording.Draws()))
+	assert.Equal(t, []Step{NewStep(model.Red, move)}, recording.Steps())
+	assert.Equal(t, model.DeckSize, recording.Initial().Deck().DrawPileSize())
+}
+
+func TestRecorderFailedMove(t *testing.T) {
+	evaluator := &rules.MockRules{}
+	obj := NewRecorder(evaluator)
+
+	game, _ := model.NewGame(2, nil, nil)
+	player := game.Players()[model.Red]
+	move := model.NewMove(model.NewCard("1", model.Card1), nil, nil)
+
+	evaluator.On("StartGame", game, model.StandardMode).Return(nil).Once()
+	evaluator.On("ExecuteMove", game, player, mock.Anything).Return(errors.New("hello")).Once()
+
+	assert.NoError(t, obj.StartGame(game, model.StandardMode))
+	assert.EqualError(t, obj.ExecuteMove(game, player, move), "hello")
+
+	// a move that fails is not recorded
+	recording, _ := obj.Recording()
+	assert.Equal(t, []Step{}, recording.Steps())
+}
+
+func TestRecorderCheckpoint(t *testing.T) {
+	for _, mode := range model.GameModes.Members() {
+		randomizer := random.NewSeededRandomizer(42)
+		delegate := source.RandomInputSource(randomizer)
+
+		// every fifth move fails after the card has been drawn, so the turn is rolled back and played again
+		calls := 0
+		input := &source.MockCharacterInputSource{}
+		input.On("ChooseMove", mock.Anything, mock.Anything, mock.Anything).Return(func(mode model.GameMode, view model.PlayerView, moves []model.Move) (model.Move, error) {
+			calls += 1
+			if calls%5 == 0 {
+				return nil, errors.New("flaky")
+			}
+			return delegate.ChooseMove(mode, view, moves)
+		})
+
+		characters := []engine.Character{engine.NewCharacter("character1", input), engine.NewCharacter("character2", input)}
+		recorder := NewRecorder(nil)
+		e, err := engine.NewEngine(mode, characters, recorder, randomizer)
+		assert.NoError(t, err)
+
+		_, err = e.StartGame()
+		assert.NoError(t, err)
+
+		failures := 0
+		for !e.Completed() {
+			if _, err = e.PlayNext(); err != nil {
+				assert.EqualError(t, err, "flaky")
+				failures += 1
+			}
+		}
+		assert.Greater(t, failures, 0)
+
+		// nothing recorded during a failed turn is left behind, so the recording replays the game exactly
+		recording, err := recorder.Recording()
+		assert.NoError(t, err)
+		assert.Equal(t, e.Game().Turn(), len(recording.Steps()))
+		obj, err := NewReplay(recording, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, obj.Verify(e.Game()), mode.Value())
+	}
+}
+
+// playRecordedGame plays a complete seeded game through the engine, returning the engine and the recording
+func playRecordedGame(t *testing.T, mode model.GameMode, seed int64) (engine.Engine, Recording) {
+	randomizer := random.NewSeededRandomizer(seed)
+	input := source.RandomInputSource(randomizer)
+	characters := []engine.Character{engine.NewCharacter("character1", input), engine.NewCharacter("character2", input), engine.NewCharacter("character3", input)}
+	recorder := NewRecorder(nil)
+
+	e, err := engine.NewEngine(mode, characters, recorder, randomizer)
+	assert.NoError(t, err)
+
+	_, err = e.StartGame()
+	assert.NoError(t, err)
+
+	for !e.Completed() {
+		_, err = e.PlayNext()
+		assert.NoError(t, err)
+	}
+
+	recording, err := recorder.Recording()
+	assert.NoError(t, err)
+
+	return e, recording
+}
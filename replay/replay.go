@@ -0,0 +1,332 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/rules"
+)
+
+// Replay Steps forward and backward through a recorded game, rebuilding game state from the recording.
+//
+// Cards are drawn and discarded exactly as the engine does it, so the state after each step includes
+// the deck and the players' hands as well as the position of every pawn.
+type Replay interface {
+	// Recording The recording being replayed
+	Recording() Recording
+
+	// Len The number of steps in the recording
+	Len() int
+
+	// Position The number of steps that have been applied to the current game state
+	Position() int
+
+	// Game The game state after the current position
+	Game() model.Game
+
+	// Forward Apply the next step, returning the resulting game state
+	Forward() (model.Game, error)
+
+	// Backward Undo the most recent step, returning the resulting game state
+	Backward() (model.Game, error)
+
+	// Seek Rebuild the game state after the indicated number of steps, where 0 is the started game before any moves
+	Seek(position int) (model.Game, error)
+
+	// Verify Replay to the end of the recording and confirm that the result matches the passed-in game
+	Verify(final model.Game) error
+}
+
+type replay struct {
+	recording Recording
+	evaluator rules.Rules
+	game      model.Game
+	position  int
+}
+
+// NewReplay constructs a new Replay positioned at the start of the game, optionally accepting a rules evaluator
+func NewReplay(recording Recording, evaluator rules.Rules) (Replay, error) {
+	if recording == nil {
+		return nil, errors.New("recording is nil")
+	}
+
+	if recording.Initial() == nil {
+		return nil, errors.New("recording has no initial game")
+	}
+
+	if evaluator == nil {
+		evaluator = rules.NewRules(nil)
+	}
+
+	r := &replay{
+		recording: recording,
+		evaluator: evaluator,
+	}
+
+	if err := r.restart(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *replay) Recording() Recording {
+	return r.recording
+}
+
+func (r *replay) Len() int {
+	return len(r.recording.Steps())
+}
+
+func (r *replay) Position() int {
+	return r.position
+}
+
+func (r *replay) Game() model.Game {
+	return r.game
+}
+
+func (r *replay) Forward() (model.Game, error) {
+	if r.position >= r.Len() {
+		return nil, errors.New("already at the end of the recording")
+	}
+
+	return r.Seek(r.position + 1)
+}
+
+func (r *replay) Backward() (model.Game, error) {
+	if r.position <= 0 {
+		return nil, errors.New("already at the start of the recording")
+	}
+
+	return r.Seek(r.position - 1)
+}
+
+func (r *replay) Seek(position int) (model.Game, error) {
+	if position < 0 || position > r.Len() {
+		return nil, fmt.Errorf("invalid position %d", position)
+	}
+
+	// moves can't be undone, so going backwards means replaying from the start
+	if position < r.position {
+		if err := r.restart(); err != nil {
+			return nil, err
+		}
+	}
+
+	for r.position < position {
+		if err := r.apply(r.recording.Steps()[r.position]); err != nil {
+			return nil, fmt.Errorf("step %d: %w", r.position+1, err)
+		}
+
+		r.position += 1
+	}
+
+	return r.game, nil
+}
+
+func (r *replay) Verify(final model.Game) error {
+	if final == nil {
+		return errors.New("game is nil")
+	}
+
+	game, err := r.Seek(r.Len())
+	if err != nil {
+		return err
+	}
+
+	differences := compare(game, final)
+	if len(differences) > 0 {
+		return fmt.Errorf("replay does not match: %s", strings.Join(differences, "; "))
+	}
+
+	return nil
+}
+
+// restart rebuilds the started game from the initial state in the recording
+func (r *replay) restart() error {
+	game := r.recording.Initial().Copy()
+	game.Deck().SetRandomizer(&replayingRandomizer{draws: r.recording.Draws()})
+
+	if err := r.evaluator.StartGame(game, r.recording.Mode()); err != nil {
+		return err
+	}
+
+	r.game = game
+	r.position = 0
+	return nil
+}
+
+// apply applies a single step, drawing and discarding cards the same way the engine does
+func (r *replay) apply(step Step) error {
+	player, exists := r.game.Players()[step.Color()]
+	if !exists {
+		return fmt.Errorf("no player for %s", step.Color().Value())
+	}
+
+	move := step.Move()
+	if move == nil || move.Card() == nil {
+		return errors.New("move has no card")
+	}
+
+	r.game.StartTurn()
+
+	if r.recording.Mode() == model.StandardMode {
+		drawn, err := r.game.Deck().Draw()
+		if err != nil {
+			return err
+		}
+
+		if drawn.Id() != move.Card().Id() {
+			return fmt.Errorf("drew card %s but the move uses card %s", drawn.Id(), move.Card().Id())
+		}
+
+		if err = r.evaluator.ExecuteMove(r.game, player, move); err != nil {
+			return err
+		}
+
+		return r.game.Deck().Discard(drawn)
+	}
+
+	if err := r.evaluator.ExecuteMove(r.game, player, move); err != nil {
+		return err
+	}
+
+	player.RemoveFromHand(move.Card())
+
+	drawn, err := r.game.Deck().Draw()
+	if err != nil {
+		return err
+	}
+
+	if err = r.game.Deck().Discard(move.Card()); err != nil {
+		return err
+	}
+
+	player.AppendToHand(drawn)
+	return nil
+}
+
+// compare lists the differences between the state of two games, ignoring history timestamps.  Notices are
+// ignored too, since they record what happened to an input source rather than a move, so they are not replayed.
+func compare(actual model.Game, expected model.Game) []string {
+	differences := make([]string, 0)
+
+	if actual.Turn() != expected.Turn() {
+		differences = append(differences, fmt.Sprintf("turn is %d but expected %d", actual.Turn(), expected.Turn()))
+	}
+
+	actualDeck := cardIds(actual.Deck().Cards())
+	expectedDeck := cardIds(expected.Deck().Cards())
+	if actualDeck != expectedDeck || actual.Deck().DrawPileSize() != expected.Deck().DrawPileSize() {
+		differences = append(differences, fmt.Sprintf("deck is %s but expected %s", actualDeck, expectedDeck))
+	}
+
+	actualDiscards := cardIds(actual.Deck().DiscardPile())
+	expectedDiscards := cardIds(expected.Deck().DiscardPile())
+	if actualDiscards != expectedDiscards {
+		differences = append(differences, fmt.Sprintf("discard pile is %s but expected %s", actualDiscards, expectedDiscards))
+	}
+
+	actualHistory := describeHistory(actual.History())
+	expectedHistory := describeHistory(expected.History())
+	if len(actualHistory) != len(expectedHistory) {
+		differences = append(differences, fmt.Sprintf("history has %d entries but expected %d", len(actualHistory), len(expectedHistory)))
+	}
+
+	for i := 0; i < len(actualHistory) && i < len(expectedHistory); i++ {
+		if actualHistory[i] != expectedHistory[i] {
+			differences = append(differences, fmt.Sprintf("history entry %d is %s but expected %s", i, actualHistory[i], expectedHistory[i]))
+			break
+		}
+	}
+
+	for _, color := range model.PlayerColors.Members() {
+		a, aexists := actual.Players()[color]
+		e, eexists := expected.Players()[color]
+		if aexists != eexists {
+			differences = append(differences, fmt.Sprintf("player %s is missing", color.Value()))
+			continue
+		} else if !aexists {
+			continue
+		}
+
+		if a.Turns() != e.Turns() {
+			differences = append(differences, fmt.Sprintf("%s has taken %d turns but expected %d", color.Value(), a.Turns(), e.Turns()))
+		}
+
+		actualHand := cardIds(a.Hand())
+		expectedHand := cardIds(e.Hand())
+		if actualHand != expectedHand {
+			differences = append(differences, fmt.Sprintf("hand for %s is %s but expected %s", color.Value(), actualHand, expectedHand))
+		}
+
+		for i := 0; i < len(a.Pawns()) && i < len(e.Pawns()); i++ {
+			actualPosition := fmt.Sprintf("%s", a.Pawns()[i].Position())
+			expectedPosition := fmt.Sprintf("%s", e.Pawns()[i].Position())
+			if actualPosition != expectedPosition {
+				differences = append(differences, fmt.Sprintf("pawn %s is on %s but expected %s", a.Pawns()[i].Name(), actualPosition, expectedPosition))
+			}
+		}
+	}
+
+	return differences
+}
+
+// describeHistory formats each history entry other than notices for comparison, leaving out the timestamp
+func describeHistory(entries []model.History) []string {
+	descriptions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Notice() {
+			continue // tracked by the engine rather than the rules, so it is not replayed
+		}
+
+		color := "General"
+		if entry.Color() != nil {
+			color = entry.Color().Value()
+		}
+
+		card := "none"
+		if entry.Card() != nil {
+			card = entry.Card().Value()
+		}
+
+		move, _ := json.Marshal(entry.Move())
+		descriptions = append(descriptions, fmt.Sprintf("[turn %d] %s - %s (card %s, move %s)", entry.Turn(), color, entry.Action(), card, move))
+	}
+
+	return descriptions
+}
+
+// cardIds formats the ids of a set of cards for comparison, in order
+func cardIds(cards []model.Card) string {
+	ids := make([]string, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.Id())
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(ids, " "))
+}
+
+// replayingRandomizer returns the choices recorded by a recordingRandomizer, in order
+type replayingRandomizer struct {
+	draws []int
+	next  int
+}
+
+func (r *replayingRandomizer) Int(max int) (int, error) {
+	if r.next >= len(r.draws) {
+		return 0, errors.New("no more recorded draws")
+	}
+
+	value := r.draws[r.next]
+	if value < 0 || value >= max {
+		return 0, fmt.Errorf("recorded draw %d is out of range", value)
+	}
+
+	r.next += 1
+	return value, nil
+}
@@ -0,0 +1,151 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReplayErrors(t *testing.T) {
+	_, err := NewReplay(nil, nil)
+	assert.EqualError(t, err, "recording is nil")
+
+	_, err = NewReplay(NewRecording(model.StandardMode, nil, nil, nil), nil)
+	assert.EqualError(t, err, "recording has no initial game")
+}
+
+func TestReplayVerify(t *testing.T) {
+	for _, mode := range model.GameModes.Members() {
+		e, recording := playRecordedGame(t, mode, 42)
+
+		obj, err := NewReplay(recording, nil)
+		assert.NoError(t, err)
+		assert.Same(t, recording, obj.Recording())
+		assert.Equal(t, e.Game().Turn(), obj.Len())
+		assert.Equal(t, 0, obj.Position())
+		assert.True(t, obj.Game().Started())
+
+		// replaying from the start reproduces the final state exactly
+		assert.NoError(t, obj.Verify(e.Game()))
+		assert.Equal(t, obj.Len(), obj.Position())
+		assert.True(t, obj.Game().Completed())
+		assert.Equal(t, (*e.Game().Winner()).Color(), (*obj.Game().Winner()).Color())
+	}
+}
+
+func TestReplayVerifyFromJSON(t *testing.T) {
+	e, recording := playRecordedGame(t, model.AdultMode, 99)
+
+	// a recording saved as a fixture replays the same way as the original
+	marshalled, _ := json.Marshal(recording)
+	loaded, err := NewRecordingFromJSON(bytes.NewReader(marshalled))
+	assert.NoError(t, err)
+
+	obj, err := NewReplay(loaded, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, obj.Verify(e.Game()))
+}
+
+func TestReplayVerifyMismatch(t *testing.T) {
+	e, recording := playRecordedGame(t, model.StandardMode, 42)
+	obj, _ := NewReplay(recording, nil)
+
+	final := e.Game().Copy()
+	_ = final.Players()[model.Red].Pawns()[0].Position().MoveToSquare(33)
+	final.StartTurn()
+
+	err := obj.Verify(final)
+	assert.ErrorContains(t, err, "replay does not match: turn is")
+	assert.ErrorContains(t, err, "pawn Red0 is on")
+	assert.ErrorContains(t, err, "but expected square 33")
+
+	assert.EqualError(t, obj.Verify(nil), "game is nil")
+
+	// the turns taken by each player and the history must match too, but not the notices about input sources
+	final = e.Game().Copy()
+	final.Players()[model.Yellow].IncrementTurns()
+	final.TrackNotice("Move timed out", model.Yellow)
+	final.Track("Extra action", final.Players()[model.Blue], nil) // not a notice, even without a card
+
+	err = obj.Verify(final)
+	assert.ErrorContains(t, err, "Yellow has taken")
+	assert.ErrorContains(t, err, fmt.Sprintf("history has %d entries but expected %d", len(e.Game().History()), len(e.Game().History())+1))
+	assert.NotContains(t, err.Error(), "timed out")
+
+	// so must the history entries themselves, other than their timestamps
+	final = e.Game().Copy()
+	final.History()[1] = model.NewHistory("Changed action", final.History()[1].Color(), final.History()[1].Card(), final.History()[1].Move(), final.History()[1].Turn(), nil)
+	assert.ErrorContains(t, obj.Verify(final), "replay does not match: history entry 1 is")
+
+	// and the discard pile, even when the same cards are in the deck
+	final = e.Game().Copy()
+	card, _ := final.Deck().Draw()
+	_ = final.Deck().Discard(card)
+	assert.ErrorContains(t, obj.Verify(final), "discard pile is")
+}
+
+func TestReplayStepping(t *testing.T) {
+	_, recording := playRecordedGame(t, model.AdultMode, 42)
+	obj, _ := NewReplay(recording, nil)
+	other, _ := NewReplay(recording, nil)
+
+	_, err := obj.Backward()
+	assert.EqualError(t, err, "already at the start of the recording")
+
+	_, err = obj.Seek(-1)
+	assert.EqualError(t, err, "invalid position -1")
+
+	_, err = obj.Seek(obj.Len() + 1)
+	assert.EqualError(t, err, fmt.Sprintf("invalid position %d", obj.Len()+1))
+
+	// stepping forward one move at a time reaches the same state as seeking directly
+	for i := 1; i <= 10; i++ {
+		game, err := obj.Forward()
+		assert.NoError(t, err)
+		assert.Same(t, obj.Game(), game)
+		assert.Equal(t, i, obj.Position())
+		assert.Equal(t, i, game.Turn())
+
+		sought, err := other.Seek(i)
+		assert.NoError(t, err)
+		assert.Empty(t, compare(sought, game))
+	}
+
+	// stepping backward rebuilds earlier states
+	for i := 9; i >= 0; i-- {
+		game, err := obj.Backward()
+		assert.NoError(t, err)
+		assert.Equal(t, i, obj.Position())
+
+		sought, err := other.Seek(i)
+		assert.NoError(t, err)
+		assert.Empty(t, compare(sought, game))
+	}
+
+	_, err = obj.Seek(obj.Len())
+	assert.NoError(t, err)
+	_, err = obj.Forward()
+	assert.EqualError(t, err, "already at the end of the recording")
+}
+
+func TestReplayCorruptRecording(t *testing.T) {
+	_, recording := playRecordedGame(t, model.StandardMode, 42)
+
+	// if the draws don't match the moves, replay fails at the first step that doesn't line up
+	draws := make([]int, len(recording.Draws()))
+	corrupted := NewRecording(recording.Mode(), recording.Initial(), draws, recording.Steps())
+	obj, err := NewReplay(corrupted, nil)
+	assert.NoError(t, err)
+	_, err = obj.Seek(obj.Len())
+	assert.ErrorContains(t, err, "drew card")
+
+	// if there aren't enough draws, replay fails when it runs out
+	truncated := NewRecording(recording.Mode(), recording.Initial(), recording.Draws()[0:2], recording.Steps())
+	obj, _ = NewReplay(truncated, nil)
+	_, err = obj.Seek(obj.Len())
+	assert.EqualError(t, err, "step 3: no more recorded draws")
+}
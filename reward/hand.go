@@ -0,0 +1,99 @@
+package reward
+
+import (
+	"github.com/pronovic/go-apologies/model"
+)
+
+// cardSquares is the number of squares that each type of card can move a pawn, which is the baseline value for holding it
+var cardSquares = map[model.CardType]int{
+	model.Card1:         1,
+	model.Card2:         2,
+	model.Card3:         3,
+	model.Card4:         4,
+	model.Card5:         5,
+	model.Card7:         7,
+	model.Card8:         8,
+	model.Card10:        10,
+	model.Card11:        11,
+	model.Card12:        12,
+	model.CardApologies: 0,
+}
+
+// HandEvaluator Scores the cards that a player keeps in hand, which only matters in adult mode.
+type HandEvaluator interface {
+	// Evaluate Calculate the value of holding a hand of cards, given the position in a player view
+	Evaluate(view model.PlayerView, hand []model.Card) float32
+}
+
+type handEvaluator struct {
+	weight float32
+}
+
+// NewHandEvaluator constructs a HandEvaluator that takes the opponents' positions into account, optionally
+// accepting weights, of which only the Hand weight is used.
+//
+// Each card is worth the number of squares it can move a pawn.  Some cards are worth more in the right
+// position: an Apologies card is worth a bonus for each opponent pawn it could bump, as long as the player
+// has a pawn in start to use it with; an 11 is worth a bonus for each opponent pawn it could swap with;
+// a 1 or a 2 is worth a bonus while the player has pawns in start; and a 2 is worth a bonus because it
+// draws again.  Like the reward calculator, the value is scaled by the number of opponents.
+//
+// A card is worth about as much on the board as it is in hand, since it moves a pawn the same number of squares
+// either way, so the value is also scaled by the Hand weight.  With the default weight, a card in hand is worth a
+// quarter of what it's worth on the board, which is enough to prefer spending the cheaper card when the board
+// comes out about the same, without giving up a clearly better position to hold on to a card.
+func NewHandEvaluator(weights *Weights) HandEvaluator {
+	if weights == nil {
+		weights = DefaultWeights()
+	}
+
+	return &handEvaluator{
+		weight: weights.Hand,
+	}
+}
+
+func (e *handEvaluator) Evaluate(view model.PlayerView, hand []model.Card) float32 {
+	inStart, onBoard := 0, 0
+	for _, pawn := range view.Player().Pawns() {
+		if pawn.Position().Start() {
+			inStart += 1
+		} else if pawn.Position().Square() != nil {
+			onBoard += 1
+		}
+	}
+
+	targets := 0
+	for _, opponent := range view.Opponents() {
+		for _, pawn := range opponent.Pawns() {
+			if pawn.Position().Square() != nil {
+				targets += 1
+			}
+		}
+	}
+
+	value := 0
+	for _, card := range hand {
+		value += cardSquares[card.Type()]
+
+		switch card.Type() {
+		case model.CardApologies:
+			if inStart > 0 {
+				value += 10 * targets // each opponent pawn on the board could be sent back to start
+			}
+		case model.Card11:
+			if onBoard > 0 {
+				value += 2 * targets // each opponent pawn on the board is a potential swap
+			}
+		case model.Card1, model.Card2:
+			if inStart > 0 {
+				value += 10 // a pawn can leave start
+			}
+		}
+
+		if model.DrawAgain[card.Type()] {
+			value += 5
+		}
+	}
+
+	return e.weight * float32(len(view.Opponents())*value)
+}
@@ -0,0 +1,70 @@
+package reward
+
+import (
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// unweighted weights score each card at its full value, so the expected values are easy to follow
+var unweighted = &Weights{Hand: 1}
+
+func TestHandEvaluatorEmpty(t *testing.T) {
+	game, _ := model.NewGame(2, nil, nil)
+	view, _ := game.CreatePlayerView(model.Red)
+	assert.Equal(t, float32(0), NewHandEvaluator(unweighted).Evaluate(view, []model.Card{}))
+}
+
+func TestHandEvaluatorBaseline(t *testing.T) {
+	game, _ := model.NewGame(2, nil, nil)
+	for i := 0; i < model.Pawns; i++ {
+		_ = game.Players()[model.Red].Pawns()[i].Position().MoveToSquare(10 + i)
+	}
+	view, _ := game.CreatePlayerView(model.Red)
+
+	// with no pawns in start and no opponents on the board, each card is worth the squares it moves
+	hand := []model.Card{model.NewCard("1", model.Card3), model.NewCard("2", model.Card12), model.NewCard("3", model.Card11), model.NewCard("4", model.CardApologies)}
+	assert.Equal(t, float32(3+12+11), NewHandEvaluator(unweighted).Evaluate(view, hand))
+
+	// a 2 always draws again
+	assert.Equal(t, float32(2+5), NewHandEvaluator(unweighted).Evaluate(view, []model.Card{model.NewCard("1", model.Card2)}))
+}
+
+func TestHandEvaluatorOpponents(t *testing.T) {
+	game, _ := model.NewGame(3, nil, nil)
+	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToSquare(10)
+	_ = game.Players()[model.Yellow].Pawns()[0].Position().MoveToSquare(20)
+	_ = game.Players()[model.Yellow].Pawns()[1].Position().MoveToSafe(1) // can't be bumped or swapped
+	_ = game.Players()[model.Green].Pawns()[0].Position().MoveToSquare(30)
+	view, _ := game.CreatePlayerView(model.Red)
+	evaluator := NewHandEvaluator(unweighted)
+
+	// the value is scaled by the number of opponents, and the bonuses depend on where the pawns are
+	assert.Equal(t, float32(2*(0+10*2)), evaluator.Evaluate(view, []model.Card{model.NewCard("1", model.CardApologies)}))
+	assert.Equal(t, float32(2*(11+2*2)), evaluator.Evaluate(view, []model.Card{model.NewCard("1", model.Card11)}))
+	assert.Equal(t, float32(2*(1+10)), evaluator.Evaluate(view, []model.Card{model.NewCard("1", model.Card1)}))
+	assert.Equal(t, float32(2*(2+10+5)), evaluator.Evaluate(view, []model.Card{model.NewCard("1", model.Card2)}))
+
+	// once every pawn has left start, an Apologies card can't be played
+	for i := 1; i < model.Pawns; i++ {
+		_ = game.Players()[model.Red].Pawns()[i].Position().MoveToSquare(10 + i)
+	}
+	view, _ = game.CreatePlayerView(model.Red)
+	assert.Equal(t, float32(0), evaluator.Evaluate(view, []model.Card{model.NewCard("1", model.CardApologies)}))
+	assert.Equal(t, float32(2*1), evaluator.Evaluate(view, []model.Card{model.NewCard("1", model.Card1)}))
+}
+
+func TestHandEvaluatorWeight(t *testing.T) {
+	game, _ := model.NewGame(2, nil, nil)
+	for i := 0; i < model.Pawns; i++ {
+		_ = game.Players()[model.Red].Pawns()[i].Position().MoveToSquare(10 + i)
+	}
+	view, _ := game.CreatePlayerView(model.Red)
+	hand := []model.Card{model.NewCard("1", model.Card12)}
+
+	// the value is scaled by the Hand weight, which defaults to a quarter
+	assert.Equal(t, float32(12*0.25), NewHandEvaluator(nil).Evaluate(view, hand))
+	assert.Equal(t, float32(12*3), NewHandEvaluator(&Weights{Hand: 3}).Evaluate(view, hand))
+	assert.Equal(t, float32(0), NewHandEvaluator(&Weights{Distance: 1}).Evaluate(view, hand))
+}
@@ -19,6 +19,9 @@ package reward
 // player will have no good move on their turn.  In a 4-player adult mode game, where the engine has
 // the opportunity to choose between more possible moves for each turn, a reward-based source wins
 // more than 98% of the time against 3 random sources.
+//
+// The weighted calculator generalizes this algorithm, with a configurable weight for each feature,
+// and the tuning package searches for better weights automatically using self-play.
 
 import (
 	"github.com/pronovic/go-apologies/model"
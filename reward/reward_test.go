@@ -28,7 +28,7 @@ func TestCalculateRewardEmptyGame(t *testing.T) {
 	calc := NewCalculator()
 	for _, count := range []int{2, 3, 4} {
 		for _, color := range model.PlayerColors.Members()[0:count] {
-			game, _ := model.NewGame(count, nil)
+			game, _ := model.NewGame(count, nil, nil)
 			view, _ := game.CreatePlayerView(color)
 			assert.Equal(t, float32(0.0), calc.Calculate(view)) // score is always zero if all pawns are in start
 		}
@@ -37,7 +37,7 @@ func TestCalculateRewardEmptyGame(t *testing.T) {
 
 func TestCalculateRewardEquivalentState(t *testing.T) {
 	calc := NewCalculator()
-	game, _ := model.NewGame(4, nil)
+	game, _ := model.NewGame(4, nil, nil)
 	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToSquare(4)
 	_ = game.Players()[model.Yellow].Pawns()[0].Position().MoveToSquare(34)
 	_ = game.Players()[model.Green].Pawns()[0].Position().MoveToSquare(49)
@@ -50,7 +50,7 @@ func TestCalculateRewardEquivalentState(t *testing.T) {
 
 func TestCalculateRewardSafeZone(t *testing.T) {
 	calc := NewCalculator()
-	game, _ := model.NewGame(4, nil)
+	game, _ := model.NewGame(4, nil, nil)
 	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToSafe(4) // last safe square before home
 	view, _ := game.CreatePlayerView(model.Red)
 	assert.Equal(t, float32(222), calc.Calculate(view))
@@ -63,7 +63,7 @@ func TestCalculateRewardSafeZone(t *testing.T) {
 func TestCalculateRewardWinner(t *testing.T) {
 	calc := NewCalculator()
 
-	game2, _ := model.NewGame(2, nil)
+	game2, _ := model.NewGame(2, nil, nil)
 	_ = game2.Players()[model.Red].Pawns()[0].Position().MoveToHome()
 	_ = game2.Players()[model.Red].Pawns()[1].Position().MoveToHome()
 	_ = game2.Players()[model.Red].Pawns()[2].Position().MoveToHome()
@@ -75,7 +75,7 @@ func TestCalculateRewardWinner(t *testing.T) {
 		assert.Equal(t, float32(0), calc.Calculate(view2)) // score is always zero if all pawns are in start
 	}
 
-	game3, _ := model.NewGame(3, nil)
+	game3, _ := model.NewGame(3, nil, nil)
 	_ = game3.Players()[model.Red].Pawns()[0].Position().MoveToHome()
 	_ = game3.Players()[model.Red].Pawns()[1].Position().MoveToHome()
 	_ = game3.Players()[model.Red].Pawns()[2].Position().MoveToHome()
@@ -87,7 +87,7 @@ func TestCalculateRewardWinner(t *testing.T) {
 		assert.Equal(t, float32(0), calc.Calculate(view3)) // score is always zero if all pawns are in start
 	}
 
-	game4, _ := model.NewGame(4, nil)
+	game4, _ := model.NewGame(4, nil, nil)
 	_ = game4.Players()[model.Red].Pawns()[0].Position().MoveToHome()
 	_ = game4.Players()[model.Red].Pawns()[1].Position().MoveToHome()
 	_ = game4.Players()[model.Red].Pawns()[2].Position().MoveToHome()
@@ -102,7 +102,7 @@ func TestCalculateRewardWinner(t *testing.T) {
 
 func TestCalculateRewardArbitrary(t *testing.T) {
 	calc := NewCalculator()
-	game, _ := model.NewGame(4, nil)
+	game, _ := model.NewGame(4, nil, nil)
 
 	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToHome()
 	_ = game.Players()[model.Red].Pawns()[1].Position().MoveToSafe(0)
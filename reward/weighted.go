@@ -0,0 +1,231 @@
+package reward
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pronovic/go-apologies/model"
+	"gopkg.in/yaml.v3"
+)
+
+// maxDistance is the total distance to home for a player with all 4 pawns in start
+const maxDistance = model.Pawns * 65
+
+// exposedSquares is the farthest that an opponent can be behind a pawn and still reach it with a forward move
+const exposedSquares = 12
+
+// Weights The weight for each feature scored by a weighted calculator.
+//
+// Each feature is counted for every player, and the player's reward is its own score relative to the
+// scores of its opponents, just like the original calculator.  A positive weight rewards a feature and
+// a negative weight penalizes it.  The default weights reproduce the original hand-tuned calculator.
+// The Hand weight is not a board feature, so the weighted calculator ignores it; it scales the value of
+// the cards kept in hand, as scored by a hand evaluator, relative to the reward for the board.
+type Weights struct {
+	// Distance Weight for each square closer to home, summed across all pawns
+	Distance float32 `json:"distance" yaml:"distance"`
+
+	// Safe Weight for each pawn in the safe zone or at home
+	Safe float32 `json:"safe" yaml:"safe"`
+
+	// Winner Weight for having all pawns at home
+	Winner float32 `json:"winner" yaml:"winner"`
+
+	// Bumped Weight for each opponent pawn in start
+	Bumped float32 `json:"bumped" yaml:"bumped"`
+
+	// SlideStart Weight for each pawn sitting on the start of an opponent's slide
+	SlideStart float32 `json:"slidestart" yaml:"slidestart"`
+
+	// Exposed Weight for each pawn with an opponent 1-12 squares behind it
+	Exposed float32 `json:"exposed" yaml:"exposed"`
+
+	// Start Weight for each pawn left in start
+	Start float32 `json:"start" yaml:"start"`
+
+	// Hand Weight for the value of the cards kept in hand, which only matters in adult mode.
+	// Like any other weight, it is zero if missing from a weights file, so the hand is ignored entirely.
+	Hand float32 `json:"hand" yaml:"hand"`
+}
+
+// DefaultWeights returns the weights that reproduce the original calculator
+func DefaultWeights() *Weights {
+	return &Weights{
+		Distance: 1,
+		Safe:     10,
+		Winner:   100,
+		Hand:     0.25,
+	}
+}
+
+// NewWeightsFromJSON loads weights from JSON in an io.Reader, where missing weights are zero rather than their defaults
+func NewWeightsFromJSON(reader io.Reader) (*Weights, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields() // a misspelled weight would otherwise be silently ignored
+
+	var weights Weights
+	if err := decoder.Decode(&weights); err != nil {
+		return nil, err
+	}
+
+	return &weights, nil
+}
+
+// NewWeightsFromYAML loads weights from YAML in an io.Reader, where missing weights are zero rather than their defaults
+func NewWeightsFromYAML(reader io.Reader) (*Weights, error) {
+	decoder := yaml.NewDecoder(reader)
+	decoder.KnownFields(true) // a misspelled weight would otherwise be silently ignored
+
+	var weights Weights
+	if err := decoder.Decode(&weights); err != nil {
+		return nil, err
+	}
+
+	return &weights, nil
+}
+
+type weightedCalculator struct {
+	weights Weights
+}
+
+// NewWeightedCalculator constructs a Calculator that scores a configurable set of features, optionally accepting weights
+func NewWeightedCalculator(weights *Weights) Calculator {
+	if weights == nil {
+		weights = DefaultWeights()
+	}
+
+	return &weightedCalculator{
+		weights: *weights,
+	}
+}
+
+func (c *weightedCalculator) Calculate(view model.PlayerView) float32 {
+	all := view.AllPawns()
+
+	// Reward measures this player's overall game position relative to their opponents
+	playerScore := c.score(view.Player(), all)
+	opponentScore := float32(0)
+
+	// range on a map explicitly does *not* return keys in a stable order, so we iterate on colors instead
+	for _, color := range model.PlayerColors.Members() {
+		if opponent, exists := view.Opponents()[color]; exists {
+			opponentScore += c.score(opponent, all)
+		}
+	}
+
+	reward := float32(len(view.Opponents()))*playerScore - opponentScore
+	if reward < 0 {
+		return 0
+	} else {
+		return reward
+	}
+}
+
+func (c *weightedCalculator) Range(players int) (float32, float32) {
+	opponents := players - 1
+
+	// each feature contributes its best case for the player and its worst case for every opponent
+	low, high := float32(0), float32(0)
+	for _, feature := range []struct {
+		weight float32
+		max    int
+	}{
+		{c.weights.Distance, maxDistance},
+		{c.weights.Safe, model.Pawns},
+		{c.weights.Winner, 1},
+		{c.weights.Bumped, opponents * model.Pawns},
+		{c.weights.SlideStart, model.Pawns},
+		{c.weights.Exposed, model.Pawns},
+		{c.weights.Start, model.Pawns},
+	} {
+		value := feature.weight * float32(feature.max)
+		if value > 0 {
+			high += value
+		} else {
+			low += value
+		}
+	}
+
+	return 0.0, float32(opponents) * (high - low)
+}
+
+// score calculates the weighted score for a single player, given all of the pawns on the board
+func (c *weightedCalculator) score(player model.Player, all []model.Pawn) float32 {
+	distance, safe, bumped, slideStart, exposed, start := 0, 0, 0, 0, 0, 0
+
+	for _, pawn := range player.Pawns() {
+		distance += distanceToHome(pawn)
+
+		if pawn.Position().Home() || pawn.Position().Safe() != nil {
+			safe += 1
+		}
+
+		if pawn.Position().Start() {
+			start += 1
+		}
+
+		if onOpponentSlideStart(pawn) {
+			slideStart += 1
+		}
+
+		if isExposed(pawn, all) {
+			exposed += 1
+		}
+	}
+
+	for _, pawn := range all {
+		if pawn.Color() != player.Color() && pawn.Position().Start() {
+			bumped += 1
+		}
+	}
+
+	winner := 0
+	if player.AllPawnsInHome() {
+		winner = 1
+	}
+
+	return c.weights.Distance*float32(maxDistance-distance) +
+		c.weights.Safe*float32(safe) +
+		c.weights.Winner*float32(winner) +
+		c.weights.Bumped*float32(bumped) +
+		c.weights.SlideStart*float32(slideStart) +
+		c.weights.Exposed*float32(exposed) +
+		c.weights.Start*float32(start)
+}
+
+// onOpponentSlideStart whether a pawn is sitting on the start of a slide for another color
+func onOpponentSlideStart(pawn model.Pawn) bool {
+	if pawn.Position().Square() == nil {
+		return false
+	}
+
+	for _, color := range model.PlayerColors.Members() {
+		if color != pawn.Color() {
+			for _, slide := range model.Slides[color] {
+				if slide.Start() == *pawn.Position().Square() {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// isExposed whether a pawn on the board has an opponent 1-12 squares behind it, close enough to land on it
+func isExposed(pawn model.Pawn, all []model.Pawn) bool {
+	if pawn.Position().Square() == nil {
+		return false
+	}
+
+	for _, other := range all {
+		if other.Color() != pawn.Color() && other.Position().Square() != nil {
+			behind := (*pawn.Position().Square() - *other.Position().Square() + model.BoardSquares) % model.BoardSquares
+			if behind >= 1 && behind <= exposedSquares {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,146 @@
+package reward
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeightedCalculatorDefaults(t *testing.T) {
+	original := NewCalculator()
+	weighted := NewWeightedCalculator(nil)
+
+	for _, players := range []int{2, 3, 4} {
+		low1, high1 := original.Range(players)
+		low2, high2 := weighted.Range(players)
+		assert.Equal(t, low1, low2)
+		assert.Equal(t, high1, high2)
+	}
+
+	// the default weights give the same reward as the original calculator, in every position
+	game, _ := model.NewGame(4, nil, nil)
+	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToHome()
+	_ = game.Players()[model.Red].Pawns()[1].Position().MoveToSafe(0)
+	_ = game.Players()[model.Red].Pawns()[2].Position().MoveToSquare(6)
+	_ = game.Players()[model.Red].Pawns()[3].Position().MoveToSquare(10)
+	_ = game.Players()[model.Yellow].Pawns()[0].Position().MoveToSquare(34)
+	_ = game.Players()[model.Yellow].Pawns()[1].Position().MoveToSquare(32)
+	_ = game.Players()[model.Yellow].Pawns()[3].Position().MoveToHome()
+	_ = game.Players()[model.Green].Pawns()[2].Position().MoveToSquare(59)
+
+	for _, color := range model.PlayerColors.Members() {
+		view, _ := game.CreatePlayerView(color)
+		assert.Equal(t, original.Calculate(view), weighted.Calculate(view))
+	}
+}
+
+func TestWeightedCalculatorRange(t *testing.T) {
+	weights := &Weights{Distance: 1, Safe: 10, Winner: 100, Bumped: 5, SlideStart: -20, Exposed: -10, Start: -1}
+	calc := NewWeightedCalculator(weights)
+
+	// per opponent, the best case for the player less the worst case for the opponent
+	low, high := calc.Range(2)
+	assert.Equal(t, float32(0), low)
+	assert.Equal(t, float32(260+40+100+20+80+40+4), high)
+
+	low, high = calc.Range(4)
+	assert.Equal(t, float32(0), low)
+	assert.Equal(t, float32(3*(260+40+100+60+80+40+4)), high)
+}
+
+func TestWeightedCalculatorFeatures(t *testing.T) {
+	game, _ := model.NewGame(2, nil, nil)
+	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToSquare(31) // on Yellow's slide start
+	_ = game.Players()[model.Red].Pawns()[1].Position().MoveToSquare(50) // 5 squares ahead of Yellow
+	_ = game.Players()[model.Yellow].Pawns()[0].Position().MoveToSquare(45)
+	_ = game.Players()[model.Yellow].Pawns()[1].Position().MoveToSquare(16) // on Blue's slide start
+	_ = game.Players()[model.Yellow].Pawns()[2].Position().MoveToSquare(5)
+
+	red, _ := game.CreatePlayerView(model.Red)
+	yellow, _ := game.CreatePlayerView(model.Yellow)
+
+	// each feature is counted once per pawn, and the reward is relative to the opponent
+	calc := NewWeightedCalculator(&Weights{SlideStart: -1})
+	assert.Equal(t, float32(0), calc.Calculate(red))
+	calc = NewWeightedCalculator(&Weights{SlideStart: 1})
+	assert.Equal(t, float32(0), calc.Calculate(red)) // both have one pawn on a slide start
+
+	calc = NewWeightedCalculator(&Weights{Exposed: 1})
+	assert.Equal(t, float32(1), calc.Calculate(red))    // Red's pawn on 50 is exposed, but Yellow's pawns are not
+	assert.Equal(t, float32(0), calc.Calculate(yellow)) // a negative reward is clipped to zero
+
+	calc = NewWeightedCalculator(&Weights{Start: 1})
+	assert.Equal(t, float32(1), calc.Calculate(red)) // Red has 2 pawns in start and Yellow has 1
+
+	calc = NewWeightedCalculator(&Weights{Bumped: 1})
+	assert.Equal(t, float32(0), calc.Calculate(red))
+	assert.Equal(t, float32(1), calc.Calculate(yellow)) // Yellow has bumped more of Red's pawns back to start
+
+	calc = NewWeightedCalculator(&Weights{Safe: 1, Winner: 1})
+	assert.Equal(t, float32(0), calc.Calculate(red))
+}
+
+func TestOnOpponentSlideStart(t *testing.T) {
+	pawn := model.NewPawn(model.Red, 0)
+	assert.False(t, onOpponentSlideStart(pawn)) // in start
+
+	_ = pawn.Position().MoveToSquare(1)
+	assert.False(t, onOpponentSlideStart(pawn)) // Red's own slide
+
+	for _, square := range []int{16, 24, 31, 39, 46, 54} {
+		_ = pawn.Position().MoveToSquare(square)
+		assert.True(t, onOpponentSlideStart(pawn))
+	}
+
+	_ = pawn.Position().MoveToSquare(17)
+	assert.False(t, onOpponentSlideStart(pawn))
+}
+
+func TestIsExposed(t *testing.T) {
+	pawn := model.NewPawn(model.Red, 0)
+	other := model.NewPawn(model.Blue, 0)
+	friend := model.NewPawn(model.Red, 1)
+	all := []model.Pawn{pawn, other, friend}
+
+	assert.False(t, isExposed(pawn, all)) // in start
+
+	_ = pawn.Position().MoveToSquare(5)
+	_ = friend.Position().MoveToSquare(4)
+	assert.False(t, isExposed(pawn, all)) // only opponents count
+
+	for _, square := range []int{53, 59, 4} {
+		_ = other.Position().MoveToSquare(square)
+		assert.True(t, isExposed(pawn, all)) // wraps around the board
+	}
+
+	for _, square := range []int{52, 5, 6} {
+		_ = other.Position().MoveToSquare(square)
+		assert.False(t, isExposed(pawn, all))
+	}
+
+	_ = other.Position().MoveToStart()
+	assert.False(t, isExposed(pawn, all))
+}
+
+func TestNewWeightsFromJSON(t *testing.T) {
+	weights, err := NewWeightsFromJSON(strings.NewReader(`{"distance": 2, "safe": 15.5, "exposed": -8}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &Weights{Distance: 2, Safe: 15.5, Exposed: -8}, weights)
+
+	_, err = NewWeightsFromJSON(strings.NewReader(`{"distnace": 2}`))
+	assert.EqualError(t, err, `json: unknown field "distnace"`)
+
+	_, err = NewWeightsFromJSON(strings.NewReader(`{"distance": "far"}`))
+	assert.Error(t, err)
+}
+
+func TestNewWeightsFromYAML(t *testing.T) {
+	weights, err := NewWeightsFromYAML(strings.NewReader("distance: 2\nsafe: 15.5\nslidestart: -20\nstart: -3\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, &Weights{Distance: 2, Safe: 15.5, SlideStart: -20, Start: -3}, weights)
+
+	_, err = NewWeightsFromYAML(strings.NewReader("distnace: 2\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,369 @@
+// Package rpc contains the gRPC service for remote input sources, generated from source.proto, along with
+// conversions between the protobuf messages and the model.  Regenerate the service code with "make proto".
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pronovic/go-apologies/model"
+)
+
+var gameModes = map[GameMode]model.GameMode{
+	GameMode_GAME_MODE_STANDARD: model.StandardMode,
+	GameMode_GAME_MODE_ADULT:    model.AdultMode,
+}
+
+var playerColors = map[PlayerColor]model.PlayerColor{
+	PlayerColor_PLAYER_COLOR_RED:    model.Red,
+	PlayerColor_PLAYER_COLOR_YELLOW: model.Yellow,
+	PlayerColor_PLAYER_COLOR_GREEN:  model.Green,
+	PlayerColor_PLAYER_COLOR_BLUE:   model.Blue,
+}
+
+var cardTypes = map[CardType]model.CardType{
+	CardType_CARD_TYPE_1:         model.Card1,
+	CardType_CARD_TYPE_2:         model.Card2,
+	CardType_CARD_TYPE_3:         model.Card3,
+	CardType_CARD_TYPE_4:         model.Card4,
+	CardType_CARD_TYPE_5:         model.Card5,
+	CardType_CARD_TYPE_7:         model.Card7,
+	CardType_CARD_TYPE_8:         model.Card8,
+	CardType_CARD_TYPE_10:        model.Card10,
+	CardType_CARD_TYPE_11:        model.Card11,
+	CardType_CARD_TYPE_12:        model.Card12,
+	CardType_CARD_TYPE_APOLOGIES: model.CardApologies,
+}
+
+var actionTypes = map[ActionType]model.ActionType{
+	ActionType_ACTION_TYPE_MOVE_TO_START:    model.MoveToStart,
+	ActionType_ACTION_TYPE_MOVE_TO_POSITION: model.MoveToPosition,
+}
+
+// ToGameMode converts a game mode to its message
+func ToGameMode(mode model.GameMode) GameMode {
+	return toEnum(gameModes, mode)
+}
+
+// FromGameMode converts a message to a game mode
+func FromGameMode(mode GameMode) (model.GameMode, error) {
+	return fromEnum(gameModes, mode)
+}
+
+// ToPlayerView converts a player view to its message, with the opponents in order by color
+func ToPlayerView(view model.PlayerView) *PlayerView {
+	opponents := make([]*Player, 0, len(view.Opponents()))
+	for _, color := range model.PlayerColors.Members() {
+		if opponent, exists := view.Opponents()[color]; exists {
+			opponents = append(opponents, toPlayer(opponent))
+		}
+	}
+
+	return &PlayerView{
+		Player:      toPlayer(view.Player()),
+		Opponents:   opponents,
+		DiscardPile: toCards(view.DiscardPile()),
+	}
+}
+
+// FromPlayerView converts a message to a player view
+func FromPlayerView(view *PlayerView) (model.PlayerView, error) {
+	if view == nil || view.Player == nil {
+		return nil, errors.New("view has no player")
+	}
+
+	player, err := fromPlayer(view.Player)
+	if err != nil {
+		return nil, err
+	}
+
+	opponents := make(map[model.PlayerColor]model.Player, len(view.Opponents))
+	for _, message := range view.Opponents {
+		opponent, err := fromPlayer(message)
+		if err != nil {
+			return nil, err
+		}
+
+		opponents[opponent.Color()] = opponent
+	}
+
+	discardPile, err := fromCards(view.DiscardPile)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewPlayerView(player, opponents, discardPile), nil
+}
+
+// ToMoves converts a list of moves to messages
+func ToMoves(moves []model.Move) []*Move {
+	messages := make([]*Move, 0, len(moves))
+	for _, move := range moves {
+		messages = append(messages, &Move{
+			Card:        toCard(move.Card()),
+			Actions:     toActions(move.Actions()),
+			SideEffects: toActions(move.SideEffects()),
+		})
+	}
+
+	return messages
+}
+
+// FromMoves converts a list of messages to moves
+func FromMoves(messages []*Move) ([]model.Move, error) {
+	moves := make([]model.Move, 0, len(messages))
+	for _, message := range messages {
+		card, err := fromCard(message.GetCard())
+		if err != nil {
+			return nil, err
+		}
+
+		actions, err := fromActions(message.GetActions())
+		if err != nil {
+			return nil, err
+		}
+
+		sideEffects, err := fromActions(message.GetSideEffects())
+		if err != nil {
+			return nil, err
+		}
+
+		moves = append(moves, model.NewMove(card, actions, sideEffects))
+	}
+
+	return moves, nil
+}
+
+func toPlayer(player model.Player) *Player {
+	pawns := make([]*Pawn, 0, len(player.Pawns()))
+	for _, pawn := range player.Pawns() {
+		pawns = append(pawns, toPawn(pawn))
+	}
+
+	return &Player{
+		Color: toEnum(playerColors, player.Color()),
+		Hand:  toCards(player.Hand()),
+		Pawns: pawns,
+		Turns: int32(player.Turns()),
+	}
+}
+
+func fromPlayer(message *Player) (model.Player, error) {
+	color, err := fromEnum(playerColors, message.GetColor())
+	if err != nil {
+		return nil, err
+	}
+
+	player := model.NewPlayer(color)
+	if len(message.Pawns) != len(player.Pawns()) {
+		return nil, fmt.Errorf("player %s has %d pawns, expected %d", color.Value(), len(message.Pawns), len(player.Pawns()))
+	}
+
+	for i, pawn := range message.Pawns {
+		position, err := fromPosition(pawn.GetPosition())
+		if err != nil {
+			return nil, err
+		} else if position != nil {
+			player.Pawns()[i].SetPosition(position)
+		}
+	}
+
+	hand, err := fromCards(message.Hand)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, card := range hand {
+		player.AppendToHand(card)
+	}
+
+	// the model only allows the number of turns to be counted up
+	for i := int32(0); i < message.Turns; i++ {
+		player.IncrementTurns()
+	}
+
+	return player, nil
+}
+
+func toPawn(pawn model.Pawn) *Pawn {
+	if pawn == nil {
+		return nil
+	}
+
+	return &Pawn{
+		Color:    toEnum(playerColors, pawn.Color()),
+		Index:    int32(pawn.Index()),
+		Position: toPosition(pawn.Position()),
+	}
+}
+
+func fromPawn(message *Pawn) (model.Pawn, error) {
+	if message == nil {
+		return nil, nil
+	}
+
+	color, err := fromEnum(playerColors, message.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.Index < 0 || message.Index >= model.Pawns {
+		return nil, fmt.Errorf("invalid pawn index %d", message.Index)
+	}
+
+	position, err := fromPosition(message.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	pawn := model.NewPawn(color, int(message.Index))
+	if position != nil {
+		pawn.SetPosition(position)
+	}
+
+	return pawn, nil
+}
+
+func toPosition(position model.Position) *Position {
+	if position == nil {
+		return nil
+	}
+
+	message := &Position{Start: position.Start(), Home: position.Home()}
+	if position.Safe() != nil {
+		safe := int32(*position.Safe())
+		message.Safe = &safe
+	}
+
+	if position.Square() != nil {
+		square := int32(*position.Square())
+		message.Square = &square
+	}
+
+	return message
+}
+
+func fromPosition(message *Position) (model.Position, error) {
+	if message == nil {
+		return nil, nil
+	}
+
+	var safe, square *int
+	if message.Safe != nil {
+		value := int(*message.Safe)
+		safe = &value
+	}
+
+	if message.Square != nil {
+		value := int(*message.Square)
+		square = &value
+	}
+
+	// moving an empty position validates the message, the same way that the model validates a move
+	position := model.NewPosition(false, false, nil, nil)
+	if err := position.MoveToPosition(model.NewPosition(message.Start, message.Home, safe, square)); err != nil {
+		return nil, err
+	}
+
+	return position, nil
+}
+
+func toCards(cards []model.Card) []*Card {
+	messages := make([]*Card, 0, len(cards))
+	for _, card := range cards {
+		messages = append(messages, toCard(card))
+	}
+
+	return messages
+}
+
+func fromCards(messages []*Card) ([]model.Card, error) {
+	cards := make([]model.Card, 0, len(messages))
+	for _, message := range messages {
+		card, err := fromCard(message)
+		if err != nil {
+			return nil, err
+		}
+
+		cards = append(cards, card)
+	}
+
+	return cards, nil
+}
+
+func toCard(card model.Card) *Card {
+	return &Card{Id: card.Id(), Type: toEnum(cardTypes, card.Type())}
+}
+
+func fromCard(message *Card) (model.Card, error) {
+	cardType, err := fromEnum(cardTypes, message.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewCard(message.GetId(), cardType), nil
+}
+
+func toActions(actions []model.Action) []*Action {
+	messages := make([]*Action, 0, len(actions))
+	for _, action := range actions {
+		messages = append(messages, &Action{
+			Type:     toEnum(actionTypes, action.Type()),
+			Pawn:     toPawn(action.Pawn()),
+			Position: toPosition(action.Position()),
+			Slid:     action.Slid(),
+		})
+	}
+
+	return messages
+}
+
+func fromActions(messages []*Action) ([]model.Action, error) {
+	actions := make([]model.Action, 0, len(messages))
+	for _, message := range messages {
+		actionType, err := fromEnum(actionTypes, message.GetType())
+		if err != nil {
+			return nil, err
+		}
+
+		pawn, err := fromPawn(message.GetPawn())
+		if err != nil {
+			return nil, err
+		}
+
+		position, err := fromPosition(message.Position)
+		if err != nil {
+			return nil, err
+		}
+
+		action := model.NewAction(actionType, pawn, position)
+		action.SetSlid(message.GetSlid())
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// toEnum converts a model enum to its message, or to the unspecified value if it has no message
+func toEnum[P comparable, M comparable](mapping map[P]M, value M) P {
+	for message, converted := range mapping {
+		if converted == value {
+			return message
+		}
+	}
+
+	return *new(P)
+}
+
+// fromEnum converts a message to a model enum, failing for the unspecified value
+func fromEnum[P interface {
+	comparable
+	fmt.Stringer
+}, M any](mapping map[P]M, value P) (M, error) {
+	converted, exists := mapping[value]
+	if !exists {
+		return *new(M), fmt.Errorf("invalid value %s", value)
+	}
+
+	return converted, nil
+}
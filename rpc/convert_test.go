@@ -0,0 +1,162 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/rules"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// setupGame returns the view and legal moves for Red in an adult mode game with pawns all over the board
+func setupGame(t *testing.T) (model.PlayerView, []model.Move) {
+	game, _ := model.NewGame(4, nil, random.NewSeededRandomizer(17))
+	evaluator := rules.NewRules(nil)
+	assert.NoError(t, evaluator.StartGame(game, model.AdultMode))
+
+	_ = game.Players()[model.Red].Pawns()[1].Position().MoveToSquare(32)
+	_ = game.Players()[model.Red].Pawns()[2].Position().MoveToSafe(2)
+	_ = game.Players()[model.Red].Pawns()[3].Position().MoveToHome()
+	_ = game.Players()[model.Yellow].Pawns()[0].Position().MoveToSquare(35)
+	_ = game.Players()[model.Green].Pawns()[2].Position().MoveToSquare(8)
+	_ = game.Players()[model.Blue].Pawns()[3].Position().MoveToSquare(15)
+	game.Players()[model.Red].IncrementTurns()
+	game.Players()[model.Red].IncrementTurns()
+	card, _ := game.Deck().Draw()
+	_ = game.Deck().Discard(card)
+
+	view, err := game.CreatePlayerView(model.Red)
+	assert.NoError(t, err)
+
+	// an Apologies card guarantees a move with a side effect
+	view.Player().AppendToHand(model.NewCard("apologies", model.CardApologies))
+	moves, err := evaluator.ConstructLegalMoves(view, nil)
+	assert.NoError(t, err)
+	assert.Greater(t, len(moves), 10)
+
+	return view, moves
+}
+
+func TestGameMode(t *testing.T) {
+	for _, mode := range model.GameModes.Members() {
+		converted, err := FromGameMode(ToGameMode(mode))
+		assert.NoError(t, err)
+		assert.Equal(t, mode, converted)
+	}
+
+	_, err := FromGameMode(GameMode_GAME_MODE_UNSPECIFIED)
+	assert.EqualError(t, err, "invalid value GAME_MODE_UNSPECIFIED")
+}
+
+func TestEnumsComplete(t *testing.T) {
+	for _, color := range model.PlayerColors.Members() {
+		assert.NotEqual(t, PlayerColor_PLAYER_COLOR_UNSPECIFIED, toEnum(playerColors, color))
+	}
+
+	for _, cardType := range model.CardTypes.Members() {
+		assert.NotEqual(t, CardType_CARD_TYPE_UNSPECIFIED, toEnum(cardTypes, cardType))
+	}
+
+	for _, actionType := range model.ActionTypes.Members() {
+		assert.NotEqual(t, ActionType_ACTION_TYPE_UNSPECIFIED, toEnum(actionTypes, actionType))
+	}
+}
+
+func TestPlayerViewRoundTrip(t *testing.T) {
+	view, _ := setupGame(t)
+
+	message := ToPlayerView(view)
+	assert.Equal(t, PlayerColor_PLAYER_COLOR_RED, message.Player.Color)
+	assert.Equal(t, int32(2), message.Player.Turns)
+	assert.Equal(t, []PlayerColor{PlayerColor_PLAYER_COLOR_YELLOW, PlayerColor_PLAYER_COLOR_GREEN, PlayerColor_PLAYER_COLOR_BLUE}, []PlayerColor{
+		message.Opponents[0].Color, message.Opponents[1].Color, message.Opponents[2].Color,
+	})
+
+	// the message survives the wire intact
+	marshalled, err := proto.Marshal(message)
+	assert.NoError(t, err)
+	unmarshalled := &PlayerView{}
+	assert.NoError(t, proto.Unmarshal(marshalled, unmarshalled))
+
+	converted, err := FromPlayerView(unmarshalled)
+	assert.NoError(t, err)
+	assert.Equal(t, view, converted)
+}
+
+func TestMovesRoundTrip(t *testing.T) {
+	_, moves := setupGame(t)
+
+	messages := ToMoves(moves)
+	assert.Equal(t, len(moves), len(messages))
+
+	wire := make([]*Move, 0, len(messages))
+	for _, message := range messages {
+		marshalled, err := proto.Marshal(message)
+		assert.NoError(t, err)
+		unmarshalled := &Move{}
+		assert.NoError(t, proto.Unmarshal(marshalled, unmarshalled))
+		wire = append(wire, unmarshalled)
+	}
+
+	converted, err := FromMoves(wire)
+	assert.NoError(t, err)
+	assert.Equal(t, moves, converted)
+
+	sideEffects := 0
+	for _, move := range converted {
+		sideEffects += len(move.SideEffects())
+	}
+	assert.Greater(t, sideEffects, 0)
+}
+
+func TestFromPlayerViewInvalid(t *testing.T) {
+	view, _ := setupGame(t)
+
+	for _, c := range []struct {
+		name    string
+		change  func(message *PlayerView)
+		message string
+	}{
+		{"no player", func(message *PlayerView) { message.Player = nil }, "view has no player"},
+		{"no color", func(message *PlayerView) { message.Player.Color = PlayerColor_PLAYER_COLOR_UNSPECIFIED }, "invalid value PLAYER_COLOR_UNSPECIFIED"},
+		{"no pawns", func(message *PlayerView) { message.Opponents[1].Pawns = nil }, "player Green has 0 pawns, expected 4"},
+		{"bad card", func(message *PlayerView) { message.Player.Hand[0].Type = CardType(6) }, "invalid value 6"},
+		{"bad discard", func(message *PlayerView) { message.DiscardPile[0] = nil }, "invalid value CARD_TYPE_UNSPECIFIED"},
+		{"two places", func(message *PlayerView) { message.Player.Pawns[0].Position.Home = true }, "invalid position"},
+		{"off the board", func(message *PlayerView) {
+			square := int32(60)
+			message.Player.Pawns[1].Position.Square = &square
+		}, "invalid square"},
+	} {
+		message := ToPlayerView(view)
+		c.change(message)
+		_, err := FromPlayerView(message)
+		assert.ErrorContains(t, err, c.message, c.name)
+	}
+
+	_, err := FromPlayerView(nil)
+	assert.EqualError(t, err, "view has no player")
+}
+
+func TestFromMovesInvalid(t *testing.T) {
+	_, moves := setupGame(t)
+
+	for _, c := range []struct {
+		name    string
+		change  func(message *Move)
+		message string
+	}{
+		{"no card", func(message *Move) { message.Card = nil }, "invalid value CARD_TYPE_UNSPECIFIED"},
+		{"no action type", func(message *Move) { message.Actions[0].Type = ActionType_ACTION_TYPE_UNSPECIFIED }, "invalid value ACTION_TYPE_UNSPECIFIED"},
+		{"bad pawn", func(message *Move) { message.Actions[0].Pawn.Index = 4 }, "invalid pawn index 4"},
+		{"bad pawn color", func(message *Move) { message.Actions[0].Pawn.Color = PlayerColor(9) }, "invalid value 9"},
+		{"bad position", func(message *Move) { message.Actions[0].Position = &Position{} }, "invalid position"},
+	} {
+		messages := ToMoves(moves)
+		c.change(messages[0])
+		_, err := FromMoves(messages)
+		assert.ErrorContains(t, err, c.message, c.name)
+	}
+}
@@ -15,6 +15,7 @@ type Rules interface {
 	StartGame(game model.Game, mode model.GameMode) error
 
 	// ExecuteMove Execute a player's move, updating game state
+	// A move with no actions is a forfeit, which is tracked in history but otherwise has no effect.
 	ExecuteMove(game model.Game, player model.Player, move model.Move) error
 
 	// EvaluateMove constructs a new player view that results from executing the passed-in move.
@@ -64,8 +65,14 @@ func (r *rules) StartGame(game model.Game, mode model.GameMode) error {
 			}
 		}
 
+		// range on a map explicitly does *not* return keys in a stable order, so we iterate on colors instead
 		for i := 0; i < model.AdultHand; i++ {
-			for _, player := range game.Players() {
+			for _, color := range model.PlayerColors.Members() {
+				player, exists := game.Players()[color]
+				if !exists {
+					continue
+				}
+
 				card, err := game.Deck().Draw()
 				if err != nil {
 					return err
@@ -91,16 +98,21 @@ func (r *rules) ExecuteMove(game model.Game, player model.Player, move model.Mov
 		return errors.New("move is nil")
 	}
 
+	if len(move.Actions()) == 0 {
+		game.TrackMove(fmt.Sprintf("Turn is forfeit; discarded card %s", move.Card().Type().Value()), player, move)
+		return nil
+	}
+
 	for _, action := range move.MergedActions() { // execute actions, then side effects, in order
 		// keep in mind that the pawn on the action is a different object than the pawn in the game
 		pawn := game.Players()[action.Pawn().Color()].Pawns()[action.Pawn().Index()]
 		if action.Type() == model.MoveToStart {
-			game.Track(fmt.Sprintf("Played card %s: [%s->start]", move.Card().Type().Value(), pawn.Name()), player, move.Card())
+			game.TrackMove(fmt.Sprintf("Played card %s: [%s->start]", move.Card().Type().Value(), pawn.Name()), player, move)
 			if err := pawn.Position().MoveToStart(); err != nil {
 				return err
 			}
 		} else if action.Type() == model.MoveToPosition && action.Position() != nil {
-			game.Track(fmt.Sprintf("Played card %s: [%s->position]", move.Card().Type().Value(), pawn.Name()), player, move.Card())
+			game.TrackMove(fmt.Sprintf("Played card %s: [%s->position]", move.Card().Type().Value(), pawn.Name()), player, move)
 			if err := pawn.Position().MoveToPosition(action.Position()); err != nil {
 				return err
 			}
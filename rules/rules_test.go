@@ -9,7 +9,7 @@ import (
 )
 
 func TestStartGameStandardMode(t *testing.T) {
-	game, _ := model.NewGame(2, nil)
+	game, _ := model.NewGame(2, nil, nil)
 	err := NewRules(nil).StartGame(game, model.StandardMode)
 
 	assert.NoError(t, err)
@@ -26,7 +26,7 @@ func TestStartGameStandardMode(t *testing.T) {
 }
 
 func TestStartGameAdultMode(t *testing.T) {
-	game, _ := model.NewGame(4, nil)
+	game, _ := model.NewGame(4, nil, nil)
 	err := NewRules(nil).StartGame(game, model.AdultMode)
 	assert.NoError(t, err)
 
@@ -63,7 +63,7 @@ func TestExecuteMove(t *testing.T) {
 
 	move := model.NewMove(model.NewCard("1", model.Card1), actions, sideEffects)
 
-	game, _ := model.NewGame(4, nil)
+	game, _ := model.NewGame(4, nil, nil)
 	player := game.Players()[model.Red]
 
 	err := NewRules(nil).ExecuteMove(game, player, move)
@@ -73,6 +73,32 @@ func TestExecuteMove(t *testing.T) {
 	assert.Equal(t, 11, *game.Players()[model.Yellow].Pawns()[3].Position().Square())
 	assert.True(t, game.Players()[model.Blue].Pawns()[2].Position().Start())
 	assert.Equal(t, 12, *game.Players()[model.Green].Pawns()[0].Position().Square())
+
+	// every action is tracked in history along with the complete move
+	assert.Equal(t, 4, len(game.History()))
+	for _, history := range game.History() {
+		assert.Equal(t, model.Red, *history.Color())
+		assert.Equal(t, model.Card1, *history.Card())
+		assert.Same(t, move, history.Move())
+	}
+}
+
+func TestExecuteMoveForfeit(t *testing.T) {
+	move := model.NewMove(model.NewCard("1", model.Card1), nil, nil)
+
+	game, _ := model.NewGame(4, nil, nil)
+	player := game.Players()[model.Red]
+
+	err := NewRules(nil).ExecuteMove(game, player, move)
+	assert.NoError(t, err)
+
+	// a forfeit is tracked in history, but nothing moves
+	assert.Equal(t, 1, len(game.History()))
+	assert.Equal(t, "Turn is forfeit; discarded card 1", game.History()[0].Action())
+	assert.Same(t, move, game.History()[0].Move())
+	for _, pawn := range player.Pawns() {
+		assert.True(t, pawn.Position().Start())
+	}
 }
 
 func TestEvaluateMove(t *testing.T) {
@@ -91,7 +117,7 @@ func TestEvaluateMove(t *testing.T) {
 
 	move := model.NewMove(model.NewCard("1", model.Card1), actions, sideEffects)
 
-	game, _ := model.NewGame(4, nil)
+	game, _ := model.NewGame(4, nil, nil)
 	view, err := game.CreatePlayerView(model.Red)
 	assert.NoError(t, err)
 
@@ -0,0 +1,287 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+	"github.com/pronovic/go-apologies/engine"
+	"github.com/pronovic/go-apologies/internal/enum"
+	"github.com/pronovic/go-apologies/internal/jsonutil"
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/source"
+)
+
+// NotificationType defines the types of notifications pushed to clients
+type NotificationType struct{ value string }
+
+func (e NotificationType) Value() string                         { return e.value }
+func (e NotificationType) MarshalText() (text []byte, err error) { return enum.Marshal(e) }
+func (e *NotificationType) UnmarshalText(text []byte) error {
+	return enum.Unmarshal(e, text, NotificationTypes)
+}
+
+var (
+	NotificationTypes = enum.NewValues[NotificationType](GameStarted, YourTurn, CardDrawn, Moved, GameOver)
+	GameStarted       = NotificationType{"GameStarted"}
+	YourTurn          = NotificationType{"YourTurn"}
+	CardDrawn         = NotificationType{"CardDrawn"}
+	Moved             = NotificationType{"Moved"}
+	GameOver          = NotificationType{"GameOver"}
+)
+
+// Notification Something that happened in a game, as seen by one seat.
+//
+// Every notification in a game has a sequence number, and the sequence numbers increase by one for
+// each notification.  Some notifications are only sent to one seat, so a seat sees gaps in the sequence,
+// but a client that reconnects can always resume from the last sequence number that it saw.  CardDrawn
+// is only sent to the seat that drew the card in adult mode, since the card goes into the player's hand.
+// The notifications for a turn are only sent once the turn is over, so other seats hear nothing about a turn
+// that was rolled back.  A seat that is asked to play is first sent its own copy of what has happened so far
+// in the turn, and is not sent those notifications again when the turn is over.
+type Notification struct {
+	// Seq The sequence number of the notification within the game
+	Seq int `json:"seq"`
+
+	// Type The type of the notification
+	Type NotificationType `json:"type"`
+
+	// Color The player that the notification is about, or the winner for GameOver
+	Color *model.PlayerColor `json:"color"`
+
+	// Card The card that was drawn, for CardDrawn
+	Card model.Card `json:"card"`
+
+	// Move The move that was played, for Moved
+	Move model.Move `json:"move"`
+
+	// Description A readable description of the notification
+	Description string `json:"description"`
+
+	// audience The only seat that may see the notification, or nil if every seat may see it
+	audience *model.PlayerColor
+
+	// delivered The seats that were already sent their own copy of the notification while the turn was in progress
+	delivered []model.PlayerColor
+}
+
+// NewNotificationFromJSON constructs a new notification from JSON in an io.Reader
+func NewNotificationFromJSON(reader io.Reader) (Notification, error) {
+	type raw struct {
+		Seq         int                `json:"seq"`
+		Type        NotificationType   `json:"type"`
+		Color       *model.PlayerColor `json:"color"`
+		Card        json.RawMessage    `json:"card"`
+		Move        json.RawMessage    `json:"move"`
+		Description string             `json:"description"`
+	}
+
+	var temp raw
+	err := json.NewDecoder(reader).Decode(&temp)
+	if err != nil {
+		return Notification{}, err
+	}
+
+	card, err := jsonutil.DecodeInterfaceJSON(temp.Card, model.NewCardFromJSON)
+	if err != nil {
+		return Notification{}, err
+	}
+
+	move, err := jsonutil.DecodeInterfaceJSON(temp.Move, model.NewMoveFromJSON)
+	if err != nil {
+		return Notification{}, err
+	}
+
+	return Notification{
+		Seq:         temp.Seq,
+		Type:        temp.Type,
+		Color:       temp.Color,
+		Card:        card,
+		Move:        move,
+		Description: temp.Description,
+	}, nil
+}
+
+// visible whether a notification may be seen by a seat
+func (n *Notification) visible(color model.PlayerColor) bool {
+	return (n.audience == nil || *n.audience == color) && !slices.Contains(n.delivered, color)
+}
+
+// feed is the sequence of notifications for a game, guarded by the game's lock
+type feed struct {
+	notifications []Notification
+	changed       chan struct{} // closed and replaced whenever a notification is added or the game is published
+}
+
+func newFeed() *feed {
+	return &feed{notifications: make([]Notification, 0), changed: make(chan struct{})}
+}
+
+// add adds a notification to the feed, assigning its sequence number and waking up anyone waiting for it
+func (f *feed) add(notification Notification) {
+	notification.Seq = len(f.notifications) + 1
+	f.notifications = append(f.notifications, notification)
+	f.wake()
+}
+
+// wake wakes up anyone waiting on the feed, so they notice when the game itself has changed
+func (f *feed) wake() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// since returns the notifications visible to a seat after a sequence number, and a channel closed once there are more
+func (f *feed) since(color model.PlayerColor, after int) ([]Notification, <-chan struct{}) {
+	found := make([]Notification, 0)
+	if after < 0 {
+		after = 0
+	}
+
+	for _, notification := range f.notifications[min(after, len(f.notifications)):] {
+		if notification.visible(color) {
+			found = append(found, notification)
+		}
+	}
+
+	return found, f.changed
+}
+
+// notify translates engine events into notifications, called by the runner without holding the game's lock.
+//
+// Apart from GameStarted, the notifications for a turn are held back until the turn is over, since a turn that
+// fails is rolled back and played again, and clients should never hear about a turn that didn't happen.  Moved
+// is only added once the move has been executed, but it goes ahead of the replacement card drawn in adult mode,
+// so clients see the move before the card that it caused to be drawn.  YourTurn is not among these, since the
+// remote input source adds it along with the request for a move, right after revealing the turn so far to the seat.
+func (g *hosted) notify(event engine.Event) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	switch event.Type {
+	case engine.GameStarted:
+		g.feed.add(Notification{Type: GameStarted, Description: "Game started"})
+	case engine.CardDrawn:
+		notification := Notification{Type: CardDrawn, Color: event.Color, Card: event.Card}
+		notification.Description = event.Color.Value() + " drew card " + event.Card.Type().Value()
+		if g.runtime.Mode() == model.AdultMode {
+			notification.audience = event.Color
+		}
+		g.turn = append(g.turn, notification)
+	case engine.MoveChosen:
+		// the move has not been executed yet, so the view still shows where the pawns are moving from
+		description := ""
+		if view, err := event.Game.CreatePlayerView(*event.Color); err == nil {
+			description = event.Color.Value() + " played " + source.DescribeMove(view, event.Move)
+		}
+		g.chosen = &Notification{Type: Moved, Color: event.Color, Move: event.Move, Description: description}
+		g.chosenAt = len(g.turn)
+	case engine.ActionApplied, engine.TurnForfeited:
+		// the first event for a move that was executed, since the rest are about the same move
+		if g.chosen != nil {
+			g.turn = slices.Insert(g.turn, g.chosenAt, *g.chosen)
+			g.chosen = nil
+		}
+	case engine.GameCompleted:
+		g.turn = append(g.turn, Notification{Type: GameOver, Color: event.Color, Description: event.Color.Value() + " won the game"})
+	}
+}
+
+// reveal sends a seat its own copy of the notifications held back for the turn so far, for a seat that is asked to
+// play before the turn is over, called while the game's lock is held
+func (g *hosted) reveal(color model.PlayerColor) {
+	for i := range g.turn {
+		if g.turn[i].visible(color) {
+			private := g.turn[i]
+			private.audience = &color
+			private.delivered = nil
+			g.feed.add(private)
+			g.turn[i].delivered = append(g.turn[i].delivered, color)
+		}
+	}
+}
+
+// finish adds the notifications held back for a turn to the feed, or drops them if the turn was rolled back,
+// called while the game's lock is held
+func (g *hosted) finish(played bool) {
+	if played {
+		for _, notification := range g.turn {
+			g.feed.add(notification)
+		}
+	}
+
+	g.turn = g.turn[:0]
+	g.chosen = nil
+}
+
+// upgrader upgrades event requests to WebSocket connections, allowing any origin since seats are authorized by token
+var upgrader = websocket.Upgrader{CheckOrigin: func(_ *http.Request) bool { return true }}
+
+// events streams the notifications for a seat over a WebSocket, starting after the sequence number in the "after" parameter.
+// The stream is closed normally once the game is over (or has failed or been deleted) and the client has seen every notification.
+func (s *server) events(w http.ResponseWriter, r *http.Request, id string, name string) error {
+	after := 0
+	if value := r.URL.Query().Get("after"); value != "" {
+		var err error
+		if after, err = strconv.Atoi(value); err != nil {
+			return withStatus(http.StatusBadRequest, err)
+		}
+	}
+
+	var g *hosted
+	var color model.PlayerColor
+	_, err := s.withSeat(r, id, name, func(found *hosted, seat model.PlayerColor) (any, error) {
+		g, color = found, seat
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil // the upgrader has already responded to the client
+	}
+	defer conn.Close()
+
+	// the client never sends anything, but reading is how a closed connection is detected
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		g.lock.Lock()
+		notifications, changed := g.feed.since(color, after)
+		over := g.over
+		g.lock.Unlock()
+
+		for _, notification := range notifications {
+			if err := conn.WriteJSON(notification); err != nil {
+				return nil
+			}
+			after = notification.Seq
+		}
+
+		if over {
+			closing := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "game over")
+			_ = conn.WriteMessage(websocket.CloseMessage, closing)
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pronovic/go-apologies/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationTypeJSON(t *testing.T) {
+	for _, value := range NotificationTypes.Members() {
+		marshalled, err := json.Marshal(value)
+		assert.NoError(t, err)
+		assert.Equal(t, `"`+value.Value()+`"`, string(marshalled))
+
+		var unmarshalled NotificationType
+		assert.NoError(t, json.Unmarshal(marshalled, &unmarshalled))
+		assert.Equal(t, value, unmarshalled)
+	}
+
+	var bogus NotificationType
+	assert.Error(t, json.Unmarshal([]byte(`"Bogus"`), &bogus))
+}
+
+func TestNotificationJSON(t *testing.T) {
+	color := model.Red
+	card := model.NewCard("card1", model.Card10)
+	move := model.NewMove(card, []model.Action{model.NewAction(model.MoveToPosition, model.NewPawn(model.Red, 0), model.NewPosition(false, false, nil, nil))}, nil)
+
+	for _, notification := range []Notification{
+		{Seq: 1, Type: GameStarted, Description: "Game started"},
+		{Seq: 2, Type: CardDrawn, Color: &color, Card: card, Description: "Red drew card 10"},
+		{Seq: 3, Type: Moved, Color: &color, Move: move, Description: "Red played something"},
+	} {
+		marshalled, err := json.Marshal(notification)
+		assert.NoError(t, err)
+
+		unmarshalled, err := NewNotificationFromJSON(bytes.NewReader(marshalled))
+		assert.NoError(t, err)
+		assert.Equal(t, notification, unmarshalled)
+	}
+}
+
+func TestFeed(t *testing.T) {
+	red, yellow := model.Red, model.Yellow
+
+	f := newFeed()
+	found, changed := f.since(red, 0)
+	assert.Empty(t, found)
+
+	f.add(Notification{Type: GameStarted})
+	f.add(Notification{Type: YourTurn, Color: &red, audience: &red})
+	f.add(Notification{Type: Moved, Color: &red})
+	f.add(Notification{Type: YourTurn, Color: &yellow, audience: &yellow})
+
+	// adding a notification wakes up anyone waiting on the feed
+	select {
+	case <-changed:
+	default:
+		assert.Fail(t, "changed was not closed")
+	}
+
+	sequence := func(notifications []Notification) []int {
+		result := make([]int, 0, len(notifications))
+		for _, notification := range notifications {
+			result = append(result, notification.Seq)
+		}
+		return result
+	}
+
+	found, changed = f.since(red, 0)
+	assert.Equal(t, []int{1, 2, 3}, sequence(found))
+
+	found, _ = f.since(yellow, 0)
+	assert.Equal(t, []int{1, 3, 4}, sequence(found))
+
+	found, _ = f.since(red, 2)
+	assert.Equal(t, []int{3}, sequence(found))
+
+	found, _ = f.since(yellow, -5)
+	assert.Equal(t, []int{1, 3, 4}, sequence(found))
+
+	found, _ = f.since(yellow, 99)
+	assert.Empty(t, found)
+
+	select {
+	case <-changed:
+		assert.Fail(t, "changed was closed without a new notification")
+	default:
+	}
+}
+
+func TestServerEventsLive(t *testing.T) {
+	ts := httptest.NewServer(NewServer(nil))
+	defer ts.Close()
+
+	var summary GameSummary
+	call(t, ts, http.MethodPost, "/games", "", `{"seats": ["remote", "RandomInputSource"], "seed": 11}`, &summary)
+
+	var joined JoinResponse
+	call(t, ts, http.MethodPost, "/games/"+summary.Id+"/seats/red", "", "", &joined)
+
+	conn := dial(t, ts, "/games/"+summary.Id+"/seats/red/events?token="+joined.Token)
+	defer conn.Close()
+
+	// play each turn as soon as the stream says that it is our turn, until the stream is closed
+	received := make([]Notification, 0)
+	for {
+		notification, err := receive(conn)
+		if err != nil {
+			assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "unexpected error: %v", err)
+			break
+		}
+
+		received = append(received, notification)
+		if notification.Type == YourTurn {
+			assert.Equal(t, model.Red, *notification.Color)
+			status := call(t, ts, http.MethodPost, "/games/"+summary.Id+"/seats/red/moves", joined.Token, `{"index": 0}`, &summary)
+			assert.Equal(t, http.StatusOK, status)
+		}
+	}
+
+	call(t, ts, http.MethodGet, "/games/"+summary.Id, "", "", &summary)
+	assert.Equal(t, GameStarted, received[0].Type)
+	assert.Equal(t, GameOver, received[len(received)-1].Type)
+	assert.Equal(t, *summary.Winner, *received[len(received)-1].Color)
+	drawn, moved := 0, 0
+	for i, notification := range received {
+		if i > 0 {
+			assert.Greater(t, notification.Seq, received[i-1].Seq)
+		}
+
+		if notification.Type == Moved {
+			assert.NotNil(t, notification.Move)
+			assert.True(t, strings.HasPrefix(notification.Description, notification.Color.Value()+" played Card "))
+		}
+
+		// our own turns are sent to us before they are over, but only once, so every card that we drew was played
+		if notification.Color != nil && *notification.Color == model.Red {
+			switch notification.Type {
+			case CardDrawn:
+				drawn++
+			case Moved:
+				moved++
+			}
+		}
+	}
+	assert.NotZero(t, moved)
+	assert.Equal(t, drawn, moved)
+
+	assert.Contains(t, types(received), CardDrawn)
+	assert.Contains(t, types(received), YourTurn)
+}
+
+func TestServerEventsVisibility(t *testing.T) {
+	ts := httptest.NewServer(NewServer(nil))
+	defer ts.Close()
+
+	var summary GameSummary
+	call(t, ts, http.MethodPost, "/games", "", `{"mode": "AdultMode", "seats": ["RewardInputSource", "remote"], "seed": 7}`, &summary)
+
+	var joined JoinResponse
+	call(t, ts, http.MethodPost, "/games/"+summary.Id+"/seats/yellow", "", "", &joined)
+	summary = await(t, ts, summary.Id, settled)
+
+	var raw json.RawMessage
+	for turns := 0; summary.Winner == nil; turns++ {
+		call(t, ts, http.MethodGet, "/games/"+summary.Id+"/seats/yellow/moves", joined.Token, "", &raw)
+		moves, err := NewMovesResponseFromJSON(bytes.NewReader(raw))
+		assert.NoError(t, err)
+
+		request := `{"index": 0}`
+		if turns%2 == 0 {
+			request = `{"id": "` + moves.Moves[len(moves.Moves)-1].Id + `"}`
+		}
+
+		if status := call(t, ts, http.MethodPost, "/games/"+summary.Id+"/seats/yellow/moves", joined.Token, request, &summary); status != http.StatusOK {
+			assert.Fail(t, "move failed", "status %d", status)
+			break
+		}
+
+		summary = await(t, ts, summary.Id, settled)
+	}
+
+	// once the game is over, connecting returns everything that the seat may see and then closes
+	conn := dial(t, ts, "/games/"+summary.Id+"/seats/yellow/events?token="+joined.Token)
+	defer conn.Close()
+	received := drain(t, conn)
+
+	skipped := false
+	for i, notification := range received {
+		if i > 0 && notification.Seq > received[i-1].Seq+1 {
+			skipped = true
+		}
+
+		switch notification.Type {
+		case CardDrawn, YourTurn:
+			assert.Equal(t, model.Yellow, *notification.Color) // in adult mode, the other seat's draws are private
+		}
+
+		// the replacement card is drawn while the move is executed, but is reported after the move
+		if notification.Type == CardDrawn {
+			assert.Equal(t, Moved, received[i-1].Type)
+			assert.Equal(t, model.Yellow, *received[i-1].Color)
+		}
+	}
+
+	assert.True(t, skipped, "expected gaps where Red's cards were drawn")
+	assert.Contains(t, types(received), CardDrawn)
+	assert.Equal(t, GameOver, received[len(received)-1].Type)
+
+	// a client that reconnects picks up where it left off
+	after := received[len(received)/2].Seq
+	resumed := dial(t, ts, "/games/"+summary.Id+"/seats/yellow/events?token="+joined.Token+"&after="+strconv.Itoa(after))
+	defer resumed.Close()
+	assert.Equal(t, received[len(received)/2+1:], drain(t, resumed))
+}
+
+func TestServerEventsDeleted(t *testing.T) {
+	ts := httptest.NewServer(NewServer(nil))
+	defer ts.Close()
+
+	var created CreateResponse
+	call(t, ts, http.MethodPost, "/games", "", `{"seats": ["remote", "RandomInputSource"], "seed": 11}`, &created)
+
+	var joined JoinResponse
+	call(t, ts, http.MethodPost, "/games/"+created.Id+"/seats/red", "", "", &joined)
+	await(t, ts, created.Id, settled)
+
+	conn := dial(t, ts, "/games/"+created.Id+"/seats/red/events?token="+joined.Token)
+	defer conn.Close()
+
+	// a client waiting on the stream is told that the game is over once it is deleted
+	received := make([]Notification, 0)
+	for len(received) == 0 || received[len(received)-1].Type != YourTurn {
+		notification, err := receive(conn)
+		if !assert.NoError(t, err) {
+			return
+		}
+		received = append(received, notification)
+	}
+
+	var deleted GameSummary
+	assert.Equal(t, http.StatusOK, call(t, ts, http.MethodDelete, "/games/"+created.Id, created.Token, "", &deleted))
+	assert.Empty(t, drain(t, conn))
+}
+
+func TestServerEventsErrors(t *testing.T) {
+	ts := httptest.NewServer(NewServer(nil))
+	defer ts.Close()
+
+	var summary GameSummary
+	call(t, ts, http.MethodPost, "/games", "", `{"seats": ["remote", "RandomInputSource"]}`, &summary)
+
+	var joined JoinResponse
+	call(t, ts, http.MethodPost, "/games/"+summary.Id+"/seats/red", "", "", &joined)
+
+	events := "/games/" + summary.Id + "/seats/red/events"
+	for _, c := range []struct {
+		method  string
+		path    string
+		token   string
+		status  int
+		message string
+	}{
+		{http.MethodGet, events, "", http.StatusUnauthorized, "a valid token for the seat is required"},
+		{http.MethodGet, events + "?token=bogus", "", http.StatusUnauthorized, "a valid token for the seat is required"},
+		{http.MethodGet, "/games/" + summary.Id + "/seats/yellow/events?token=" + joined.Token, "", http.StatusUnauthorized, "a valid token for the seat is required"},
+		{http.MethodGet, "/games/bogus/seats/red/events", "", http.StatusNotFound, "no such game: bogus"},
+		{http.MethodGet, events + "?after=first", joined.Token, http.StatusBadRequest, "invalid syntax"},
+		{http.MethodPost, events, joined.Token, http.StatusMethodNotAllowed, "method not allowed"},
+	} {
+		var failure map[string]string
+		status := call(t, ts, c.method, c.path, c.token, "", &failure)
+		assert.Equal(t, c.status, status, "%s %s", c.method, c.path)
+		assert.Contains(t, failure["error"], c.message, "%s %s", c.method, c.path)
+	}
+
+	// a valid token that is sent in the header rather than the query is accepted
+	header := http.Header{"Authorization": []string{"Bearer " + joined.Token}}
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+events, header)
+	assert.NoError(t, err)
+	if err == nil {
+		defer conn.Close()
+		notification, err := receive(conn)
+		assert.NoError(t, err)
+		assert.Equal(t, GameStarted, notification.Type)
+	}
+}
+
+// dial opens a WebSocket connection to a path on a test server
+func dial(t *testing.T, ts *httptest.Server, path string) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+path, nil)
+	assert.NoError(t, err)
+	return conn
+}
+
+// receive reads the next notification from a connection, failing rather than hanging if none arrives
+func receive(conn *websocket.Conn) (Notification, error) {
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, reader, err := conn.NextReader()
+	if err != nil {
+		return Notification{}, err
+	}
+
+	return NewNotificationFromJSON(reader)
+}
+
+// drain reads notifications from a connection until the server closes it normally
+func drain(t *testing.T, conn *websocket.Conn) []Notification {
+	received := make([]Notification, 0)
+	for {
+		notification, err := receive(conn)
+		if err != nil {
+			assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "unexpected error: %v", err)
+			return received
+		}
+
+		received = append(received, notification)
+	}
+}
+
+// types returns the type of each notification
+func types(notifications []Notification) []NotificationType {
+	result := make([]NotificationType, 0, len(notifications))
+	for _, notification := range notifications {
+		result = append(result, notification.Type)
+	}
+
+	return result
+}
@@ -0,0 +1,347 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/pronovic/go-apologies/engine"
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/source"
+)
+
+// Remote is the seat configuration for a seat played by a client that joins over the network
+const Remote = "remote"
+
+// errNotYourTurn is returned when a client submits a move for a seat that is not up next
+var errNotYourTurn = errors.New("it is not your turn")
+
+// errNoSuchMove is returned when a client submits a move index that is not among the legal moves for its seat
+var errNoSuchMove = errors.New("no such move")
+
+// seat is one of the seats in a hosted game
+type seat struct {
+	color  model.PlayerColor
+	source string
+	remote *remoteInputSource // nil for a seat played by the server
+	token  string             // empty until a client joins
+}
+
+// request is a request from the game for a client to choose a move for a remote seat
+type request struct {
+	color      model.PlayerColor
+	legalMoves []model.Move
+	reply      chan model.Move
+}
+
+// maxFailures is the number of times in a row that a turn may fail before the game gives up
+const maxFailures = 3
+
+// hosted is a game hosted by the server, along with the request that is waiting for a client, if any.
+//
+// Once every remote seat has been joined, the game is played in the background by a runner goroutine, which is
+// the only thing that touches the engine.  The runner takes the lock just long enough to publish a copy of the
+// game after each turn, and handlers only ever read what was published, so a slow input source never holds up a
+// request.  When a turn needs a move from a client, the runner publishes the request and waits until the client
+// submits a move or the game is stopped.  A turn that fails is rolled back and played again, but a game whose
+// turns keep failing is given up on, and the failure is published as its state.
+type hosted struct {
+	lock     sync.Mutex
+	id       string
+	owner    string // the token that must be presented to delete the game
+	runtime  engine.Engine
+	seats    []*seat
+	game     model.Game // the copy of the game published by the runner
+	state    string
+	over     bool
+	pending  *request
+	feed     *feed
+	turn     []Notification     // the notifications held back until the turn being played is over
+	chosen   *Notification      // the Moved notification for a move that has been chosen but not yet executed
+	chosenAt int                // where the chosen move goes among the notifications held back for the turn
+	cancel   context.CancelFunc // nil until the runner is started
+	done     chan struct{}      // closed once the runner has returned
+}
+
+// newHosted constructs a game with a seat for each entry in seats, which is either Remote or the name of a registered source
+func newHosted(mode model.GameMode, seats []string, registry source.Registry, randomizer random.Randomizer) (*hosted, error) {
+	if len(seats) < model.MinPlayers || len(seats) > model.MaxPlayers {
+		return nil, fmt.Errorf("a game needs %d to %d seats", model.MinPlayers, model.MaxPlayers)
+	}
+
+	g := &hosted{
+		id:    newToken(),
+		owner: newToken(),
+		seats: make([]*seat, 0, len(seats)),
+		feed:  newFeed(),
+	}
+
+	characters := make([]engine.Character, 0, len(seats))
+	for i, configured := range seats {
+		s := &seat{color: model.PlayerColors.Members()[i], source: configured}
+
+		var input source.CharacterInputSource
+		if configured == Remote {
+			s.remote = &remoteInputSource{color: s.color, game: g}
+			input = s.remote
+		} else {
+			var err error
+			if input, err = registry.Lookup(configured, randomizer); err != nil {
+				return nil, err
+			}
+		}
+
+		g.seats = append(g.seats, s)
+		characters = append(characters, engine.NewCharacter(s.color.Value(), input))
+	}
+
+	runtime, err := engine.NewEngine(mode, characters, nil, randomizer)
+	if err != nil {
+		return nil, err
+	}
+
+	g.runtime = runtime
+	runtime.Subscribe(g.notify)
+	g.publish()
+	return g, nil
+}
+
+// seat returns the seat for a color, or nil if the game has no such seat
+func (g *hosted) seat(color model.PlayerColor) *seat {
+	for _, s := range g.seats {
+		if s.color == color {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// claimable checks whether a client may join a seat
+func (g *hosted) claimable(color model.PlayerColor) error {
+	s := g.seat(color)
+	if s == nil {
+		return fmt.Errorf("game has no %s seat", color.Value())
+	}
+
+	if s.remote == nil {
+		return fmt.Errorf("seat %s is played by %s", color.Value(), s.source)
+	}
+
+	if s.token != "" {
+		return fmt.Errorf("seat %s has already been joined", color.Value())
+	}
+
+	return nil
+}
+
+// join claims a remote seat for a client, returning the token that the client must present from now on.
+// Once the last remote seat is claimed, the game starts playing in the background.
+func (g *hosted) join(color model.PlayerColor) (string, error) {
+	if err := g.claimable(color); err != nil {
+		return "", err
+	}
+
+	s := g.seat(color)
+	s.token = newToken()
+	g.start()
+
+	return s.token, nil
+}
+
+// joined whether every remote seat has been joined by a client
+func (g *hosted) joined() bool {
+	for _, s := range g.seats {
+		if s.remote != nil && s.token == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// waiting returns the color of the seat that the game is waiting on, or nil if it is not waiting on a client
+func (g *hosted) waiting() *model.PlayerColor {
+	if g.pending == nil {
+		return nil
+	}
+
+	color := g.pending.color
+	return &color
+}
+
+// legalMoves returns the legal moves for a seat, which are empty unless the game is waiting on that seat
+func (g *hosted) legalMoves(color model.PlayerColor) []model.Move {
+	if waiting := g.waiting(); waiting == nil || *waiting != color {
+		return make([]model.Move, 0)
+	}
+
+	return g.pending.legalMoves
+}
+
+// submit hands a move chosen by a client to the runner, identified by its index among the legal moves for its seat.
+// The move is played in the background, so the game has not necessarily moved on by the time this returns.
+func (g *hosted) submit(color model.PlayerColor, index int) error {
+	legalMoves := g.legalMoves(color)
+	if len(legalMoves) == 0 {
+		return errNotYourTurn
+	}
+
+	if index < 0 || index >= len(legalMoves) {
+		return errNoSuchMove
+	}
+
+	g.pending.reply <- legalMoves[index]
+	g.pending = nil
+
+	return nil
+}
+
+// start starts the runner once every remote seat has been joined, unless it has already been started or the game was stopped
+func (g *hosted) start() {
+	if g.cancel != nil || g.over || !g.joined() {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g.cancel = cancel
+	g.done = make(chan struct{})
+	go g.run(ctx)
+}
+
+// stop stops the game, so the runner gives up on whatever it is waiting for rather than waiting forever
+func (g *hosted) stop() {
+	if g.cancel != nil {
+		g.cancel()
+	}
+
+	g.state = "Game deleted"
+	g.over = true
+	g.pending = nil
+	g.feed.wake()
+}
+
+// run plays the game until it is over or stopped, taking the lock only to publish the game after each turn
+func (g *hosted) run(ctx context.Context) {
+	defer close(g.done)
+
+	if _, err := g.runtime.StartGameContext(ctx); err != nil {
+		g.fail(ctx, err)
+		return
+	}
+
+	failures := 0
+	for !g.runtime.Completed() {
+		g.lock.Lock()
+		g.publish()
+		g.lock.Unlock()
+
+		_, err := g.runtime.PlayNextContext(ctx)
+
+		g.lock.Lock()
+		g.finish(err == nil)
+		g.lock.Unlock()
+
+		if err != nil {
+			// the failed turn was rolled back, so it can be played again
+			failures++
+			if failures < maxFailures && ctx.Err() == nil {
+				continue
+			}
+
+			g.fail(ctx, err)
+			return
+		}
+
+		failures = 0
+	}
+
+	g.lock.Lock()
+	g.publish()
+	g.lock.Unlock()
+}
+
+// publish copies the state of the engine for handlers to read, called while the game's lock is held
+func (g *hosted) publish() {
+	if g.over {
+		return
+	}
+
+	g.game = g.runtime.Game().Copy()
+	g.state = g.runtime.State()
+	g.over = g.runtime.Completed()
+	g.feed.wake()
+}
+
+// fail publishes the failure that ended the game, unless the game failed because it was stopped
+func (g *hosted) fail(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.publish()
+	g.state = "Game failed: " + err.Error()
+	g.over = true
+}
+
+// remoteInputSource source of input for a seat played by a client, which waits for the client to submit a move
+type remoteInputSource struct {
+	color model.PlayerColor
+	game  *hosted
+}
+
+func (s *remoteInputSource) Name() string {
+	return "RemoteInputSource"
+}
+
+func (s *remoteInputSource) ChooseMove(mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	return s.ChooseMoveContext(context.Background(), mode, view, legalMoves)
+}
+
+func (s *remoteInputSource) ChooseMoveContext(ctx context.Context, _ model.GameMode, _ model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	// buffered, so a client can submit a move without waiting for the game to pick it up
+	reply := make(chan model.Move, 1)
+	color := s.color
+
+	// the request is published along with the notification, so a client that is told it's their turn can always play
+	g := s.game
+	g.lock.Lock()
+	g.publish()
+	g.pending = &request{color, legalMoves, reply}
+	g.reveal(color) // the client can't play without knowing what has happened so far this turn
+	g.feed.add(Notification{Type: YourTurn, Color: &color, Description: "Your turn", audience: &color})
+	g.lock.Unlock()
+
+	select {
+	case move := <-reply:
+		return move, nil
+	case <-ctx.Done():
+		g.lock.Lock()
+		g.pending = nil
+		g.lock.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// moveId identifies a move by its content, so a client can't accidentally play a move from a turn that is over
+func moveId(move model.Move) string {
+	marshalled, _ := json.Marshal(move)
+	sum := sha256.Sum256(marshalled)
+	return hex.EncodeToString(sum[:8])
+}
+
+// newToken generates an identifier that can't be guessed, used for game ids and seat tokens
+func newToken() string {
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	return hex.EncodeToString(token)
+}
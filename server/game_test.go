@@ -0,0 +1,354 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewHosted(t *testing.T) {
+	registry := source.NewRegistry()
+
+	_, err := newHosted(model.StandardMode, []string{Remote}, registry, nil)
+	assert.EqualError(t, err, "a game needs 2 to 4 seats")
+
+	_, err = newHosted(model.StandardMode, []string{Remote, "Bogus"}, registry, nil)
+	assert.EqualError(t, err, "unknown source Bogus")
+
+	g, err := newHosted(model.StandardMode, []string{Remote, "RandomInputSource", Remote}, registry, random.NewSeededRandomizer(42))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(g.seats))
+	assert.Equal(t, model.Green, g.seats[2].color)
+	assert.NotNil(t, g.seats[0].remote)
+	assert.Nil(t, g.seats[1].remote)
+	assert.False(t, g.joined())
+	assert.False(t, g.game.Started())
+	assert.Equal(t, "Game waiting to start", g.state)
+	assert.Nil(t, g.waiting())
+	assert.Nil(t, g.done)
+	assert.NotEqual(t, g.id, g.owner)
+}
+
+func TestHostedJoinAndSubmit(t *testing.T) {
+	g, _ := newHosted(model.AdultMode, []string{Remote, Remote}, source.NewRegistry(), random.NewSeededRandomizer(42))
+
+	red, err := g.join(model.Red)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, red)
+	assert.Nil(t, g.done)
+
+	// once the last seat is joined, the game is played in the background
+	g.lock.Lock()
+	_, err = g.join(model.Yellow)
+	g.lock.Unlock()
+	assert.NoError(t, err)
+	settle(t, g)
+	assert.True(t, g.game.Started())
+	assert.Equal(t, "Game in progress", g.state)
+
+	// only the seat that the game is waiting on has legal moves
+	waiting := *g.waiting()
+	other := model.Red
+	if waiting == model.Red {
+		other = model.Yellow
+	}
+	assert.NotEmpty(t, g.legalMoves(waiting))
+	assert.Empty(t, g.legalMoves(other))
+	assert.ErrorIs(t, g.submit(other, 0), errNotYourTurn)
+
+	// a submitted move is played, and the game moves on to the other seat unless the card draws again
+	turn := g.game.Turn()
+	g.lock.Lock()
+	assert.NoError(t, g.submit(waiting, 0))
+	assert.Nil(t, g.waiting())
+	g.lock.Unlock()
+	settle(t, g)
+	assert.Equal(t, turn+1, g.game.Turn())
+	assert.NotNil(t, g.waiting())
+
+	stop(t, g)
+}
+
+func TestHostedSubmitNoSuchMove(t *testing.T) {
+	g, _ := newHosted(model.AdultMode, []string{Remote, Remote}, source.NewRegistry(), random.NewSeededRandomizer(42))
+	_, _ = g.join(model.Red)
+	g.lock.Lock()
+	_, _ = g.join(model.Yellow)
+	g.lock.Unlock()
+	settle(t, g)
+
+	waiting := *g.waiting()
+	legalMoves := g.legalMoves(waiting)
+	assert.ErrorIs(t, g.submit(waiting, len(legalMoves)), errNoSuchMove)
+	assert.ErrorIs(t, g.submit(waiting, -1), errNoSuchMove)
+	assert.Equal(t, legalMoves, g.legalMoves(waiting))
+
+	stop(t, g)
+}
+
+func TestHostedTurnFails(t *testing.T) {
+	broken := &source.MockCharacterInputSource{}
+	broken.On("Name").Return("Broken")
+	broken.On("ChooseMove", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("broken"))
+
+	registry := source.NewRegistry()
+	_ = registry.Register("Broken", func(_ random.Randomizer) source.CharacterInputSource { return broken })
+
+	g, _ := newHosted(model.StandardMode, []string{Remote, "Broken"}, registry, random.NewSeededRandomizer(42))
+	assert.NoError(t, g.runtime.SetFirst(model.Yellow))
+
+	g.lock.Lock()
+	_, err := g.join(model.Red)
+	g.lock.Unlock()
+	assert.NoError(t, err)
+
+	// the failed turn is rolled back and played again, until the game gives up and publishes the failure
+	<-g.done
+	broken.AssertNumberOfCalls(t, "ChooseMove", maxFailures)
+	assert.Equal(t, "Game failed: broken", g.state)
+	assert.True(t, g.over)
+	assert.Nil(t, g.waiting())
+	assert.NoError(t, model.ValidateGame(g.game))
+	assert.Equal(t, 45, g.game.Deck().DrawPileSize())
+
+	// none of the turns that were rolled back were reported
+	found, _ := g.feed.since(model.Red, 0)
+	assert.Equal(t, []NotificationType{GameStarted}, types(found))
+}
+
+func TestHostedTurnFailsOnce(t *testing.T) {
+	flaky := &source.MockCharacterInputSource{}
+	flaky.On("Name").Return("Flaky")
+	flaky.On("ChooseMove", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("broken")).Once()
+	flaky.On("ChooseMove", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ model.GameMode, _ model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+			return legalMoves[0], nil
+		},
+	)
+
+	registry := source.NewRegistry()
+	_ = registry.Register("Flaky", func(_ random.Randomizer) source.CharacterInputSource { return flaky })
+
+	g, _ := newHosted(model.StandardMode, []string{Remote, "Flaky"}, registry, random.NewSeededRandomizer(42))
+	assert.NoError(t, g.runtime.SetFirst(model.Red))
+	g.lock.Lock()
+	_, _ = g.join(model.Red)
+	g.lock.Unlock()
+
+	// the failed turn is rolled back and played again, so the game carries on
+	for i := 0; i < 10; i++ {
+		settle(t, g)
+		g.lock.Lock()
+		_ = g.submit(model.Red, 0)
+		g.lock.Unlock()
+	}
+	settle(t, g)
+	assert.Equal(t, "Game in progress", g.state)
+	assert.Equal(t, model.Red, *g.waiting())
+
+	// the only card missing from the deck is the one drawn for the turn that is waiting
+	deck := g.game.Deck()
+	assert.Equal(t, 44, deck.DrawPileSize()+deck.DiscardPileSize())
+
+	// the card drawn for the turn that was rolled back was never reported, so every card drawn was played
+	drawn, moved := 0, 0
+	found, _ := g.feed.since(model.Red, 0)
+	for _, notification := range found {
+		if notification.Color != nil && *notification.Color == model.Yellow {
+			switch notification.Type {
+			case CardDrawn:
+				drawn++
+			case Moved:
+				moved++
+			}
+		}
+	}
+	assert.NotZero(t, moved)
+	assert.Equal(t, drawn, moved)
+
+	stop(t, g)
+}
+
+func TestHostedStop(t *testing.T) {
+	g, _ := newHosted(model.StandardMode, []string{Remote, Remote}, source.NewRegistry(), random.NewSeededRandomizer(42))
+	_, _ = g.join(model.Red)
+	g.lock.Lock()
+	_, _ = g.join(model.Yellow)
+	g.lock.Unlock()
+	settle(t, g)
+	turn := g.game.Turn()
+
+	// a game waiting on a client gives up on the client, rather than leaving the runner waiting forever
+	stop(t, g)
+	assert.Equal(t, "Game deleted", g.state)
+	assert.True(t, g.over)
+	assert.Nil(t, g.waiting())
+	assert.Equal(t, turn, g.game.Turn())
+
+	// a game that is stopped before every seat is joined never starts
+	g, _ = newHosted(model.StandardMode, []string{Remote, Remote}, source.NewRegistry(), random.NewSeededRandomizer(42))
+	g.stop()
+	_, _ = g.join(model.Red)
+	_, _ = g.join(model.Yellow)
+	assert.Nil(t, g.done)
+	assert.Equal(t, "Game deleted", g.state)
+}
+
+func TestHostedStopDrawAgain(t *testing.T) {
+	g, _ := newHosted(model.StandardMode, []string{Remote, Remote}, source.NewRegistry(), random.NewSeededRandomizer(42))
+	_, _ = g.join(model.Red)
+	g.lock.Lock()
+	_, _ = g.join(model.Yellow)
+	g.lock.Unlock()
+
+	// play until a seat is asked to play again in the same turn, after drawing a card that draws again
+	var waiting model.PlayerColor
+	for turns := 0; ; turns++ {
+		settle(t, g)
+		g.lock.Lock()
+		waiting = *g.waiting()
+		again := slices.Contains(types(g.turn), Moved)
+		if !again {
+			_ = g.submit(waiting, 0)
+		}
+		g.lock.Unlock()
+
+		if again {
+			break
+		}
+
+		if turns > 200 {
+			assert.Fail(t, "no seat ever drew again")
+			stop(t, g)
+			return
+		}
+	}
+
+	other := model.Red
+	if waiting == model.Red {
+		other = model.Yellow
+	}
+
+	// the seat that is playing was sent its own copy of the turn so far, but the other seat has heard nothing
+	g.lock.Lock()
+	mine, _ := g.feed.since(waiting, 0)
+	theirs, _ := g.feed.since(other, 0)
+	g.lock.Unlock()
+	assert.Equal(t, []NotificationType{CardDrawn, YourTurn, Moved, CardDrawn, YourTurn}, types(mine[len(mine)-5:]))
+	for _, notification := range mine[len(mine)-5:] {
+		assert.Equal(t, waiting, *notification.audience)
+	}
+
+	// once the game is deleted, the turn is rolled back, so the other seat never hears about it
+	stop(t, g)
+	found, _ := g.feed.since(other, 0)
+	assert.Equal(t, theirs, found)
+	drawn, moved := 0, 0
+	for _, notification := range found {
+		if notification.Color != nil && *notification.Color == waiting {
+			switch notification.Type {
+			case CardDrawn:
+				drawn++
+			case Moved:
+				moved++
+			}
+		}
+	}
+	assert.Equal(t, drawn, moved)
+}
+
+func TestRemoteInputSource(t *testing.T) {
+	g, _ := newHosted(model.StandardMode, []string{Remote, Remote}, source.NewRegistry(), random.NewSeededRandomizer(42))
+	obj := g.seats[0].remote
+	assert.Equal(t, "RemoteInputSource", obj.Name())
+
+	// the source waits for the move submitted for its request
+	legalMoves := []model.Move{model.NewMove(model.NewCard("1", model.Card1), nil, nil), model.NewMove(model.NewCard("2", model.Card2), nil, nil)}
+	go func() {
+		settle(t, g)
+		g.lock.Lock()
+		defer g.lock.Unlock()
+		assert.Equal(t, model.Red, *g.waiting())
+		assert.NoError(t, g.submit(model.Red, 1))
+	}()
+
+	result, err := obj.ChooseMove(model.StandardMode, nil, legalMoves)
+	assert.NoError(t, err)
+	assert.Same(t, legalMoves[1], result)
+
+	// the client was told that it's their turn, and nobody else was
+	found, _ := g.feed.since(model.Red, 0)
+	assert.Equal(t, []NotificationType{YourTurn}, types(found))
+	found, _ = g.feed.since(model.Yellow, 0)
+	assert.Empty(t, found)
+
+	// the source gives up once its context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		settle(t, g)
+		cancel()
+	}()
+
+	_, err = obj.ChooseMoveContext(ctx, model.StandardMode, nil, legalMoves)
+	assert.ErrorIs(t, err, context.Canceled)
+	g.lock.Lock()
+	assert.Nil(t, g.waiting())
+	g.lock.Unlock()
+}
+
+func TestMoveId(t *testing.T) {
+	move1 := model.NewMove(model.NewCard("1", model.Card1), nil, nil)
+	move2 := model.NewMove(model.NewCard("1", model.Card1), nil, nil)
+	move3 := model.NewMove(model.NewCard("2", model.Card2), nil, nil)
+	assert.Equal(t, 16, len(moveId(move1)))
+	assert.Equal(t, moveId(move1), moveId(move2))
+	assert.NotEqual(t, moveId(move1), moveId(move3))
+}
+
+func TestNewToken(t *testing.T) {
+	token := newToken()
+	assert.Equal(t, 32, len(token))
+	assert.NotEqual(t, token, newToken())
+}
+
+// settle waits until a game is waiting on a client or is over, failing rather than hanging if neither happens
+func settle(t *testing.T, g *hosted) {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		g.lock.Lock()
+		settled := g.pending != nil || g.over
+		g.lock.Unlock()
+
+		if settled {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			assert.Fail(t, "game never settled")
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// stop stops a game and waits for its runner to return
+func stop(t *testing.T, g *hosted) {
+	g.lock.Lock()
+	g.stop()
+	g.lock.Unlock()
+
+	select {
+	case <-g.done:
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "runner never returned")
+	}
+}
@@ -0,0 +1,555 @@
+// Package server hosts games over HTTP, using JSON for requests and responses.  Each game has a
+// seat for every player.  A seat is either played by the server using a registered input source, or
+// is left open for a client to join.  Once every open seat has been joined, the game is played in the
+// background, so requests never wait on the server's own seats; a client learns that it is its turn
+// from the events stream or by polling.  The model types are returned in their usual JSON encoding, and
+// every client sees the game through the player view for its own seat, which hides the opponents' hands.
+//
+// The API looks like this, where a seat is identified by its color in lowercase:
+//
+//	POST   /games                               create a game: {"mode": "AdultMode", "seats": ["remote", "RewardInputSource"]}
+//	GET    /games                               list all games
+//	GET    /games/{id}                          get the public state of a game
+//	DELETE /games/{id}                          stop and delete a game, given the token returned when it was created
+//	POST   /games/{id}/seats/{color}            join a remote seat, returning the seat's token
+//	GET    /games/{id}/seats/{color}/view       get the player view for a seat
+//	GET    /games/{id}/seats/{color}/moves      list the legal moves for a seat, which is empty unless it's the seat's turn
+//	POST   /games/{id}/seats/{color}/moves      play a move by index or id: {"index": 2} or {"id": "9f86d081884c7d65"}
+//	GET    /games/{id}/seats/{color}/events     stream notifications for a seat over a WebSocket, optionally ?after={seq}
+//
+// Requests for a seat must present the seat's token as "Authorization: Bearer <token>", and deleting a game
+// must present the game's token the same way.  Since a browser can't set headers on a WebSocket, a token may
+// also be passed as a query parameter, like ?token=<token>.
+// Errors are returned with an appropriate status code and a body like {"error": "it is not your turn"}.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pronovic/go-apologies/internal/jsonutil"
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/source"
+)
+
+// Server Hosts many concurrent games over HTTP.
+type Server interface {
+	http.Handler
+}
+
+type server struct {
+	registry source.Registry
+	lock     sync.RWMutex
+	games    map[string]*hosted
+	order    []string
+}
+
+// NewServer constructs a new Server, optionally accepting the registry used to look up the sources for server-played seats
+func NewServer(registry source.Registry) Server {
+	if registry == nil {
+		registry = source.NewRegistry()
+	}
+
+	return &server{
+		registry: registry,
+		games:    make(map[string]*hosted),
+		order:    make([]string, 0),
+	}
+}
+
+// CreateRequest The request to create a game
+type CreateRequest struct {
+	// Mode The game mode (default StandardMode)
+	Mode model.GameMode `json:"mode"`
+
+	// Seats The configuration for each seat: either Remote, or the name of a registered input source
+	Seats []string `json:"seats"`
+
+	// Seed The seed for the game's randomizer, so that a game can be reproduced (optional)
+	Seed *int64 `json:"seed"`
+}
+
+// SeatSummary The public state of a seat
+type SeatSummary struct {
+	Color  model.PlayerColor `json:"color"`
+	Source string            `json:"source"`
+	Joined bool              `json:"joined"`
+}
+
+// GameSummary The public state of a game, which is visible to anyone
+type GameSummary struct {
+	Id      string             `json:"id"`
+	Mode    model.GameMode     `json:"mode"`
+	State   string             `json:"state"`
+	Waiting *model.PlayerColor `json:"waiting"`
+	Winner  *model.PlayerColor `json:"winner"`
+	Seats   []SeatSummary      `json:"seats"`
+}
+
+// CreateResponse The response to creating a game, which is the public state of the game along with its token
+type CreateResponse struct {
+	GameSummary
+
+	// Token The token that must be presented to delete the game
+	Token string `json:"token"`
+}
+
+// JoinResponse The response to joining a seat
+type JoinResponse struct {
+	Color model.PlayerColor `json:"color"`
+	Token string            `json:"token"`
+}
+
+// LegalMove A legal move for a seat, identified by both its index and its id
+type LegalMove struct {
+	Index       int        `json:"index"`
+	Id          string     `json:"id"`
+	Description string     `json:"description"`
+	Move        model.Move `json:"move"`
+}
+
+// NewLegalMoveFromJSON constructs a new legal move from JSON in an io.Reader
+func NewLegalMoveFromJSON(reader io.Reader) (LegalMove, error) {
+	type raw struct {
+		Index       int             `json:"index"`
+		Id          string          `json:"id"`
+		Description string          `json:"description"`
+		Move        json.RawMessage `json:"move"`
+	}
+
+	var temp raw
+	err := json.NewDecoder(reader).Decode(&temp)
+	if err != nil {
+		return LegalMove{}, err
+	}
+
+	move, err := jsonutil.DecodeInterfaceJSON(temp.Move, model.NewMoveFromJSON)
+	if err != nil {
+		return LegalMove{}, err
+	}
+
+	return LegalMove{Index: temp.Index, Id: temp.Id, Description: temp.Description, Move: move}, nil
+}
+
+// MovesResponse The legal moves for a seat
+type MovesResponse struct {
+	Moves []LegalMove `json:"moves"`
+}
+
+// NewMovesResponseFromJSON constructs a new moves response from JSON in an io.Reader
+func NewMovesResponseFromJSON(reader io.Reader) (MovesResponse, error) {
+	type raw struct {
+		Moves []json.RawMessage `json:"moves"`
+	}
+
+	var temp raw
+	err := json.NewDecoder(reader).Decode(&temp)
+	if err != nil {
+		return MovesResponse{}, err
+	}
+
+	moves, err := jsonutil.DecodeSliceJSON(temp.Moves, NewLegalMoveFromJSON)
+	if err != nil {
+		return MovesResponse{}, err
+	}
+
+	return MovesResponse{Moves: moves}, nil
+}
+
+// MoveRequest The request to play a move, identified by either its index or its id
+type MoveRequest struct {
+	Index *int   `json:"index"`
+	Id    string `json:"id"`
+}
+
+// statusError is an error that maps to a particular HTTP status
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+// withStatus wraps an error with the HTTP status it should be reported as
+func withStatus(status int, err error) error {
+	return &statusError{status, err}
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	var result any
+	var err error
+
+	switch {
+	case len(path) == 5 && path[0] == "games" && path[2] == "seats" && path[4] == "events":
+		// a WebSocket writes its own responses, so only an error before the upgrade needs to be handled here
+		if r.Method != http.MethodGet {
+			err = notAllowed(w, http.MethodGet)
+		} else if err = s.events(w, r, path[1], path[3]); err == nil {
+			return
+		}
+	case len(path) == 1 && path[0] == "games":
+		switch r.Method {
+		case http.MethodGet:
+			result, err = s.list()
+		case http.MethodPost:
+			result, err = s.create(r)
+		default:
+			err = notAllowed(w, http.MethodGet, http.MethodPost)
+		}
+	case len(path) == 2 && path[0] == "games":
+		switch r.Method {
+		case http.MethodGet:
+			result, err = s.withGame(path[1], func(g *hosted) (any, error) {
+				return summarize(g), nil
+			})
+		case http.MethodDelete:
+			result, err = s.delete(r, path[1])
+		default:
+			err = notAllowed(w, http.MethodGet, http.MethodDelete)
+		}
+	case len(path) == 4 && path[0] == "games" && path[2] == "seats":
+		if r.Method != http.MethodPost {
+			err = notAllowed(w, http.MethodPost)
+		} else {
+			result, err = s.join(path[1], path[3])
+		}
+	case len(path) == 5 && path[0] == "games" && path[2] == "seats" && path[4] == "view":
+		if r.Method != http.MethodGet {
+			err = notAllowed(w, http.MethodGet)
+		} else {
+			result, err = s.withSeat(r, path[1], path[3], view)
+		}
+	case len(path) == 5 && path[0] == "games" && path[2] == "seats" && path[4] == "moves":
+		switch r.Method {
+		case http.MethodGet:
+			result, err = s.withSeat(r, path[1], path[3], moves)
+		case http.MethodPost:
+			var request MoveRequest
+			if err = decode(r, &request); err == nil {
+				result, err = s.withSeat(r, path[1], path[3], func(g *hosted, color model.PlayerColor) (any, error) {
+					return play(g, color, request)
+				})
+			}
+		default:
+			err = notAllowed(w, http.MethodGet, http.MethodPost)
+		}
+	default:
+		err = withStatus(http.StatusNotFound, fmt.Errorf("no such resource: %s", r.URL.Path))
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if r.Method == http.MethodPost && len(path) == 1 {
+		status = http.StatusCreated
+	}
+
+	writeJSON(w, status, result)
+}
+
+func (s *server) list() (any, error) {
+	s.lock.RLock()
+	games := make([]*hosted, 0, len(s.order))
+	for _, id := range s.order {
+		games = append(games, s.games[id])
+	}
+	s.lock.RUnlock()
+
+	summaries := make([]GameSummary, 0, len(games))
+	for _, g := range games {
+		g.lock.Lock()
+		summaries = append(summaries, summarize(g))
+		g.lock.Unlock()
+	}
+
+	return summaries, nil
+}
+
+func (s *server) create(r *http.Request) (any, error) {
+	var request CreateRequest
+	if err := decode(r, &request); err != nil {
+		return nil, err
+	}
+
+	if request.Mode == (model.GameMode{}) {
+		request.Mode = model.StandardMode
+	}
+
+	randomizer := random.NewRandomizer()
+	if request.Seed != nil {
+		randomizer = random.NewSeededRandomizer(*request.Seed)
+	}
+
+	g, err := newHosted(request.Mode, request.Seats, s.registry, randomizer)
+	if err != nil {
+		return nil, withStatus(http.StatusBadRequest, err)
+	}
+
+	s.lock.Lock()
+	s.games[g.id] = g
+	s.order = append(s.order, g.id)
+	s.lock.Unlock()
+
+	// a game without remote seats starts playing right away
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.start()
+
+	return CreateResponse{GameSummary: summarize(g), Token: g.owner}, nil
+}
+
+func (s *server) delete(r *http.Request, id string) (any, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	g, exists := s.games[id]
+	if !exists {
+		return nil, withStatus(http.StatusNotFound, fmt.Errorf("no such game: %s", id))
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if bearer(r) != g.owner {
+		return nil, withStatus(http.StatusUnauthorized, errors.New("a valid token for the game is required"))
+	}
+
+	delete(s.games, id)
+	s.order = slices.DeleteFunc(s.order, func(other string) bool { return other == id })
+	g.stop()
+
+	return summarize(g), nil
+}
+
+func (s *server) join(id string, name string) (any, error) {
+	return s.withGame(id, func(g *hosted) (any, error) {
+		color, err := parseColor(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if g.seat(color) == nil {
+			return nil, withStatus(http.StatusNotFound, fmt.Errorf("game has no %s seat", color.Value()))
+		}
+
+		if err = g.claimable(color); err != nil {
+			return nil, withStatus(http.StatusConflict, err)
+		}
+
+		token, err := g.join(color)
+		if err != nil {
+			return nil, err
+		}
+
+		return JoinResponse{Color: color, Token: token}, nil
+	})
+}
+
+// withGame looks up a game and calls a function while holding the game's lock
+func (s *server) withGame(id string, f func(g *hosted) (any, error)) (any, error) {
+	s.lock.RLock()
+	g, exists := s.games[id]
+	s.lock.RUnlock()
+
+	if !exists {
+		return nil, withStatus(http.StatusNotFound, fmt.Errorf("no such game: %s", id))
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return f(g)
+}
+
+// withSeat looks up a game and calls a function for a seat, once the request has been authorized for the seat
+func (s *server) withSeat(r *http.Request, id string, name string, f func(g *hosted, color model.PlayerColor) (any, error)) (any, error) {
+	return s.withGame(id, func(g *hosted) (any, error) {
+		color, err := parseColor(name)
+		if err != nil {
+			return nil, err
+		}
+
+		seat := g.seat(color)
+		if seat == nil {
+			return nil, withStatus(http.StatusNotFound, fmt.Errorf("game has no %s seat", color.Value()))
+		}
+
+		if token := bearer(r); seat.token == "" || token != seat.token {
+			return nil, withStatus(http.StatusUnauthorized, errors.New("a valid token for the seat is required"))
+		}
+
+		return f(g, color)
+	})
+}
+
+func view(g *hosted, color model.PlayerColor) (any, error) {
+	return g.game.CreatePlayerView(color)
+}
+
+func moves(g *hosted, color model.PlayerColor) (any, error) {
+	legalMoves, err := describe(g, color)
+	if err != nil {
+		return nil, err
+	}
+
+	return MovesResponse{Moves: legalMoves}, nil
+}
+
+func play(g *hosted, color model.PlayerColor, request MoveRequest) (any, error) {
+	legalMoves, err := describe(g, color)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(legalMoves) == 0 {
+		return nil, withStatus(http.StatusConflict, errNotYourTurn)
+	}
+
+	index := -1
+	if request.Index != nil {
+		index = *request.Index
+		if index < 0 || index >= len(legalMoves) {
+			return nil, withStatus(http.StatusBadRequest, fmt.Errorf("move index must be from 0 to %d", len(legalMoves)-1))
+		}
+	} else if request.Id != "" {
+		for _, legal := range legalMoves {
+			if legal.Id == request.Id {
+				index = legal.Index
+			}
+		}
+
+		if index < 0 {
+			return nil, withStatus(http.StatusBadRequest, fmt.Errorf("no legal move has id %s", request.Id))
+		}
+	} else {
+		return nil, withStatus(http.StatusBadRequest, errors.New("either a move index or a move id is required"))
+	}
+
+	if err = g.submit(color, index); err != nil {
+		switch {
+		case errors.Is(err, errNotYourTurn):
+			return nil, withStatus(http.StatusConflict, err)
+		case errors.Is(err, errNoSuchMove):
+			return nil, withStatus(http.StatusBadRequest, err)
+		}
+		return nil, err
+	}
+
+	return summarize(g), nil
+}
+
+// describe lists the legal moves for a seat in the form returned to clients
+func describe(g *hosted, color model.PlayerColor) ([]LegalMove, error) {
+	legalMoves := g.legalMoves(color)
+	described := make([]LegalMove, 0, len(legalMoves))
+	if len(legalMoves) == 0 {
+		return described, nil
+	}
+
+	playerView, err := g.game.CreatePlayerView(color)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, move := range legalMoves {
+		described = append(described, LegalMove{
+			Index:       i,
+			Id:          moveId(move),
+			Description: source.DescribeMove(playerView, move),
+			Move:        move,
+		})
+	}
+
+	return described, nil
+}
+
+// summarize describes the public state of a game
+func summarize(g *hosted) GameSummary {
+	seats := make([]SeatSummary, 0, len(g.seats))
+	for _, s := range g.seats {
+		seats = append(seats, SeatSummary{Color: s.color, Source: s.source, Joined: s.remote == nil || s.token != ""})
+	}
+
+	var winner *model.PlayerColor
+	if w := g.game.Winner(); w != nil {
+		color := (*w).Color()
+		winner = &color
+	}
+
+	return GameSummary{
+		Id:      g.id,
+		Mode:    g.runtime.Mode(),
+		State:   g.state,
+		Waiting: g.waiting(),
+		Winner:  winner,
+		Seats:   seats,
+	}
+}
+
+// bearer returns the token presented with a request, either as a bearer token or as a query parameter
+func bearer(r *http.Request) string {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found {
+		token = r.URL.Query().Get("token")
+	}
+
+	return token
+}
+
+// parseColor parses a seat color from a path, ignoring case
+func parseColor(name string) (model.PlayerColor, error) {
+	for _, color := range model.PlayerColors.Members() {
+		if strings.EqualFold(color.Value(), name) {
+			return color, nil
+		}
+	}
+
+	return model.PlayerColor{}, withStatus(http.StatusNotFound, fmt.Errorf("unknown color %q", name))
+}
+
+// decode decodes a JSON request body, rejecting unknown fields so that mistakes are caught early
+func decode(r *http.Request, v any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return withStatus(http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+	}
+
+	return nil
+}
+
+// notAllowed sets the Allow header and returns an error for a request with an unsupported method
+func notAllowed(w http.ResponseWriter, methods ...string) error {
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	return withStatus(http.StatusMethodNotAllowed, errors.New("method not allowed"))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var failure *statusError
+	if errors.As(err, &failure) {
+		status = failure.status
+	}
+
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
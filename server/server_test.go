@@ -0,0 +1,283 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestServerCreateServerPlayed(t *testing.T) {
+	ts := httptest.NewServer(NewServer(nil))
+	defer ts.Close()
+
+	// a game without remote seats is played to completion in the background as soon as it is created
+	var created CreateResponse
+	status := call(t, ts, http.MethodPost, "/games", "", `{"mode": "AdultMode", "seats": ["RandomInputSource", "RewardInputSource"], "seed": 42}`, &created)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.NotEmpty(t, created.Id)
+	assert.NotEmpty(t, created.Token)
+	assert.Equal(t, model.AdultMode, created.Mode)
+
+	summary := await(t, ts, created.Id, settled)
+	assert.Equal(t, "Game completed", summary.State)
+	assert.Nil(t, summary.Waiting)
+	assert.NotNil(t, summary.Winner)
+	assert.Equal(t, []SeatSummary{
+		{Color: model.Red, Source: "RandomInputSource", Joined: true},
+		{Color: model.Yellow, Source: "RewardInputSource", Joined: true},
+	}, summary.Seats)
+
+	var fetched GameSummary
+	status = call(t, ts, http.MethodGet, "/games/"+summary.Id, "", "", &fetched)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, summary, fetched)
+
+	var listed []GameSummary
+	status = call(t, ts, http.MethodGet, "/games", "", "", &listed)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []GameSummary{summary}, listed)
+}
+
+func TestServerCreateReturnsWhilePlaying(t *testing.T) {
+	cancelled := make(chan struct{}, 2)
+	stalled := &source.MockContextInputSource{}
+	stalled.On("Name").Return("Stalled").Maybe()
+	stalled.On("ChooseMoveContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, _ model.GameMode, _ model.PlayerView, _ []model.Move) (model.Move, error) {
+			<-ctx.Done()
+			cancelled <- struct{}{}
+			return nil, ctx.Err()
+		},
+	)
+
+	registry := source.NewRegistry()
+	_ = registry.Register("Stalled", func(_ random.Randomizer) source.CharacterInputSource { return stalled })
+
+	ts := httptest.NewServer(NewServer(registry))
+	defer ts.Close()
+
+	// the response doesn't wait on the server's own seats, however long they take
+	var created CreateResponse
+	status := call(t, ts, http.MethodPost, "/games", "", `{"seats": ["Stalled", "Stalled"]}`, &created)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.NotEqual(t, "Game completed", created.State)
+	assert.Nil(t, created.Winner)
+
+	// only the token returned when the game was created may delete it
+	var failure map[string]string
+	status = call(t, ts, http.MethodDelete, "/games/"+created.Id, "bogus", "", &failure)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "a valid token for the game is required", failure["error"])
+
+	// deleting the game stops the source that it was waiting on
+	var deleted GameSummary
+	status = call(t, ts, http.MethodDelete, "/games/"+created.Id, created.Token, "", &deleted)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Game deleted", deleted.State)
+
+	select {
+	case <-cancelled:
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "source was never cancelled")
+	}
+
+	var listed []GameSummary
+	call(t, ts, http.MethodGet, "/games", "", "", &listed)
+	assert.Empty(t, listed)
+
+	status = call(t, ts, http.MethodGet, "/games/"+created.Id, "", "", &failure)
+	assert.Equal(t, http.StatusNotFound, status)
+	status = call(t, ts, http.MethodDelete, "/games/"+created.Id, created.Token, "", &failure)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestServerPlayRemote(t *testing.T) {
+	ts := httptest.NewServer(NewServer(nil))
+	defer ts.Close()
+
+	// the game waits to start until every remote seat has been joined
+	var summary GameSummary
+	call(t, ts, http.MethodPost, "/games", "", `{"mode": "AdultMode", "seats": ["RewardInputSource", "remote"], "seed": 7}`, &summary)
+	assert.Equal(t, "Game waiting to start", summary.State)
+	assert.False(t, summary.Seats[1].Joined)
+
+	var joined JoinResponse
+	status := call(t, ts, http.MethodPost, "/games/"+summary.Id+"/seats/yellow", "", "", &joined)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, model.Yellow, joined.Color)
+	assert.NotEmpty(t, joined.Token)
+
+	summary = await(t, ts, summary.Id, settled)
+	assert.Equal(t, "Game in progress", summary.State)
+	assert.Equal(t, model.Yellow, *summary.Waiting)
+
+	// the view for the seat shows the seat's own hand, but not the opponent's
+	var raw json.RawMessage
+	status = call(t, ts, http.MethodGet, "/games/"+summary.Id+"/seats/yellow/view", joined.Token, "", &raw)
+	assert.Equal(t, http.StatusOK, status)
+	view, err := model.NewPlayerViewFromJSON(bytes.NewReader(raw))
+	assert.NoError(t, err)
+	assert.Equal(t, model.Yellow, view.Player().Color())
+	assert.Equal(t, 5, len(view.Player().Hand()))
+	assert.Equal(t, 0, len(view.Opponents()[model.Red].Hand()))
+
+	// play alternately by id and by index until the game is over
+	for turns := 0; summary.Winner == nil; turns++ {
+		status = call(t, ts, http.MethodGet, "/games/"+summary.Id+"/seats/yellow/moves", joined.Token, "", &raw)
+		assert.Equal(t, http.StatusOK, status)
+		moves, err := NewMovesResponseFromJSON(bytes.NewReader(raw))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, moves.Moves)
+		for i, legal := range moves.Moves {
+			assert.Equal(t, i, legal.Index)
+			assert.Equal(t, moveId(legal.Move), legal.Id)
+			assert.True(t, strings.HasPrefix(legal.Description, "Card "))
+		}
+
+		request := `{"index": 0}`
+		if turns%2 == 0 {
+			request = `{"id": "` + moves.Moves[len(moves.Moves)-1].Id + `"}`
+		}
+
+		status = call(t, ts, http.MethodPost, "/games/"+summary.Id+"/seats/yellow/moves", joined.Token, request, &summary)
+		assert.Equal(t, http.StatusOK, status)
+		if status != http.StatusOK {
+			break
+		}
+
+		// the move is played in the background, so the response is not waiting on anyone yet
+		assert.Nil(t, summary.Waiting)
+		summary = await(t, ts, summary.Id, settled)
+	}
+
+	assert.Equal(t, "Game completed", summary.State)
+	assert.Nil(t, summary.Waiting)
+
+	// there is nothing left to play
+	call(t, ts, http.MethodGet, "/games/"+summary.Id+"/seats/yellow/moves", joined.Token, "", &raw)
+	moves, err := NewMovesResponseFromJSON(bytes.NewReader(raw))
+	assert.NoError(t, err)
+	assert.Empty(t, moves.Moves)
+}
+
+func TestServerErrors(t *testing.T) {
+	ts := httptest.NewServer(NewServer(nil))
+	defer ts.Close()
+
+	var summary GameSummary
+	call(t, ts, http.MethodPost, "/games", "", `{"seats": ["remote", "remote", "RandomInputSource"]}`, &summary)
+	assert.Equal(t, model.StandardMode, summary.Mode)
+	games := "/games/" + summary.Id
+
+	var red, yellow JoinResponse
+	call(t, ts, http.MethodPost, games+"/seats/RED", "", "", &red)
+	call(t, ts, http.MethodPost, games+"/seats/yellow", "", "", &yellow)
+	summary = await(t, ts, summary.Id, settled)
+	waiting, other := red, yellow
+	if *summary.Waiting == model.Yellow {
+		waiting, other = yellow, red
+	}
+	seat := func(join JoinResponse, suffix string) string {
+		return games + "/seats/" + strings.ToLower(join.Color.Value()) + suffix
+	}
+
+	for _, c := range []struct {
+		method  string
+		path    string
+		token   string
+		body    string
+		status  int
+		message string
+	}{
+		{http.MethodPost, "/games", "", `{"seats": ["remote"]}`, http.StatusBadRequest, "a game needs 2 to 4 seats"},
+		{http.MethodPost, "/games", "", `{"seats": ["remote", "Unknown"]}`, http.StatusBadRequest, "unknown source Unknown"},
+		{http.MethodPost, "/games", "", `{"seats": ["remote", "remote"], "players": 2}`, http.StatusBadRequest, `invalid request: json: unknown field "players"`},
+		{http.MethodPost, "/games", "", `{"mode": "Bogus", "seats": ["remote", "remote"]}`, http.StatusBadRequest, "invalid request"},
+		{http.MethodDelete, "/games", "", "", http.StatusMethodNotAllowed, "method not allowed"},
+		{http.MethodGet, "/players", "", "", http.StatusNotFound, "no such resource: /players"},
+		{http.MethodGet, "/games/bogus", "", "", http.StatusNotFound, "no such game: bogus"},
+		{http.MethodPut, games, "", "", http.StatusMethodNotAllowed, "method not allowed"},
+		{http.MethodDelete, games, "", "", http.StatusUnauthorized, "a valid token for the game is required"},
+		{http.MethodDelete, games, red.Token, "", http.StatusUnauthorized, "a valid token for the game is required"},
+		{http.MethodDelete, "/games/bogus", "", "", http.StatusNotFound, "no such game: bogus"},
+		{http.MethodPost, games + "/seats/purple", "", "", http.StatusNotFound, `unknown color "purple"`},
+		{http.MethodPost, games + "/seats/blue", "", "", http.StatusNotFound, "game has no Blue seat"},
+		{http.MethodPost, games + "/seats/green", "", "", http.StatusConflict, "seat Green is played by RandomInputSource"},
+		{http.MethodPost, games + "/seats/red", "", "", http.StatusConflict, "seat Red has already been joined"},
+		{http.MethodGet, games + "/seats/red/view", "", "", http.StatusUnauthorized, "a valid token for the seat is required"},
+		{http.MethodGet, games + "/seats/red/view", yellow.Token, "", http.StatusUnauthorized, "a valid token for the seat is required"},
+		{http.MethodGet, games + "/seats/green/view", "", "", http.StatusUnauthorized, "a valid token for the seat is required"},
+		{http.MethodGet, games + "/seats/blue/moves", red.Token, "", http.StatusNotFound, "game has no Blue seat"},
+		{http.MethodDelete, seat(red, "/moves"), red.Token, "", http.StatusMethodNotAllowed, "method not allowed"},
+		{http.MethodPost, seat(other, "/moves"), other.Token, `{"index": 0}`, http.StatusConflict, "it is not your turn"},
+		{http.MethodPost, seat(waiting, "/moves"), waiting.Token, `{}`, http.StatusBadRequest, "either a move index or a move id is required"},
+		{http.MethodPost, seat(waiting, "/moves"), waiting.Token, `{"index": 99}`, http.StatusBadRequest, "move index must be from 0 to"},
+		{http.MethodPost, seat(waiting, "/moves"), waiting.Token, `{"index": -1}`, http.StatusBadRequest, "move index must be from 0 to"},
+		{http.MethodPost, seat(waiting, "/moves"), waiting.Token, `{"id": "bogus"}`, http.StatusBadRequest, "no legal move has id bogus"},
+		{http.MethodPost, seat(waiting, "/moves"), waiting.Token, `{"index": "one"}`, http.StatusBadRequest, "invalid request"},
+	} {
+		var failure map[string]string
+		status := call(t, ts, c.method, c.path, c.token, c.body, &failure)
+		assert.Equal(t, c.status, status, "%s %s", c.method, c.path)
+		assert.Contains(t, failure["error"], c.message, "%s %s", c.method, c.path)
+	}
+
+	// none of the failed requests changed the state of the game
+	var after GameSummary
+	call(t, ts, http.MethodGet, games, "", "", &after)
+	assert.Equal(t, summary, after)
+}
+
+// call makes a request to a test server, decoding the JSON response and returning the status
+func call(t *testing.T, ts *httptest.Server, method string, path string, token string, body string, result any) int {
+	request, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	assert.NoError(t, err)
+
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	response, err := ts.Client().Do(request)
+	assert.NoError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(result))
+
+	return response.StatusCode
+}
+
+// settled whether a game is waiting on a client or is over
+func settled(summary GameSummary) bool {
+	return summary.Waiting != nil || summary.Winner != nil
+}
+
+// await polls a game until a condition holds for its public state, failing rather than hanging if it never does
+func await(t *testing.T, ts *httptest.Server, id string, condition func(summary GameSummary) bool) GameSummary {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var summary GameSummary
+		call(t, ts, http.MethodGet, "/games/"+id, "", "", &summary)
+		if condition(summary) {
+			return summary
+		}
+
+		if time.Now().After(deadline) {
+			assert.Fail(t, "timed out waiting on game", id)
+			return summary
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,202 @@
+package simulation
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+)
+
+// confidence is the z-score for a 95% confidence interval
+const confidence = 1.96
+
+// Statistics Summary statistics for a group of seats, either by source or by seat color
+type Statistics struct {
+	// Name The name of the source or the seat color
+	Name string `json:"name"`
+
+	// Seats The number of seats played across all games
+	Seats int `json:"seats"`
+
+	// Wins The number of games won
+	Wins int `json:"wins"`
+
+	// WinRate The fraction of seats that won
+	WinRate float64 `json:"winrate"`
+
+	// Lower The lower bound of the 95% confidence interval for the win rate
+	Lower float64 `json:"lower"`
+
+	// Upper The upper bound of the 95% confidence interval for the win rate
+	Upper float64 `json:"upper"`
+
+	// AverageTurns The average number of turns per seat, from Player.Turns()
+	AverageTurns float64 `json:"averageturns"`
+
+	// Forfeits The total number of forfeited moves
+	Forfeits int `json:"forfeits"`
+}
+
+// Results The results of a simulation, including summary statistics
+type Results interface {
+	// Mode The game mode
+	Mode() string
+
+	// Players The number of players in each game
+	Players() int
+
+	// Games The results of each individual game, in order
+	Games() []GameResult
+
+	// BySource Summary statistics for each source, in the order the sources were configured
+	BySource() []Statistics
+
+	// BySeat Summary statistics for each seat color, in the order colors are assigned
+	BySeat() []Statistics
+
+	// WriteCSV Write the summary statistics in CSV format
+	WriteCSV(writer io.Writer) error
+
+	// WriteJSON Write the scenario, summary statistics, and per-game results in JSON format
+	WriteJSON(writer io.Writer) error
+}
+
+type results struct {
+	Xmode     string       `json:"mode"`
+	Xplayers  int          `json:"players"`
+	XbySource []Statistics `json:"bysource"`
+	XbySeat   []Statistics `json:"byseat"`
+	Xgames    []GameResult `json:"games"`
+}
+
+// NewResults constructs summary results for a set of games played for a scenario
+func NewResults(scenario Scenario, games []GameResult) Results {
+	return &results{
+		Xmode:     scenario.Mode.Value(),
+		Xplayers:  scenario.Players,
+		XbySource: summarize(games, func(seat SeatResult) string { return seat.Source }),
+		XbySeat:   summarize(games, func(seat SeatResult) string { return seat.Color }),
+		Xgames:    games,
+	}
+}
+
+func (r *results) Mode() string {
+	return r.Xmode
+}
+
+func (r *results) Players() int {
+	return r.Xplayers
+}
+
+func (r *results) Games() []GameResult {
+	return r.Xgames
+}
+
+func (r *results) BySource() []Statistics {
+	return r.XbySource
+}
+
+func (r *results) BySeat() []Statistics {
+	return r.XbySeat
+}
+
+func (r *results) WriteCSV(writer io.Writer) error {
+	w := csv.NewWriter(writer)
+
+	header := []string{"category", "name", "seats", "wins", "winrate", "lower", "upper", "averageturns", "forfeits"}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, category := range []struct {
+		name       string
+		statistics []Statistics
+	}{{"source", r.XbySource}, {"seat", r.XbySeat}} {
+		for _, s := range category.statistics {
+			record := []string{
+				category.name,
+				s.Name,
+				strconv.Itoa(s.Seats),
+				strconv.Itoa(s.Wins),
+				formatFloat(s.WinRate),
+				formatFloat(s.Lower),
+				formatFloat(s.Upper),
+				formatFloat(s.AverageTurns),
+				strconv.Itoa(s.Forfeits),
+			}
+
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func (r *results) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// summarize calculates statistics for seats grouped by a key, in the order each key is first seen
+func summarize(games []GameResult, key func(seat SeatResult) string) []Statistics {
+	order := make([]string, 0)
+	grouped := make(map[string]*Statistics)
+	turns := make(map[string]int)
+
+	for _, game := range games {
+		for _, seat := range game.Seats {
+			name := key(seat)
+
+			statistics, exists := grouped[name]
+			if !exists {
+				statistics = &Statistics{Name: name}
+				grouped[name] = statistics
+				order = append(order, name)
+			}
+
+			statistics.Seats += 1
+			statistics.Forfeits += seat.Forfeits
+			turns[name] += seat.Turns
+			if seat.Winner {
+				statistics.Wins += 1
+			}
+		}
+	}
+
+	summary := make([]Statistics, 0, len(order))
+	for _, name := range order {
+		statistics := grouped[name]
+		statistics.WinRate = float64(statistics.Wins) / float64(statistics.Seats)
+		statistics.Lower, statistics.Upper = wilsonInterval(statistics.Wins, statistics.Seats)
+		statistics.AverageTurns = float64(turns[name]) / float64(statistics.Seats)
+		summary = append(summary, *statistics)
+	}
+
+	return summary
+}
+
+// wilsonInterval calculates the Wilson score interval for a win rate, which behaves better than
+// the normal approximation when the win rate is close to 0 or 1 or the number of trials is small.
+func wilsonInterval(wins int, trials int) (float64, float64) {
+	if trials == 0 {
+		return 0.0, 0.0
+	}
+
+	n := float64(trials)
+	p := float64(wins) / n
+	z2 := confidence * confidence
+
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	margin := (confidence / (1 + z2/n)) * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+
+	return math.Max(0.0, center-margin), math.Min(1.0, center+margin)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 4, 64)
+}
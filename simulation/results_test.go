@@ -0,0 +1,115 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWilsonInterval(t *testing.T) {
+	lower, upper := wilsonInterval(50, 100)
+	assert.InDelta(t, 0.4038, lower, 0.0001)
+	assert.InDelta(t, 0.5962, upper, 0.0001)
+
+	lower, upper = wilsonInterval(0, 10)
+	assert.Equal(t, 0.0, lower)
+	assert.InDelta(t, 0.2775, upper, 0.0001)
+
+	lower, upper = wilsonInterval(10, 10)
+	assert.InDelta(t, 0.7225, lower, 0.0001)
+	assert.Equal(t, 1.0, upper)
+
+	lower, upper = wilsonInterval(0, 0)
+	assert.Equal(t, 0.0, lower)
+	assert.Equal(t, 0.0, upper)
+}
+
+func TestNewResults(t *testing.T) {
+	results := createResults()
+
+	assert.Equal(t, "AdultMode", results.Mode())
+	assert.Equal(t, 2, results.Players())
+	assert.Equal(t, 2, len(results.Games()))
+
+	assert.Equal(t, []Statistics{
+		{Name: "reward", Seats: 2, Wins: 2, WinRate: 1.0, Lower: 0.3424, Upper: 1.0, AverageTurns: 15.0, Forfeits: 1},
+		{Name: "random", Seats: 2, Wins: 0, WinRate: 0.0, Lower: 0.0, Upper: 0.6576, AverageTurns: 18.0, Forfeits: 5},
+	}, roundStatistics(results.BySource()))
+
+	assert.Equal(t, []Statistics{
+		{Name: "Red", Seats: 2, Wins: 1, WinRate: 0.5, Lower: 0.0945, Upper: 0.9055, AverageTurns: 16.0, Forfeits: 3},
+		{Name: "Yellow", Seats: 2, Wins: 1, WinRate: 0.5, Lower: 0.0945, Upper: 0.9055, AverageTurns: 17.0, Forfeits: 3},
+	}, roundStatistics(results.BySeat()))
+}
+
+func TestResultsWriteCSV(t *testing.T) {
+	results := createResults()
+
+	var buffer bytes.Buffer
+	err := results.WriteCSV(&buffer)
+	assert.NoError(t, err)
+
+	expected := "category,name,seats,wins,winrate,lower,upper,averageturns,forfeits\n" +
+		"source,reward,2,2,1.0000,0.3424,1.0000,15.0000,1\n" +
+		"source,random,2,0,0.0000,0.0000,0.6576,18.0000,5\n" +
+		"seat,Red,2,1,0.5000,0.0945,0.9055,16.0000,3\n" +
+		"seat,Yellow,2,1,0.5000,0.0945,0.9055,17.0000,3\n"
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestResultsWriteJSON(t *testing.T) {
+	results := createResults()
+
+	var buffer bytes.Buffer
+	err := results.WriteJSON(&buffer)
+	assert.NoError(t, err)
+
+	var decoded map[string]any
+	err = json.Unmarshal(buffer.Bytes(), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, "AdultMode", decoded["mode"])
+	assert.Equal(t, 2.0, decoded["players"])
+	assert.Equal(t, 2, len(decoded["bysource"].([]any)))
+	assert.Equal(t, 2, len(decoded["byseat"].([]any)))
+	assert.Equal(t, 2, len(decoded["games"].([]any)))
+}
+
+func createResults() Results {
+	scenario := Scenario{Mode: model.AdultMode, Players: 2, Games: 2}
+
+	games := []GameResult{
+		{
+			Game:  0,
+			Seed:  10,
+			First: "Red",
+			Seats: []SeatResult{
+				{Color: "Red", Source: "reward", Winner: true, Turns: 12, Forfeits: 0},
+				{Color: "Yellow", Source: "random", Winner: false, Turns: 16, Forfeits: 2},
+			},
+		},
+		{
+			Game:  1,
+			Seed:  11,
+			First: "Yellow",
+			Seats: []SeatResult{
+				{Color: "Red", Source: "random", Winner: false, Turns: 20, Forfeits: 3},
+				{Color: "Yellow", Source: "reward", Winner: true, Turns: 18, Forfeits: 1},
+			},
+		},
+	}
+
+	return NewResults(scenario, games)
+}
+
+func roundStatistics(statistics []Statistics) []Statistics {
+	rounded := make([]Statistics, 0, len(statistics))
+	for _, s := range statistics {
+		s.Lower = float64(int(s.Lower*10000+0.5)) / 10000
+		s.Upper = float64(int(s.Upper*10000+0.5)) / 10000
+		rounded = append(rounded, s)
+	}
+	return rounded
+}
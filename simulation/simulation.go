@@ -0,0 +1,225 @@
+package simulation
+
+// The simulation package runs batches of games headlessly and reports statistics about the
+// results.  In the original Python implementation, this was how the reward algorithm was tuned:
+// run a few thousand games between different character input sources, and compare win rates.
+//
+// Every game is played with its own seeded randomizer, derived from the scenario's base seed.  So,
+// a scenario with the same seed always produces exactly the same results, and any individual game
+// can be reproduced from the seed that is recorded with its result.  This is also what makes it
+// safe to spread games across a pool of workers: games share nothing, so results are identical
+// regardless of how many workers are used or which worker happens to play a given game.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/pronovic/go-apologies/engine"
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/source"
+)
+
+// Source configures a character input source used in a simulation
+type Source struct {
+	// Name The name to report statistics under, defaults to the name of the input source
+	Name string
+
+	// Factory Constructs a new input source for each game, using the game's randomizer.
+	// The factory may be called concurrently, and must not return an instance that is shared between games.
+	Factory func(randomizer random.Randomizer) source.CharacterInputSource
+}
+
+// Scenario describes a set of games to simulate
+type Scenario struct {
+	// Mode The game mode
+	Mode model.GameMode
+
+	// Players The number of players in each game
+	Players int
+
+	// Games The number of games to play
+	Games int
+
+	// Seed The base seed, where game N is played with seed Seed+N
+	Seed int64
+
+	// Sources The input sources, assigned to seats in order and repeated if there are fewer sources than players
+	Sources []Source
+}
+
+// SeatResult The result for a single seat in a single game
+type SeatResult struct {
+	Color    string `json:"color"`
+	Source   string `json:"source"`
+	Winner   bool   `json:"winner"`
+	Turns    int    `json:"turns"`
+	Forfeits int    `json:"forfeits"`
+}
+
+// GameResult The result of a single game
+type GameResult struct {
+	Game  int          `json:"game"`
+	Seed  int64        `json:"seed"`
+	First string       `json:"first"`
+	Seats []SeatResult `json:"seats"`
+}
+
+// Run plays all of the games in a scenario sequentially, returning the results
+func Run(scenario Scenario) (Results, error) {
+	return RunParallel(context.Background(), scenario, 1)
+}
+
+// RunParallel plays all of the games in a scenario using a pool of workers, returning the results in game order.
+// If the context is cancelled, or if any game fails, the remaining games are abandoned and an error is returned.
+func RunParallel(ctx context.Context, scenario Scenario, workers int) (Results, error) {
+	if err := validate(scenario); err != nil {
+		return nil, err
+	}
+
+	if workers < 1 {
+		return nil, errors.New("at least one worker required")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var failure error
+	var once sync.Once
+	var wg sync.WaitGroup
+
+	games := make([]GameResult, scenario.Games)
+	jobs := make(chan int)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for game := range jobs {
+				result, err := playGame(ctx, scenario, game)
+				if err != nil {
+					once.Do(func() {
+						failure = err
+						cancel() // stop handing out work to the other workers
+					})
+					return
+				}
+
+				games[game] = result // each worker writes a distinct element, so no lock is needed
+			}
+		}()
+	}
+
+feed:
+	for game := 0; game < scenario.Games; game++ {
+		select {
+		case jobs <- game:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if failure != nil {
+		return nil, failure
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewResults(scenario, games), nil
+}
+
+// PlayGame plays a single game from a scenario, using the seed for that game
+func PlayGame(scenario Scenario, game int) (GameResult, error) {
+	return playGame(context.Background(), scenario, game)
+}
+
+func playGame(ctx context.Context, scenario Scenario, game int) (GameResult, error) {
+	seed := scenario.Seed + int64(game)
+	randomizer := random.NewSeededRandomizer(seed)
+
+	names := make([]string, 0, scenario.Players)
+	characters := make([]engine.Character, 0, scenario.Players)
+	for seat := 0; seat < scenario.Players; seat++ {
+		configured := scenario.Sources[seat%len(scenario.Sources)]
+		input := configured.Factory(randomizer)
+
+		name := configured.Name
+		if name == "" {
+			name = input.Name()
+		}
+
+		names = append(names, name)
+		characters = append(characters, engine.NewCharacter(fmt.Sprintf("Player %d", seat), input))
+	}
+
+	runtime, err := engine.NewEngine(scenario.Mode, characters, nil, randomizer)
+	if err != nil {
+		return GameResult{}, err
+	}
+
+	// forfeits are counted from the moves that were executed, since the engine replaces an illegal move from a source
+	forfeits := make(map[model.PlayerColor]int)
+	runtime.Subscribe(func(event engine.Event) {
+		if event.Type == engine.TurnForfeited {
+			forfeits[*event.Color] += 1
+		}
+	})
+
+	completion, err := runtime.PlayToCompletionContext(ctx, engine.CompletionOptions{})
+	if err != nil {
+		return GameResult{}, err
+	}
+
+	winner := completion.Winner
+
+	seats := make([]SeatResult, 0, scenario.Players)
+	for seat, character := range characters {
+		seats = append(seats, SeatResult{
+			Color:    character.Color().Value(),
+			Source:   names[seat],
+			Winner:   character == winner,
+			Turns:    runtime.Game().Players()[character.Color()].Turns(),
+			Forfeits: forfeits[character.Color()],
+		})
+	}
+
+	return GameResult{
+		Game:  game,
+		Seed:  seed,
+		First: runtime.First().Value(),
+		Seats: seats,
+	}, nil
+}
+
+func validate(scenario Scenario) error {
+	if !model.GameModes.MemberOf(scenario.Mode.Value()) {
+		return errors.New("invalid game mode")
+	}
+
+	if scenario.Players < model.MinPlayers || scenario.Players > model.MaxPlayers {
+		return errors.New("invalid number of players")
+	}
+
+	if scenario.Games < 1 {
+		return errors.New("at least one game required")
+	}
+
+	if len(scenario.Sources) < 1 {
+		return errors.New("at least one source required")
+	}
+
+	for _, configured := range scenario.Sources {
+		if configured.Factory == nil {
+			return errors.New("source factory is nil")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"unsafe"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunInvalid(t *testing.T) {
+	randomSource := Source{Factory: func(randomizer random.Randomizer) source.CharacterInputSource {
+		return source.RandomInputSource(randomizer)
+	}}
+
+	_, err := Run(Scenario{Players: 2, Games: 1, Sources: []Source{randomSource}})
+	assert.EqualError(t, err, "invalid game mode")
+
+	_, err = Run(Scenario{Mode: unknownMode(), Players: 2, Games: 1, Sources: []Source{randomSource}})
+	assert.EqualError(t, err, "invalid game mode")
+
+	_, err = Run(Scenario{Mode: model.StandardMode, Players: 1, Games: 1, Sources: []Source{randomSource}})
+	assert.EqualError(t, err, "invalid number of players")
+
+	_, err = Run(Scenario{Mode: model.StandardMode, Players: 5, Games: 1, Sources: []Source{randomSource}})
+	assert.EqualError(t, err, "invalid number of players")
+
+	_, err = Run(Scenario{Mode: model.StandardMode, Players: 2, Games: 0, Sources: []Source{randomSource}})
+	assert.EqualError(t, err, "at least one game required")
+
+	_, err = Run(Scenario{Mode: model.StandardMode, Players: 2, Games: 1, Sources: []Source{}})
+	assert.EqualError(t, err, "at least one source required")
+
+	_, err = Run(Scenario{Mode: model.StandardMode, Players: 2, Games: 1, Sources: []Source{{Name: "x"}}})
+	assert.EqualError(t, err, "source factory is nil")
+}
+
+func TestRun(t *testing.T) {
+	for _, mode := range model.GameModes.Members() {
+		scenario := Scenario{
+			Mode:    mode,
+			Players: 3,
+			Games:   10,
+			Seed:    42,
+			Sources: []Source{
+				{Name: "reward", Factory: func(_ random.Randomizer) source.CharacterInputSource { return source.RewardInputSource(nil, nil) }},
+				{Factory: func(randomizer random.Randomizer) source.CharacterInputSource {
+					return source.RandomInputSource(randomizer)
+				}},
+			},
+		}
+
+		results, err := Run(scenario)
+		assert.NoError(t, err)
+		assert.Equal(t, mode.Value(), results.Mode())
+		assert.Equal(t, 3, results.Players())
+		assert.Equal(t, 10, len(results.Games()))
+
+		wins := 0
+		for i, game := range results.Games() {
+			assert.Equal(t, i, game.Game)
+			assert.Equal(t, int64(42+i), game.Seed)
+			assert.Equal(t, 3, len(game.Seats))
+			assert.Equal(t, "reward", game.Seats[0].Source)
+			assert.Equal(t, "RandomInputSource", game.Seats[1].Source)
+			assert.Equal(t, "reward", game.Seats[2].Source)
+			assert.Equal(t, model.Red.Value(), game.Seats[0].Color)
+			assert.Equal(t, model.Yellow.Value(), game.Seats[1].Color)
+			assert.Equal(t, model.Green.Value(), game.Seats[2].Color)
+			for _, seat := range game.Seats {
+				if seat.Winner {
+					wins += 1
+				}
+			}
+		}
+		assert.Equal(t, 10, wins) // every game has exactly one winner
+
+		assert.Equal(t, 2, len(results.BySource()))
+		assert.Equal(t, "reward", results.BySource()[0].Name)
+		assert.Equal(t, 20, results.BySource()[0].Seats)
+		assert.Equal(t, "RandomInputSource", results.BySource()[1].Name)
+		assert.Equal(t, 10, results.BySource()[1].Seats)
+
+		assert.Equal(t, 3, len(results.BySeat()))
+		assert.Equal(t, model.Red.Value(), results.BySeat()[0].Name)
+		assert.Equal(t, model.Yellow.Value(), results.BySeat()[1].Name)
+		assert.Equal(t, model.Green.Value(), results.BySeat()[2].Name)
+	}
+}
+
+func TestRunRepeatable(t *testing.T) {
+	scenario := Scenario{
+		Mode:    model.AdultMode,
+		Players: 2,
+		Games:   5,
+		Seed:    1234,
+		Sources: []Source{{Factory: func(randomizer random.Randomizer) source.CharacterInputSource {
+			return source.RandomInputSource(randomizer)
+		}}},
+	}
+
+	results1, err := Run(scenario)
+	assert.NoError(t, err)
+
+	results2, err := Run(scenario)
+	assert.NoError(t, err)
+
+	assert.Equal(t, results1.Games(), results2.Games())
+
+	// an individual game can be reproduced from its seed
+	game, err := PlayGame(scenario, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, results1.Games()[3], game)
+}
+
+func TestPlayGameForfeits(t *testing.T) {
+	calls, stuck := 0, 0
+	cheater := &source.MockCharacterInputSource{}
+	cheater.On("Name").Return("Cheater")
+	cheater.On("ChooseMove", model.StandardMode, mock.Anything, mock.Anything).Return(
+		func(_ model.GameMode, _ model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+			calls += 1
+			if len(legalMoves[0].Actions()) == 0 {
+				stuck += 1
+			}
+
+			// forfeiting is only legal when there is no other move, so otherwise a random legal move is executed instead
+			return model.NewMove(legalMoves[0].Card(), nil, nil), nil
+		},
+	)
+
+	scenario := createRandomScenario(model.StandardMode, 1)
+	scenario.Sources = append([]Source{{Factory: func(_ random.Randomizer) source.CharacterInputSource { return cheater }}}, scenario.Sources...)
+
+	result, err := PlayGame(scenario, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Cheater", result.Seats[0].Source)
+	assert.Equal(t, stuck, result.Seats[0].Forfeits)
+	assert.Less(t, stuck, calls)
+}
+
+func TestRunParallelInvalid(t *testing.T) {
+	scenario := createRandomScenario(model.StandardMode, 1)
+	_, err := RunParallel(context.Background(), scenario, 0)
+	assert.EqualError(t, err, "at least one worker required")
+}
+
+func TestRunParallel(t *testing.T) {
+	for _, mode := range model.GameModes.Members() {
+		scenario := createRandomScenario(mode, 8)
+
+		sequential, err := Run(scenario)
+		assert.NoError(t, err)
+
+		// results are identical and in game order, regardless of the number of workers
+		for _, workers := range []int{2, 3, 10} {
+			parallel, err := RunParallel(context.Background(), scenario, workers)
+			assert.NoError(t, err)
+			assert.Equal(t, sequential.Games(), parallel.Games())
+			assert.Equal(t, sequential.BySource(), parallel.BySource())
+			assert.Equal(t, sequential.BySeat(), parallel.BySeat())
+		}
+	}
+}
+
+func TestRunParallelCancelled(t *testing.T) {
+	scenario := createRandomScenario(model.StandardMode, 1000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := RunParallel(ctx, scenario, 4)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunParallelFailure(t *testing.T) {
+	scenario := createRandomScenario(model.StandardMode, 100)
+	scenario.Sources = []Source{
+		{
+			Factory: func(randomizer random.Randomizer) source.CharacterInputSource {
+				input := source.MockCharacterInputSource{}
+				input.On("Name").Return("failing")
+				input.On("ChooseMove", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("hello"))
+				return &input
+			},
+		},
+	}
+
+	_, err := RunParallel(context.Background(), scenario, 4)
+	assert.EqualError(t, err, "hello")
+}
+
+// createRandomScenario creates a 2-player scenario where both players choose their moves randomly
+func createRandomScenario(mode model.GameMode, games int) Scenario {
+	return Scenario{
+		Mode:    mode,
+		Players: 2,
+		Games:   games,
+		Seed:    99,
+		Sources: []Source{
+			{
+				Factory: func(randomizer random.Randomizer) source.CharacterInputSource {
+					return source.RandomInputSource(randomizer)
+				},
+			},
+		},
+	}
+}
+
+// unknownMode returns a game mode that is not a member of model.GameModes, which the model package never hands out
+func unknownMode() model.GameMode {
+	mode := model.StandardMode
+	*(*string)(unsafe.Pointer(&mode)) = "UnknownMode"
+	return mode
+}
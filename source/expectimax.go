@@ -0,0 +1,412 @@
+package source
+
+import (
+	"errors"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/reward"
+	"github.com/pronovic/go-apologies/rules"
+)
+
+// ExpectimaxConfig Configuration for an expectimax input source, where zero values select the defaults.
+type ExpectimaxConfig struct {
+	// Depth The number of turns to look ahead after the current move (default 2)
+	Depth int
+
+	// Budget The maximum number of positions to evaluate when looking ahead, past which a shallower lookahead is used (default 2000)
+	Budget int
+
+	// Calculator Evaluates the positions at the edge of the lookahead (default reward.NewCalculator())
+	Calculator reward.Calculator
+}
+
+type expectimaxInputSource struct {
+	config    ExpectimaxConfig
+	evaluator rules.Rules
+}
+
+// errBudget is returned when a lookahead evaluates more positions than its budget allows
+var errBudget = errors.New("lookahead exceeded its budget")
+
+// lookahead holds the state that is fixed for a single decision
+type lookahead struct {
+	mode        model.GameMode
+	color       model.PlayerColor
+	order       []model.PlayerColor
+	budget      int
+	evaluations *int // nil for a lookahead without a budget
+}
+
+// spend counts the evaluation of a position against the budget, failing once the budget is used up
+func (l lookahead) spend() error {
+	if l.evaluations == nil {
+		return nil
+	}
+
+	if *l.evaluations >= l.budget {
+		return errBudget
+	}
+
+	*l.evaluations += 1
+	return nil
+}
+
+// ExpectimaxInputSource source of input for a character which chooses its next move using a depth-limited
+// expectimax search, optionally accepting a configuration and a rules evaluator.
+//
+// Every turn in the lookahead starts with a chance node, weighted by the cards that might still be drawn.
+// That distribution is estimated from DeckCounts, less the cards that the player can see: its own hand,
+// the card in play, and the discard pile.  The player always chooses the move that maximizes its expected
+// reward.  Opponents are assumed to play the card they draw, choosing the move that maximizes their own
+// immediate reward.  In adult mode, the player also draws a replacement for each card it plays.  A card
+// that draws again gives the same player another turn.  The search is deterministic, so the same position
+// always results in the same move.
+//
+// The number of positions grows quickly with the depth, especially in adult mode, so the lookahead is deepened
+// one turn at a time, up to the configured depth, for as long as it stays within the budget.  The move comes
+// from the deepest lookahead that completed, and a move that only considers its immediate reward is always
+// available.  The budget counts positions rather than time, so the choice is still deterministic.
+func ExpectimaxInputSource(config *ExpectimaxConfig, evaluator rules.Rules) CharacterInputSource {
+	if evaluator == nil {
+		evaluator = rules.NewRules(nil)
+	}
+
+	var c ExpectimaxConfig
+	if config != nil {
+		c = *config
+	}
+
+	if c.Depth < 1 {
+		c.Depth = 2
+	}
+
+	if c.Budget < 1 {
+		c.Budget = 2000
+	}
+
+	if c.Calculator == nil {
+		c.Calculator = reward.NewCalculator()
+	}
+
+	return &expectimaxInputSource{
+		config:    c,
+		evaluator: evaluator,
+	}
+}
+
+func (s *expectimaxInputSource) Name() string {
+	return "ExpectimaxInputSource"
+}
+
+func (s *expectimaxInputSource) ChooseMove(mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	if len(legalMoves) == 0 {
+		return nil, errors.New("no legal moves")
+	}
+
+	if len(legalMoves) == 1 {
+		return legalMoves[0], nil // nothing to search
+	}
+
+	order := []model.PlayerColor{}
+	for _, color := range model.PlayerColors.Members() {
+		if _, exists := view.Opponents()[color]; exists || color == view.Player().Color() {
+			order = append(order, color)
+		}
+	}
+
+	remaining := remainingCards(view, legalMoves)
+
+	var best model.Move
+	for depth := 0; depth <= s.config.Depth; depth++ {
+		state := lookahead{mode: mode, color: view.Player().Color(), order: order}
+		if depth > 0 {
+			evaluations := 0
+			state.budget = s.config.Budget
+			state.evaluations = &evaluations
+		}
+
+		move, err := s.search(state, view, remaining, legalMoves, depth)
+		if errors.Is(err, errBudget) {
+			break // keep the move from the deepest lookahead that completed
+		} else if err != nil {
+			return nil, err
+		}
+
+		best = move
+	}
+
+	return best, nil
+}
+
+// search returns the move with the best expected value, looking ahead the indicated number of turns
+func (s *expectimaxInputSource) search(state lookahead, view model.PlayerView, remaining map[model.CardType]int, legalMoves []model.Move, depth int) (model.Move, error) {
+	var best model.Move
+	var bestScore float64
+
+	for _, move := range legalMoves {
+		score, err := s.afterMove(state, view, remaining, state.color, move, depth)
+		if err != nil {
+			return nil, err
+		}
+
+		// ties go to the earliest move, which keeps the choice stable
+		if best == nil || score > bestScore {
+			best = move
+			bestScore = score
+		}
+	}
+
+	return best, nil
+}
+
+// afterMove returns the expected value of the position after a color plays a move
+func (s *expectimaxInputSource) afterMove(state lookahead, view model.PlayerView, remaining map[model.CardType]int, color model.PlayerColor, move model.Move, depth int) (float64, error) {
+	if err := state.spend(); err != nil {
+		return 0, err
+	}
+
+	result, err := s.evaluator.EvaluateMove(view, move)
+	if err != nil {
+		return 0, err
+	}
+
+	if depth < 1 || completed(result) {
+		return s.leaf(result), nil
+	}
+
+	next := color
+	if len(move.Actions()) == 0 || !s.evaluator.DrawAgain(move.Card()) {
+		next = nextColor(state.order, color)
+	}
+
+	if state.mode != model.AdultMode || color != state.color {
+		return s.turn(state, result, remaining, next, depth)
+	}
+
+	// in adult mode, the player replaces the card it played before anyone else takes a turn
+	result.Player().RemoveFromHand(move.Card())
+
+	return s.chance(remaining, func(card model.Card, remaining map[model.CardType]int) (float64, error) {
+		drawn := result.Copy()
+		drawn.Player().AppendToHand(card)
+		return s.turn(state, drawn, remaining, next, depth)
+	})
+}
+
+// turn returns the expected value of a turn for a color, given the position before the turn is taken
+func (s *expectimaxInputSource) turn(state lookahead, view model.PlayerView, remaining map[model.CardType]int, color model.PlayerColor, depth int) (float64, error) {
+	if color == state.color && state.mode == model.AdultMode {
+		if err := state.spend(); err != nil {
+			return 0, err
+		}
+
+		moves, err := s.evaluator.ConstructLegalMoves(view, nil)
+		if err != nil {
+			return 0, err
+		}
+
+		return s.maximize(state, view, remaining, moves, depth)
+	}
+
+	return s.chance(remaining, func(card model.Card, remaining map[model.CardType]int) (float64, error) {
+		if err := state.spend(); err != nil {
+			return 0, err
+		}
+
+		moves, err := s.evaluator.ConstructLegalMoves(perspective(view, color), card)
+		if err != nil {
+			return 0, err
+		}
+
+		if color == state.color {
+			return s.maximize(state, view, remaining, moves, depth)
+		}
+
+		move, err := s.respond(state, view, color, moves)
+		if err != nil {
+			return 0, err
+		}
+
+		return s.afterMove(state, view, remaining, color, move, depth-1)
+	})
+}
+
+// maximize returns the value of the best move for the player
+func (s *expectimaxInputSource) maximize(state lookahead, view model.PlayerView, remaining map[model.CardType]int, moves []model.Move, depth int) (float64, error) {
+	best := 0.0
+	for i, move := range moves {
+		score, err := s.afterMove(state, view, remaining, state.color, move, depth-1)
+		if err != nil {
+			return 0, err
+		}
+
+		if i == 0 || score > best {
+			best = score
+		}
+	}
+
+	return best, nil
+}
+
+// respond chooses the move for an opponent, which is the move that maximizes the opponent's immediate reward
+func (s *expectimaxInputSource) respond(state lookahead, view model.PlayerView, color model.PlayerColor, moves []model.Move) (model.Move, error) {
+	var best model.Move
+	var bestScore float32
+
+	for _, move := range moves {
+		if err := state.spend(); err != nil {
+			return nil, err
+		}
+
+		result, err := s.evaluator.EvaluateMove(view, move)
+		if err != nil {
+			return nil, err
+		}
+
+		score := s.config.Calculator.Calculate(perspective(result, color))
+		if best == nil || score > bestScore {
+			best = move
+			bestScore = score
+		}
+	}
+
+	return best, nil
+}
+
+// chance returns the expected value over every card type that might be drawn next
+func (s *expectimaxInputSource) chance(remaining map[model.CardType]int, value func(card model.Card, remaining map[model.CardType]int) (float64, error)) (float64, error) {
+	// a long lookahead can exhaust the draw pile, at which point the discards are shuffled back in
+	if countCards(remaining) == 0 {
+		remaining = copyCounts(model.DeckCounts)
+	}
+
+	total := countCards(remaining)
+	expected := 0.0
+	for _, cardType := range model.CardTypes.Members() {
+		count := remaining[cardType]
+		if count == 0 {
+			continue
+		}
+
+		after := copyCounts(remaining)
+		after[cardType] -= 1
+
+		// the identity of the drawn card doesn't matter, only its type
+		card := model.NewCard("lookahead-"+cardType.Value(), cardType)
+
+		score, err := value(card, after)
+		if err != nil {
+			return 0, err
+		}
+
+		expected += float64(count) / float64(total) * score
+	}
+
+	return expected, nil
+}
+
+// leaf evaluates a position at the edge of the lookahead
+func (s *expectimaxInputSource) leaf(view model.PlayerView) float64 {
+	return float64(s.config.Calculator.Calculate(view))
+}
+
+// remainingCards estimates how many cards of each type might still be drawn, based on what the player can see
+func remainingCards(view model.PlayerView, legalMoves []model.Move) map[model.CardType]int {
+	seen := make(map[string]model.Card)
+
+	for _, card := range view.Player().Hand() {
+		seen[card.Id()] = card
+	}
+
+	for _, card := range view.DiscardPile() {
+		seen[card.Id()] = card
+	}
+
+	for _, move := range legalMoves {
+		if move.Card() != nil {
+			seen[move.Card().Id()] = move.Card()
+		}
+	}
+
+	remaining := copyCounts(model.DeckCounts)
+	for _, card := range seen {
+		if remaining[card.Type()] > 0 {
+			remaining[card.Type()] -= 1
+		}
+	}
+
+	// once every card has been seen, the discard pile is shuffled back into the draw pile
+	if countCards(remaining) == 0 {
+		remaining = copyCounts(model.DeckCounts)
+		for _, card := range view.Player().Hand() {
+			remaining[card.Type()] -= 1
+		}
+	}
+
+	return remaining
+}
+
+// countCards returns the total number of cards across all types
+func countCards(counts map[model.CardType]int) int {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	return total
+}
+
+// copyCounts returns an independent copy of a set of card counts
+func copyCounts(counts map[model.CardType]int) map[model.CardType]int {
+	result := make(map[model.CardType]int, len(counts))
+	for cardType, count := range counts {
+		result[cardType] = count
+	}
+
+	return result
+}
+
+// perspective returns a view of the same position from the perspective of another color
+func perspective(view model.PlayerView, color model.PlayerColor) model.PlayerView {
+	if view.Player().Color() == color {
+		return view
+	}
+
+	others := make(map[model.PlayerColor]model.Player, len(view.Opponents()))
+	others[view.Player().Color()] = view.Player().PublicData()
+
+	// range on a map explicitly does *not* return keys in a stable order, so we iterate on colors instead
+	for _, c := range model.PlayerColors.Members() {
+		if opponent, exists := view.Opponents()[c]; exists && c != color {
+			others[c] = opponent
+		}
+	}
+
+	return model.NewPlayerView(view.Opponents()[color], others, view.DiscardPile())
+}
+
+// nextColor returns the color that takes the next turn after a color
+func nextColor(order []model.PlayerColor, color model.PlayerColor) model.PlayerColor {
+	for i, c := range order {
+		if c == color {
+			return order[(i+1)%len(order)]
+		}
+	}
+
+	return color
+}
+
+// completed returns whether any player in a view has won the game
+func completed(view model.PlayerView) bool {
+	if view.Player().AllPawnsInHome() {
+		return true
+	}
+
+	for _, opponent := range view.Opponents() {
+		if opponent.AllPawnsInHome() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,215 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/reward"
+	"github.com/pronovic/go-apologies/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpectimaxInputSourceName(t *testing.T) {
+	obj := ExpectimaxInputSource(nil, nil)
+	assert.Equal(t, "ExpectimaxInputSource", obj.Name())
+}
+
+func TestExpectimaxInputSourceDefaults(t *testing.T) {
+	obj := ExpectimaxInputSource(nil, nil).(*expectimaxInputSource)
+	assert.Equal(t, 2, obj.config.Depth)
+	assert.Equal(t, 2000, obj.config.Budget)
+	assert.NotNil(t, obj.config.Calculator)
+	assert.NotNil(t, obj.evaluator)
+}
+
+func TestExpectimaxInputSourceChooseMoveTrivial(t *testing.T) {
+	obj := ExpectimaxInputSource(nil, nil)
+
+	_, err := obj.ChooseMove(model.StandardMode, nil, []model.Move{})
+	assert.EqualError(t, err, "no legal moves")
+
+	// with only one legal move, there is nothing to search
+	move := model.NewMove(model.NewCard("1", model.Card1), nil, nil)
+	result, err := obj.ChooseMove(model.StandardMode, nil, []model.Move{move})
+	assert.NoError(t, err)
+	assert.Same(t, move, result)
+}
+
+func TestExpectimaxInputSourceChooseMoveWinning(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+	assert.Equal(t, 2, len(moves))
+
+	// playing the 2 wins immediately, while playing the 1 gives Yellow a chance to win first
+	obj := ExpectimaxInputSource(&ExpectimaxConfig{Depth: 1}, nil)
+	result, err := obj.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Card2, result.Card().Type())
+}
+
+func TestExpectimaxInputSourceChooseMoveDeterministic(t *testing.T) {
+	game, _ := model.NewGame(3, nil, nil)
+	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToSquare(10)
+	_ = game.Players()[model.Yellow].Pawns()[0].Position().MoveToSquare(14)
+	_ = game.Players()[model.Green].Pawns()[0].Position().MoveToSquare(40)
+
+	view, _ := game.CreatePlayerView(model.Red)
+	moves, _ := rules.NewRules(nil).ConstructLegalMoves(view, model.NewCard("0", model.Card11))
+	assert.Less(t, 1, len(moves))
+
+	// the search has no randomness, so the same position always results in the same move
+	config := &ExpectimaxConfig{Depth: 1}
+	result1, err := ExpectimaxInputSource(config, nil).ChooseMove(model.StandardMode, view, moves)
+	assert.NoError(t, err)
+	result2, err := ExpectimaxInputSource(config, nil).ChooseMove(model.StandardMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, result1, result2)
+}
+
+func TestExpectimaxInputSourceBudget(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	// within the budget, the lookahead considers Yellow's response to each move
+	calculator := &recordingCalculator{}
+	result, err := ExpectimaxInputSource(&ExpectimaxConfig{Depth: 1, Calculator: calculator}, nil).ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Card2, result.Card().Type())
+	assert.Contains(t, calculator.colors, model.Yellow)
+
+	// past the budget, the move comes from its immediate reward alone, which still finds the win
+	calculator = &recordingCalculator{}
+	result, err = ExpectimaxInputSource(&ExpectimaxConfig{Depth: 2, Budget: 1, Calculator: calculator}, nil).ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Card2, result.Card().Type())
+	assert.NotContains(t, calculator.colors, model.Yellow)
+}
+
+func TestExpectimaxInputSourceDrawAgain(t *testing.T) {
+	game, _ := model.NewGame(2, nil, nil)
+	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToSquare(10)
+	_ = game.Players()[model.Yellow].Pawns()[0].Position().MoveToSquare(40)
+	view, _ := game.CreatePlayerView(model.Red)
+
+	evaluator := rules.NewRules(nil)
+	state := lookahead{mode: model.StandardMode, color: model.Red, order: []model.PlayerColor{model.Red, model.Yellow}}
+	remaining := remainingCards(view, nil)
+
+	for _, cardType := range []model.CardType{model.Card1, model.Card2} {
+		calculator := &recordingCalculator{}
+		obj := ExpectimaxInputSource(&ExpectimaxConfig{Calculator: calculator}, evaluator).(*expectimaxInputSource)
+
+		moves, _ := evaluator.ConstructLegalMoves(view, model.NewCard("0", cardType))
+		_, err := obj.afterMove(state, view, remaining, model.Red, moves[0], 1)
+		assert.NoError(t, err)
+
+		// a 2 draws again, so Red takes the next turn; otherwise, Yellow chooses its response
+		if cardType == model.Card2 {
+			assert.NotContains(t, calculator.colors, model.Yellow)
+		} else {
+			assert.Contains(t, calculator.colors, model.Yellow)
+		}
+	}
+}
+
+func TestExpectimaxChance(t *testing.T) {
+	obj := ExpectimaxInputSource(nil, nil).(*expectimaxInputSource)
+	remaining := map[model.CardType]int{model.Card1: 3, model.Card2: 1}
+
+	// chance nodes are weighted by the number of cards of each type that remain
+	drawn := make(map[model.CardType]int)
+	result, err := obj.chance(remaining, func(card model.Card, after map[model.CardType]int) (float64, error) {
+		drawn[card.Type()] = after[card.Type()]
+		if card.Type() == model.Card1 {
+			return 1, nil
+		}
+		return 0, nil
+	})
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.75, result, 0.0001)
+	assert.Equal(t, map[model.CardType]int{model.Card1: 2, model.Card2: 0}, drawn)
+	assert.Equal(t, 3, remaining[model.Card1]) // the passed-in counts are not changed
+}
+
+func TestRemainingCards(t *testing.T) {
+	game, _ := model.NewGame(2, nil, nil)
+
+	discarded := make([]model.Card, 0)
+	for i := 0; i < 5; i++ {
+		card, _ := game.Deck().Draw()
+		_ = game.Deck().Discard(card)
+		discarded = append(discarded, card)
+	}
+
+	card, _ := game.Deck().Draw()
+	view, _ := game.CreatePlayerView(model.Red)
+	moves, _ := rules.NewRules(nil).ConstructLegalMoves(view, card)
+
+	// the discard pile and the card in play can't be drawn next
+	expected := copyCounts(model.DeckCounts)
+	for _, c := range append(discarded, card) {
+		expected[c.Type()] -= 1
+	}
+
+	result := remainingCards(view, moves)
+	assert.Equal(t, expected, result)
+	assert.Equal(t, model.DeckSize-6, countCards(result))
+}
+
+func TestRemainingCardsReshuffled(t *testing.T) {
+	game, _ := model.NewGame(2, nil, nil)
+
+	var card model.Card
+	for game.Deck().DrawPileSize() > 0 {
+		card, _ = game.Deck().Draw()
+		if game.Deck().DrawPileSize() > 0 {
+			_ = game.Deck().Discard(card)
+		}
+	}
+
+	game.Players()[model.Red].AppendToHand(card)
+	view, _ := game.CreatePlayerView(model.Red)
+
+	// every card has been seen, so the discard pile will be shuffled back into the draw pile
+	result := remainingCards(view, nil)
+	assert.Equal(t, model.DeckSize-1, countCards(result))
+	assert.Equal(t, model.DeckCounts[card.Type()]-1, result[card.Type()])
+}
+
+func TestPerspective(t *testing.T) {
+	game, _ := model.NewGame(3, nil, nil)
+	card, _ := game.Deck().Draw()
+	game.Players()[model.Red].AppendToHand(card)
+	discard, _ := game.Deck().Draw()
+	_ = game.Deck().Discard(discard)
+
+	view, _ := game.CreatePlayerView(model.Red)
+	assert.Same(t, view, perspective(view, model.Red))
+
+	result := perspective(view, model.Yellow)
+	assert.Equal(t, model.Yellow, result.Player().Color())
+	assert.Equal(t, 2, len(result.Opponents()))
+	assert.Equal(t, 0, len(result.Opponents()[model.Red].Hand())) // the player's hand is private
+	assert.Equal(t, model.Green, result.Opponents()[model.Green].Color())
+	assert.Equal(t, view.DiscardPile(), result.DiscardPile())
+}
+
+func TestNextColor(t *testing.T) {
+	order := []model.PlayerColor{model.Red, model.Yellow, model.Green}
+	assert.Equal(t, model.Yellow, nextColor(order, model.Red))
+	assert.Equal(t, model.Green, nextColor(order, model.Yellow))
+	assert.Equal(t, model.Red, nextColor(order, model.Green))
+}
+
+// recordingCalculator records the color of every view that it scores
+type recordingCalculator struct {
+	colors []model.PlayerColor
+}
+
+func (c *recordingCalculator) Calculate(view model.PlayerView) float32 {
+	c.colors = append(c.colors, view.Player().Color())
+	return reward.NewCalculator().Calculate(view)
+}
+
+func (c *recordingCalculator) Range(players int) (float32, float32) {
+	return reward.NewCalculator().Range(players)
+}
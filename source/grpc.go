@@ -0,0 +1,181 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pronovic/go-apologies/internal/equality"
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCConfig Configuration for a gRPC input source, where zero values select the defaults.
+type GRPCConfig struct {
+	// Timeout The maximum time to wait for the remote source to reply to a request (default 10 seconds)
+	Timeout time.Duration
+
+	// Options Options for the connection to the remote source (default an insecure connection)
+	Options []grpc.DialOption
+}
+
+type grpcInputSource struct {
+	name       string
+	config     GRPCConfig
+	conn       *grpc.ClientConn
+	client     rpc.InputSourceClient
+	randomizer random.Randomizer
+}
+
+// GRPCInputSource source of input for a character which asks a remote source implementing the InputSource gRPC
+// service to choose its moves, optionally accepting a configuration and a randomizer.
+//
+// The remote source is asked for its name right away, which both checks the connection and gives this source
+// its name.  After that, as for ProcessInputSource, the remote source can't stall or break the game: if a
+// request fails or times out, or the reply is not the index of a legal move, then a legal move is chosen at
+// random instead.  The source must be closed once the game is over, to close the connection.
+func GRPCInputSource(target string, config *GRPCConfig, randomizer random.Randomizer) (ExternalInputSource, error) {
+	if randomizer == nil {
+		randomizer = random.NewRandomizer()
+	}
+
+	var c GRPCConfig
+	if config != nil {
+		c = *config
+	}
+
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+
+	if len(c.Options) == 0 {
+		c.Options = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+
+	conn, err := grpc.NewClient(target, c.Options...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &grpcInputSource{
+		config:     c,
+		conn:       conn,
+		client:     rpc.NewInputSourceClient(conn),
+		randomizer: randomizer,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	reply, err := s.client.Name(ctx, &rpc.NameRequest{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	s.name = reply.Name
+	return s, nil
+}
+
+func (s *grpcInputSource) Name() string {
+	return s.name
+}
+
+func (s *grpcInputSource) ChooseMove(mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	return s.ChooseMoveContext(context.Background(), mode, view, legalMoves)
+}
+
+func (s *grpcInputSource) ChooseMoveContext(ctx context.Context, mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	if len(legalMoves) == 0 {
+		return nil, errors.New("no legal moves")
+	}
+
+	limited, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	request := &rpc.ChooseMoveRequest{
+		Mode:       rpc.ToGameMode(mode),
+		View:       rpc.ToPlayerView(view),
+		LegalMoves: rpc.ToMoves(legalMoves),
+	}
+
+	reply, err := s.client.ChooseMove(limited, request)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err != nil || reply.Index < 0 || int(reply.Index) >= len(legalMoves) {
+		return random.Choice(s.randomizer, legalMoves)
+	}
+
+	return legalMoves[reply.Index], nil
+}
+
+// Close closes the connection to the remote source
+func (s *grpcInputSource) Close() error {
+	return s.conn.Close()
+}
+
+type grpcInputSourceServer struct {
+	rpc.UnimplementedInputSourceServer
+	lock  sync.Mutex
+	input CharacterInputSource
+}
+
+// RegisterGRPCInputSource registers a local input source as the InputSource gRPC service, so it can be used by a remote engine.
+// Input sources are not safe for concurrent use, so requests are handled one at a time.
+func RegisterGRPCInputSource(registrar grpc.ServiceRegistrar, input CharacterInputSource) {
+	rpc.RegisterInputSourceServer(registrar, &grpcInputSourceServer{input: input})
+}
+
+func (s *grpcInputSourceServer) Name(_ context.Context, _ *rpc.NameRequest) (*rpc.NameReply, error) {
+	return &rpc.NameReply{Name: s.input.Name()}, nil
+}
+
+func (s *grpcInputSourceServer) ChooseMove(ctx context.Context, request *rpc.ChooseMoveRequest) (*rpc.MoveIndex, error) {
+	mode, err := rpc.FromGameMode(request.Mode)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	view, err := rpc.FromPlayerView(request.View)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	legalMoves, err := rpc.FromMoves(request.LegalMoves)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if len(legalMoves) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no legal moves")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	move, err := ChooseMoveContext(ctx, s.input, mode, view, legalMoves)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// the chosen move is not necessarily one of the legal moves, since a source may hand back an equivalent copy
+	index := slices.IndexFunc(legalMoves, func(legal model.Move) bool {
+		return legal == move || equality.EqualByValue(legal, move)
+	})
+	if index < 0 {
+		return nil, status.Error(codes.Internal, "input source chose an illegal move")
+	}
+
+	return &rpc.MoveIndex{Index: int32(index)}, nil
+}
@@ -0,0 +1,208 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// serve serves an input source in-process over bufconn, returning the options needed to connect to it
+func serve(t *testing.T, input CharacterInputSource) []grpc.DialOption {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterGRPCInputSource(server, input)
+
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+}
+
+// connect connects a gRPC input source to a served input source
+func connect(t *testing.T, input CharacterInputSource, timeout time.Duration, randomizer random.Randomizer) ExternalInputSource {
+	obj, err := GRPCInputSource("passthrough:///bufnet", &GRPCConfig{Timeout: timeout, Options: serve(t, input)}, randomizer)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = obj.Close() })
+	return obj
+}
+
+func TestGRPCInputSourceDefaults(t *testing.T) {
+	obj := connect(t, RandomInputSource(nil), 0, nil).(*grpcInputSource)
+	assert.Equal(t, 10*time.Second, obj.config.Timeout)
+	assert.NotNil(t, obj.randomizer)
+}
+
+func TestGRPCInputSourceName(t *testing.T) {
+	obj := connect(t, ExpectimaxInputSource(nil, nil), 0, nil)
+	assert.Equal(t, "ExpectimaxInputSource", obj.Name())
+}
+
+func TestGRPCInputSourceChooseMove(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	// the remote source sees the same game, so it makes the same choices as a local source
+	for _, c := range []struct {
+		remote CharacterInputSource
+		local  CharacterInputSource
+	}{
+		{RewardInputSource(nil, nil), RewardInputSource(nil, nil)},
+		{RandomInputSource(random.NewSeededRandomizer(5)), RandomInputSource(random.NewSeededRandomizer(5))},
+	} {
+		obj := connect(t, c.remote, 0, nil)
+		for i := 0; i < 5; i++ {
+			expected, err := c.local.ChooseMove(model.AdultMode, view, moves)
+			assert.NoError(t, err)
+
+			result, err := obj.ChooseMove(model.AdultMode, view, moves)
+			assert.NoError(t, err, c.remote.Name())
+			assert.Same(t, expected, result, c.remote.Name())
+		}
+	}
+}
+
+func TestGRPCInputSourceChooseMoveCopy(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	// a remote source that hands back a deserialized copy of a legal move still gets that move
+	copied := &MockCharacterInputSource{}
+	copied.On("Name").Return("Copied")
+	copied.On("ChooseMove", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ model.GameMode, _ model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+			marshalled, err := json.Marshal(legalMoves[len(legalMoves)-1])
+			if err != nil {
+				return nil, err
+			}
+			return model.NewMoveFromJSON(bytes.NewReader(marshalled))
+		},
+	)
+
+	obj := connect(t, copied, 0, random.NewSeededRandomizer(42))
+	for i := 0; i < 5; i++ {
+		result, err := obj.ChooseMove(model.AdultMode, view, moves)
+		assert.NoError(t, err)
+		assert.Same(t, moves[len(moves)-1], result)
+	}
+}
+
+func TestGRPCInputSourceChooseMoveFallback(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	release := make(chan time.Time)
+	defer close(release)
+
+	for _, c := range []struct {
+		name  string
+		setup func(remote *MockCharacterInputSource)
+	}{
+		{"error", func(remote *MockCharacterInputSource) {
+			remote.On("ChooseMove", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("broken"))
+		}},
+		{"illegal", func(remote *MockCharacterInputSource) {
+			remote.On("ChooseMove", mock.Anything, mock.Anything, mock.Anything).Return(model.NewMove(model.NewCard("x", model.Card1), nil, nil), nil)
+		}},
+		{"slow", func(remote *MockCharacterInputSource) {
+			remote.On("ChooseMove", mock.Anything, mock.Anything, mock.Anything).WaitUntil(release).Return(nil, errors.New("too late"))
+		}},
+	} {
+		remote := &MockCharacterInputSource{}
+		remote.On("Name").Return("Mock")
+		c.setup(remote)
+
+		obj := connect(t, remote, 100*time.Millisecond, random.NewSeededRandomizer(42))
+		expected := fallback(42, view, moves, 3)
+
+		for i := 0; i < 3; i++ {
+			result, err := obj.ChooseMove(model.AdultMode, view, moves)
+			assert.NoError(t, err, c.name)
+			assert.Same(t, expected[i], result, c.name)
+		}
+	}
+}
+
+func TestGRPCInputSourceChooseMoveContextCancelled(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	release := make(chan time.Time)
+	defer close(release)
+
+	remote := &MockCharacterInputSource{}
+	remote.On("Name").Return("Mock")
+	remote.On("ChooseMove", mock.Anything, mock.Anything, mock.Anything).WaitUntil(release).Return(nil, errors.New("too late"))
+
+	obj := connect(t, remote, 0, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// the caller gave up, so there is no move at all
+	_, err := obj.ChooseMoveContext(ctx, model.AdultMode, view, moves)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = obj.ChooseMove(model.AdultMode, view, []model.Move{})
+	assert.EqualError(t, err, "no legal moves")
+}
+
+func TestGRPCInputSourceUnavailable(t *testing.T) {
+	listener := bufconn.Listen(1024)
+	_ = listener.Close()
+
+	options := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+
+	_, err := GRPCInputSource("passthrough:///bufnet", &GRPCConfig{Timeout: time.Second, Options: options}, nil)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestRegisterGRPCInputSourceInvalid(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet", serve(t, RandomInputSource(nil))...)
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := rpc.NewInputSourceClient(conn)
+
+	valid := func() *rpc.ChooseMoveRequest {
+		return &rpc.ChooseMoveRequest{Mode: rpc.ToGameMode(model.AdultMode), View: rpc.ToPlayerView(view), LegalMoves: rpc.ToMoves(moves)}
+	}
+
+	reply, err := client.ChooseMove(context.Background(), valid())
+	assert.NoError(t, err)
+	assert.True(t, reply.Index >= 0 && int(reply.Index) < len(moves))
+
+	for _, c := range []struct {
+		change  func(request *rpc.ChooseMoveRequest)
+		message string
+	}{
+		{func(request *rpc.ChooseMoveRequest) { request.Mode = rpc.GameMode_GAME_MODE_UNSPECIFIED }, "invalid value GAME_MODE_UNSPECIFIED"},
+		{func(request *rpc.ChooseMoveRequest) { request.View = nil }, "view has no player"},
+		{func(request *rpc.ChooseMoveRequest) { request.LegalMoves[0].Card = nil }, "invalid value CARD_TYPE_UNSPECIFIED"},
+		{func(request *rpc.ChooseMoveRequest) { request.LegalMoves = nil }, "no legal moves"},
+	} {
+		request := valid()
+		c.change(request)
+
+		_, err := client.ChooseMove(context.Background(), request)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), c.message)
+		assert.Equal(t, c.message, status.Convert(err).Message())
+	}
+}
@@ -0,0 +1,86 @@
+package source
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/reward"
+	"github.com/pronovic/go-apologies/rules"
+)
+
+type handRewardInputSource struct {
+	evaluator  rules.Rules
+	calculator reward.Calculator
+	hand       reward.HandEvaluator
+}
+
+// HandRewardInputSource source of input for a character which chooses its next move based on a reward calculation
+// plus the value of the cards left in its hand, optionally accepting a rules evaluator, a reward calculator, and a
+// hand evaluator.
+//
+// In adult mode, the same board position can often be reached by spending different cards, and this source prefers
+// to spend the card that is least valuable to keep.  The hand is weighted well below the board by default, so the
+// source doesn't hold on to a card at the expense of a clearly better position.  In standard mode, the hand is
+// always empty, so this source chooses the same moves as RewardInputSource.
+func HandRewardInputSource(evaluator rules.Rules, calculator reward.Calculator, hand reward.HandEvaluator) CharacterInputSource {
+	if evaluator == nil {
+		evaluator = rules.NewRules(nil)
+	}
+
+	if calculator == nil {
+		calculator = reward.NewCalculator()
+	}
+
+	if hand == nil {
+		hand = reward.NewHandEvaluator(nil)
+	}
+
+	return &handRewardInputSource{
+		evaluator:  evaluator,
+		calculator: calculator,
+		hand:       hand,
+	}
+}
+
+func (s *handRewardInputSource) Name() string {
+	return "HandRewardInputSource"
+}
+
+func (s *handRewardInputSource) ChooseMove(_ model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	results := make([]result, 0, len(legalMoves))
+
+	for _, move := range legalMoves {
+		evaluated, err := s.evaluator.EvaluateMove(view, move)
+		if err != nil {
+			return nil, err
+		}
+
+		score := s.calculator.Calculate(evaluated) + s.hand.Evaluate(evaluated, remainingHand(view, move))
+		results = append(results, result{move, score})
+	}
+
+	// sort the highest-scoring move to the top
+	slices.SortStableFunc(results, func(i, j result) int {
+		return cmp.Compare(j.score, i.score) // j before i reverses the sort, so largest is at [0]
+	})
+
+	// return the highest-scoring move
+	return results[0].move, nil
+}
+
+// remainingHand returns the cards left in the player's hand after the card for a move is spent
+func remainingHand(view model.PlayerView, move model.Move) []model.Card {
+	hand := make([]model.Card, 0, len(view.Player().Hand()))
+
+	spent := false
+	for _, card := range view.Player().Hand() {
+		if !spent && move.Card() != nil && card.Id() == move.Card().Id() {
+			spent = true
+		} else {
+			hand = append(hand, card)
+		}
+	}
+
+	return hand
+}
@@ -0,0 +1,125 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/reward"
+	"github.com/pronovic/go-apologies/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandRewardInputSourceName(t *testing.T) {
+	obj := HandRewardInputSource(nil, nil, nil)
+	assert.Equal(t, "HandRewardInputSource", obj.Name())
+}
+
+func TestHandRewardInputSourceChooseMove(t *testing.T) {
+	card1 := model.NewCard("1", model.Card10)
+	card2 := model.NewCard("2", model.CardApologies)
+	card3 := model.NewCard("3", model.Card11)
+
+	player := model.NewPlayer(model.Red)
+	player.AppendToHand(card1)
+	player.AppendToHand(card2)
+	player.AppendToHand(card3)
+	view := model.NewPlayerView(player, map[model.PlayerColor]model.Player{}, nil)
+
+	evaluated1 := model.MockPlayerView{}
+	evaluated2 := model.MockPlayerView{}
+	evaluated3 := model.MockPlayerView{}
+
+	move1 := model.NewMove(card1, nil, nil)
+	move2 := model.NewMove(card2, nil, nil)
+	move3 := model.NewMove(card3, nil, nil)
+	moves := []model.Move{move1, move2, move3}
+
+	evaluator := rules.MockRules{}
+	calculator := reward.MockCalculator{}
+	hand := reward.MockHandEvaluator{}
+	obj := HandRewardInputSource(&evaluator, &calculator, &hand)
+
+	evaluator.On("EvaluateMove", view, move1).Return(&evaluated1, nil).Once()
+	evaluator.On("EvaluateMove", view, move2).Return(&evaluated2, nil).Once()
+	evaluator.On("EvaluateMove", view, move3).Return(&evaluated3, nil).Once()
+
+	// the board is better after move 1, but it spends a card that is worth more to keep
+	calculator.On("Calculate", &evaluated1).Return(float32(110.0)).Once()
+	calculator.On("Calculate", &evaluated2).Return(float32(100.0)).Once()
+	calculator.On("Calculate", &evaluated3).Return(float32(100.0)).Once()
+
+	hand.On("Evaluate", &evaluated1, []model.Card{card2, card3}).Return(float32(20.0)).Once()
+	hand.On("Evaluate", &evaluated2, []model.Card{card1, card3}).Return(float32(40.0)).Once()
+	hand.On("Evaluate", &evaluated3, []model.Card{card1, card2}).Return(float32(10.0)).Once()
+
+	result, err := obj.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, move2, result)
+
+	evaluator.AssertExpectations(t)
+	calculator.AssertExpectations(t)
+	hand.AssertExpectations(t)
+}
+
+func TestHandRewardInputSourceStandardMode(t *testing.T) {
+	game, _ := model.NewGame(3, nil, nil)
+	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToSquare(10)
+	_ = game.Players()[model.Yellow].Pawns()[0].Position().MoveToSquare(14)
+	_ = game.Players()[model.Green].Pawns()[0].Position().MoveToSquare(40)
+	view, _ := game.CreatePlayerView(model.Red)
+
+	// with no hand to keep, the choice is the same as for the plain reward source
+	for _, cardType := range model.CardTypes.Members() {
+		moves, _ := rules.NewRules(nil).ConstructLegalMoves(view, model.NewCard("0", cardType))
+
+		expected, err := RewardInputSource(nil, nil).ChooseMove(model.StandardMode, view, moves)
+		assert.NoError(t, err)
+		result, err := HandRewardInputSource(nil, nil, nil).ChooseMove(model.StandardMode, view, moves)
+		assert.NoError(t, err)
+		assert.Same(t, expected, result)
+	}
+}
+
+func TestHandRewardInputSourceBoardAdvantage(t *testing.T) {
+	game, _ := model.NewGame(4, nil, nil)
+	red := game.Players()[model.Red]
+	for i, square := range []int{45, 47, 53} {
+		_ = red.Pawns()[i+1].Position().MoveToSquare(square)
+	}
+	for _, color := range []model.PlayerColor{model.Yellow, model.Green, model.Blue} {
+		for i := 0; i < 3; i++ {
+			_ = game.Players()[color].Pawns()[i].Position().MoveToSquare(*model.StartCircles[color].Square() + i)
+		}
+	}
+
+	red.AppendToHand(model.NewCard("1", model.CardApologies))
+	red.AppendToHand(model.NewCard("2", model.Card5))
+	view, _ := game.CreatePlayerView(model.Red)
+	moves, _ := rules.NewRules(nil).ConstructLegalMoves(view, nil)
+
+	// with every opponent pawn a target, an Apologies card is worth so much in hand that, unweighted, it is never played
+	unweighted := HandRewardInputSource(nil, nil, reward.NewHandEvaluator(&reward.Weights{Hand: 1}))
+	result, err := unweighted.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Card5, result.Card().Type())
+
+	// with the default weight, bumping an opponent is still worth more than holding on to the card
+	result, err = HandRewardInputSource(nil, nil, nil).ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Equal(t, model.CardApologies, result.Card().Type())
+}
+
+func TestRemainingHand(t *testing.T) {
+	card1 := model.NewCard("1", model.Card10)
+	card2 := model.NewCard("2", model.Card10)
+
+	player := model.NewPlayer(model.Red)
+	player.AppendToHand(card1)
+	player.AppendToHand(card2)
+	view := model.NewPlayerView(player, map[model.PlayerColor]model.Player{}, nil)
+
+	// only the card that was spent is removed, even if another card has the same type
+	assert.Equal(t, []model.Card{card2}, remainingHand(view, model.NewMove(card1, nil, nil)))
+	assert.Equal(t, []model.Card{card1}, remainingHand(view, model.NewMove(card2, nil, nil)))
+	assert.Equal(t, []model.Card{card1, card2}, remainingHand(view, model.NewMove(model.NewCard("3", model.Card4), nil, nil)))
+}
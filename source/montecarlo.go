@@ -0,0 +1,496 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/reward"
+	"github.com/pronovic/go-apologies/rules"
+)
+
+// MonteCarloConfig Configuration for a Monte Carlo input source, where zero values select the defaults.
+type MonteCarloConfig struct {
+	// Iterations The maximum number of playouts per decision (default 30)
+	Iterations int
+
+	// TimeBudget The maximum time to spend on a decision, or zero for no time limit
+	TimeBudget time.Duration
+
+	// Exploration The UCB1 exploration constant (default √2)
+	Exploration float64
+
+	// PlayoutTurns The maximum number of turns after the current move, in the tree and the playout, before the position is scored (default 10)
+	PlayoutTurns int
+
+	// Rollout The policy used to choose moves for every player during a playout (default RandomInputSource)
+	Rollout CharacterInputSource
+
+	// Calculator Scores a playout that reaches the turn limit before the game is completed (default reward.NewCalculator())
+	Calculator reward.Calculator
+}
+
+type monteCarloInputSource struct {
+	config     MonteCarloConfig
+	evaluator  rules.Rules
+	randomizer random.Randomizer
+}
+
+// node is a node in the search tree, reached from its parent by playing a move
+type node struct {
+	move      model.Move        // the move that reaches this node, nil at the root
+	color     model.PlayerColor // the player that plays the move
+	visits    int               // the number of playouts through this node
+	available int               // the number of times the move was legal when its parent was visited
+	total     float64           // the total score of the playouts, for the player that plays the move
+	children  map[string]*node  // children keyed by moveKey
+}
+
+// MonteCarloInputSource source of input for a character which chooses its next move using Monte Carlo tree search,
+// optionally accepting a configuration, a rules evaluator, and a randomizer.
+//
+// The player can't see the order of the draw pile or, in adult mode, the opponents' hands, so each iteration
+// starts by sampling that hidden information to build a complete game consistent with the player view.  The
+// iteration then walks down the tree, choosing among the moves that are legal in the sampled game using UCB1,
+// where each player chooses the move that is best for them.  Different samples allow different moves, so a move's
+// exploration term counts the visits when it was legal rather than all visits to its parent (information set
+// MCTS).  The first move without a node is added to the tree, the rest of the game is played out using the rollout
+// policy, and the result is backed up through every node on the path.  Once the budget is spent, the legal move
+// with the most visits is chosen.  A playout that reaches the turn limit is scored with the calculator, so the
+// default budget stays small enough to choose a move quickly.
+func MonteCarloInputSource(config *MonteCarloConfig, evaluator rules.Rules, randomizer random.Randomizer) CharacterInputSource {
+	if evaluator == nil {
+		evaluator = rules.NewRules(nil)
+	}
+
+	if randomizer == nil {
+		randomizer = random.NewRandomizer()
+	}
+
+	var c MonteCarloConfig
+	if config != nil {
+		c = *config
+	}
+
+	if c.Iterations < 1 {
+		c.Iterations = 30
+	}
+
+	if c.Exploration <= 0 {
+		c.Exploration = math.Sqrt2
+	}
+
+	if c.PlayoutTurns < 1 {
+		c.PlayoutTurns = 10
+	}
+
+	if c.Rollout == nil {
+		c.Rollout = RandomInputSource(randomizer)
+	}
+
+	if c.Calculator == nil {
+		c.Calculator = reward.NewCalculator()
+	}
+
+	return &monteCarloInputSource{
+		config:     c,
+		evaluator:  evaluator,
+		randomizer: randomizer,
+	}
+}
+
+func (s *monteCarloInputSource) Name() string {
+	return "MonteCarloInputSource"
+}
+
+func (s *monteCarloInputSource) ChooseMove(mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	return s.ChooseMoveContext(context.Background(), mode, view, legalMoves)
+}
+
+func (s *monteCarloInputSource) ChooseMoveContext(ctx context.Context, mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	if len(legalMoves) == 0 {
+		return nil, errors.New("no legal moves")
+	}
+
+	if len(legalMoves) == 1 {
+		return legalMoves[0], nil // nothing to search
+	}
+
+	color := view.Player().Color()
+	root := &node{color: color, children: make(map[string]*node)}
+
+	start := time.Now()
+	for i := 0; i < s.config.Iterations; i++ {
+		if s.config.TimeBudget > 0 && time.Since(start) >= s.config.TimeBudget {
+			break
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if err := s.iterate(mode, view, legalMoves, root); err != nil {
+			return nil, err
+		}
+	}
+
+	return bestMove(root, legalMoves), nil
+}
+
+// iterate runs a single iteration of the search against a newly-sampled game, expanding and updating the tree
+func (s *monteCarloInputSource) iterate(mode model.GameMode, view model.PlayerView, legalMoves []model.Move, root *node) error {
+	game, err := sampleGame(mode, view, legalMoves, s.randomizer)
+	if err != nil {
+		return err
+	}
+
+	color := view.Player().Color()
+	order := playOrder(game)
+
+	current := 0
+	for i, c := range order {
+		if c == color {
+			current = i
+		}
+	}
+
+	// walk down the tree until a node is added, the game is completed, or the turn limit is reached
+	path := []*node{root}
+	parent, moves, turns := root, legalMoves, 0
+	for {
+		child, move, expanded := s.selectChild(parent, order[current], moves)
+		path = append(path, child)
+
+		again, err := playMove(mode, s.evaluator, game, order[current], move)
+		if err != nil {
+			return err
+		}
+
+		if !again {
+			current = (current + 1) % len(order)
+		}
+
+		if expanded || game.Completed() || turns >= s.config.PlayoutTurns {
+			break
+		}
+
+		_, moves, err = s.legalMoves(mode, game, order[current])
+		if err != nil {
+			return err
+		}
+
+		parent = child
+		turns++
+	}
+
+	// play out the rest of the game using the rollout policy
+	for ; turns < s.config.PlayoutTurns && !game.Completed(); turns++ {
+		again, err := s.playTurn(mode, game, order[current])
+		if err != nil {
+			return err
+		}
+
+		if !again {
+			current = (current + 1) % len(order)
+		}
+	}
+
+	scores := make(map[model.PlayerColor]float64, len(order))
+	for _, c := range order {
+		if scores[c], err = s.score(game, c); err != nil {
+			return err
+		}
+	}
+
+	for _, n := range path {
+		n.visits += 1
+		n.total += scores[n.color]
+	}
+
+	return nil
+}
+
+// selectChild selects the move to play from a node among the moves that are legal in the current sample, adding a
+// node for the first move that has never been tried, or otherwise applying UCB1.  Returns the child, the move to
+// play, and whether the child was just added to the tree.
+func (s *monteCarloInputSource) selectChild(parent *node, color model.PlayerColor, moves []model.Move) (*node, model.Move, bool) {
+	var untried model.Move
+	var untriedKey string
+
+	available := make([]*node, 0, len(moves))
+	availableMoves := make([]model.Move, 0, len(moves))
+	for _, move := range moves {
+		key := moveKey(move)
+		child, exists := parent.children[key]
+		if !exists {
+			if untried == nil {
+				untried, untriedKey = move, key
+			}
+			continue
+		}
+
+		if !slices.Contains(available, child) { // moves with the same cards and actions share a node
+			child.available += 1
+			available = append(available, child)
+			availableMoves = append(availableMoves, move)
+		}
+	}
+
+	if untried != nil {
+		child := &node{move: untried, color: color, available: 1, children: make(map[string]*node)}
+		parent.children[untriedKey] = child
+		return child, untried, true
+	}
+
+	selected := 0
+	best := math.Inf(-1)
+	for i, n := range available {
+		value := n.total/float64(n.visits) + s.config.Exploration*math.Sqrt(math.Log(float64(n.available))/float64(n.visits))
+		if value > best {
+			best = value
+			selected = i
+		}
+	}
+
+	return available[selected], availableMoves[selected], false
+}
+
+// bestMove returns the legal move whose node has the most visits, breaking ties by average score
+func bestMove(root *node, legalMoves []model.Move) model.Move {
+	var best model.Move
+	var bestNode *node
+
+	for _, move := range legalMoves {
+		n, exists := root.children[moveKey(move)]
+		if !exists {
+			continue
+		}
+
+		if bestNode == nil || n.visits > bestNode.visits || (n.visits == bestNode.visits && n.total > bestNode.total) {
+			best = move
+			bestNode = n
+		}
+	}
+
+	if best == nil {
+		return legalMoves[0] // the search was stopped before any move was tried
+	}
+
+	return best
+}
+
+// moveKey identifies a move by its card type and actions, so the same move maps to the same node in every sampled
+// game, even though the card and pawn instances differ between samples
+func moveKey(move model.Move) string {
+	key := "none"
+	if move.Card() != nil {
+		key = move.Card().Type().Value()
+	}
+
+	for _, action := range move.Actions() {
+		key += fmt.Sprintf("|%s %s%d %v", action.Type().Value(), action.Pawn().Color().Value(), action.Pawn().Index(), action.Position())
+	}
+
+	return key
+}
+
+// legalMoves draws a card for a color in a simulated game if necessary, and returns the color's view and legal moves
+func (s *monteCarloInputSource) legalMoves(mode model.GameMode, game model.Game, color model.PlayerColor) (model.PlayerView, []model.Move, error) {
+	var card model.Card
+	var err error
+
+	if mode == model.StandardMode {
+		card, err = game.Deck().Draw()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	view, err := game.CreatePlayerView(color)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	moves, err := s.evaluator.ConstructLegalMoves(view, card)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return view, moves, nil
+}
+
+// playTurn plays a single turn for a color in a simulated game, using the rollout policy
+func (s *monteCarloInputSource) playTurn(mode model.GameMode, game model.Game, color model.PlayerColor) (bool, error) {
+	view, moves, err := s.legalMoves(mode, game, color)
+	if err != nil {
+		return false, err
+	}
+
+	move, err := s.config.Rollout.ChooseMove(mode, view, moves)
+	if err != nil {
+		return false, err
+	}
+
+	return playMove(mode, s.evaluator, game, color, move)
+}
+
+// score scores a simulated game for a color: 1 for a win, 0 for a loss, and the scaled reward otherwise
+func (s *monteCarloInputSource) score(game model.Game, color model.PlayerColor) (float64, error) {
+	if game.Completed() {
+		if (*game.Winner()).Color() == color {
+			return 1, nil
+		}
+
+		return 0, nil
+	}
+
+	view, err := game.CreatePlayerView(color)
+	if err != nil {
+		return 0, err
+	}
+
+	low, high := s.config.Calculator.Range(game.PlayerCount())
+	if high <= low {
+		return 0, nil
+	}
+
+	scaled := float64((s.config.Calculator.Calculate(view) - low) / (high - low))
+	return math.Max(0, math.Min(1, scaled)), nil
+}
+
+// sampleGame builds a complete game that is consistent with a player view, filling in hidden information at random.
+// The player's own hand, the cards on the legal moves, and the discard pile are known; every other card is unknown,
+// so it is dealt to the opponents at random in adult mode, and otherwise left in the draw pile.
+func sampleGame(mode model.GameMode, view model.PlayerView, legalMoves []model.Move, randomizer random.Randomizer) (model.Game, error) {
+	player := view.Player()
+
+	game, err := model.NewGame(len(view.Opponents())+1, nil, randomizer)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range append([]model.Player{player}, opponents(view)...) {
+		target, exists := game.Players()[p.Color()]
+		if !exists {
+			return nil, errors.New("view does not match a game")
+		}
+
+		for i, pawn := range p.Pawns() {
+			if err = target.Pawns()[i].Position().MoveToPosition(pawn.Position()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// a known card might not come from a real deck (for instance, in a test), in which case there's nothing to remove
+	known := make([]model.Card, 0, len(player.Hand())+len(legalMoves)+len(view.DiscardPile()))
+	known = append(known, player.Hand()...)
+	known = append(known, view.DiscardPile()...)
+	for _, move := range legalMoves {
+		if move.Card() != nil {
+			known = append(known, move.Card())
+		}
+	}
+
+	for _, card := range known {
+		_ = game.Deck().Remove(card)
+	}
+
+	for _, card := range player.Hand() {
+		game.Players()[player.Color()].AppendToHand(card)
+	}
+
+	// a view built before the card in play was drawn may still show it in the discard pile, if drawing it
+	// reshuffled the discard pile into the draw pile, but that card is about to be played, not discarded
+	inPlay := make(map[string]bool, len(legalMoves))
+	for _, move := range legalMoves {
+		if move.Card() != nil {
+			inPlay[move.Card().Id()] = true
+		}
+	}
+
+	for _, card := range view.DiscardPile() {
+		if inPlay[card.Id()] {
+			continue
+		}
+
+		if err = game.Deck().Discard(card); err != nil {
+			return nil, err
+		}
+	}
+
+	// drawing from the remaining cards deals the opponents a random hand of unknown cards
+	if mode == model.AdultMode {
+		for _, opponent := range opponents(view) {
+			for i := 0; i < model.AdultHand && game.Deck().DrawPileSize() > 0; i++ {
+				card, err := game.Deck().Draw()
+				if err != nil {
+					return nil, err
+				}
+
+				game.Players()[opponent.Color()].AppendToHand(card)
+			}
+		}
+	}
+
+	return game, nil
+}
+
+// playMove plays a move in a simulated game, handling cards the same way the engine does, and returns whether the player draws again
+func playMove(mode model.GameMode, evaluator rules.Rules, game model.Game, color model.PlayerColor, move model.Move) (bool, error) {
+	player := game.Players()[color]
+	card := move.Card()
+
+	if err := evaluator.ExecuteMove(game, player, move); err != nil {
+		return false, err
+	}
+
+	if mode == model.AdultMode {
+		player.RemoveFromHand(card)
+
+		drawn, err := game.Deck().Draw()
+		if err != nil {
+			return false, err
+		}
+
+		player.AppendToHand(drawn)
+	}
+
+	if err := game.Deck().Discard(card); err != nil {
+		return false, err
+	}
+
+	return len(move.Actions()) > 0 && !game.Completed() && evaluator.DrawAgain(card), nil
+}
+
+// playOrder returns the colors in a game in the order that they take turns
+func playOrder(game model.Game) []model.PlayerColor {
+	order := make([]model.PlayerColor, 0, game.PlayerCount())
+
+	for _, color := range model.PlayerColors.Members() {
+		if _, exists := game.Players()[color]; exists {
+			order = append(order, color)
+		}
+	}
+
+	return order
+}
+
+// opponents returns the opponents in a player view, in color order
+func opponents(view model.PlayerView) []model.Player {
+	result := make([]model.Player, 0, len(view.Opponents()))
+
+	// range on a map explicitly does *not* return keys in a stable order, so we iterate on colors instead
+	for _, color := range model.PlayerColors.Members() {
+		if opponent, exists := view.Opponents()[color]; exists {
+			result = append(result, opponent)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,323 @@
+package source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonteCarloInputSourceName(t *testing.T) {
+	obj := MonteCarloInputSource(nil, nil, nil)
+	assert.Equal(t, "MonteCarloInputSource", obj.Name())
+}
+
+func TestMonteCarloInputSourceDefaults(t *testing.T) {
+	obj := MonteCarloInputSource(nil, nil, nil).(*monteCarloInputSource)
+	assert.Equal(t, 30, obj.config.Iterations)
+	assert.Equal(t, time.Duration(0), obj.config.TimeBudget)
+	assert.InDelta(t, 1.414, obj.config.Exploration, 0.001)
+	assert.Equal(t, 10, obj.config.PlayoutTurns)
+	assert.Equal(t, "RandomInputSource", obj.config.Rollout.Name())
+	assert.NotNil(t, obj.config.Calculator)
+}
+
+func TestMonteCarloInputSourceChooseMoveTrivial(t *testing.T) {
+	obj := MonteCarloInputSource(nil, nil, nil)
+
+	_, err := obj.ChooseMove(model.StandardMode, nil, []model.Move{})
+	assert.EqualError(t, err, "no legal moves")
+
+	// with only one legal move, there is nothing to search
+	move := model.NewMove(model.NewCard("1", model.Card1), nil, nil)
+	result, err := obj.ChooseMove(model.StandardMode, nil, []model.Move{move})
+	assert.NoError(t, err)
+	assert.Same(t, move, result)
+}
+
+func TestMonteCarloInputSourceChooseMoveWinning(t *testing.T) {
+	game, view, moves := setupNearlyWon(t)
+	assert.Equal(t, 2, len(moves))
+
+	// playing the 2 wins immediately, while playing the 1 gives Yellow a chance to win first
+	config := &MonteCarloConfig{Iterations: 50, Rollout: RewardInputSource(nil, nil)}
+	obj := MonteCarloInputSource(config, nil, random.NewSeededRandomizer(42))
+	result, err := obj.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Card2, result.Card().Type())
+
+	// the search works on sampled copies, so the real game is untouched
+	assert.NoError(t, model.ValidateGame(game))
+	assert.Equal(t, 3, *game.Players()[model.Red].Pawns()[3].Position().Safe())
+}
+
+func TestMonteCarloInputSourceChooseMoveSeeded(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	config := &MonteCarloConfig{Iterations: 20}
+	first := MonteCarloInputSource(config, nil, random.NewSeededRandomizer(99))
+	second := MonteCarloInputSource(config, nil, random.NewSeededRandomizer(99))
+
+	// the same seed always searches the same way and chooses the same move
+	result1, err := first.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	result2, err := second.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, result1, result2)
+}
+
+func TestMonteCarloInputSourceChooseMoveTimeBudget(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	// the time budget stops the search long before the iteration budget would
+	config := &MonteCarloConfig{Iterations: 1_000_000, TimeBudget: 50 * time.Millisecond}
+	obj := MonteCarloInputSource(config, nil, nil)
+
+	start := time.Now()
+	result, err := obj.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Contains(t, moves, result)
+}
+
+func TestMonteCarloInputSourceChooseMoveContext(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	config := &MonteCarloConfig{Iterations: 1_000_000}
+	obj := MonteCarloInputSource(config, nil, nil).(ContextInputSource)
+
+	// the search is abandoned once the context is done, no matter how many iterations remain
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := obj.ChooseMoveContext(ctx, model.AdultMode, view, moves)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestMonteCarloInputSourceTree(t *testing.T) {
+	game, _ := model.NewGame(2, nil, random.NewSeededRandomizer(3))
+	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToSquare(10)
+	_ = game.Players()[model.Yellow].Pawns()[0].Position().MoveToSquare(40)
+	card, _ := game.Deck().Draw()
+	view, _ := game.CreatePlayerView(model.Red)
+	moves, _ := rules.NewRules(nil).ConstructLegalMoves(view, card)
+
+	obj := MonteCarloInputSource(nil, nil, random.NewSeededRandomizer(42)).(*monteCarloInputSource)
+	root := &node{color: model.Red, children: make(map[string]*node)}
+	for i := 0; i < 200; i++ {
+		assert.NoError(t, obj.iterate(model.StandardMode, view, moves, root))
+	}
+
+	// every legal move is tried, and the tree grows past the current move, through the other player's turns
+	assert.Equal(t, 200, root.visits)
+	for _, move := range moves {
+		assert.Contains(t, root.children, moveKey(move))
+	}
+
+	depth, colors := treeDepth(root), make(map[model.PlayerColor]bool)
+	collectColors(root, colors)
+	assert.Greater(t, depth, 2)
+	assert.True(t, colors[model.Yellow])
+
+	// every playout through a node is backed up through its parent, and a move is only ever available as often as its parent is visited
+	checkTree(t, root)
+}
+
+func TestMoveKey(t *testing.T) {
+	game, _ := model.NewGame(2, nil, nil)
+	pawn := game.Players()[model.Red].Pawns()[0]
+	position := model.NewPosition(false, false, nil, nil)
+	_ = position.MoveToSquare(4)
+
+	move1 := model.NewMove(model.NewCard("1", model.Card4), []model.Action{model.NewAction(model.MoveToPosition, pawn, position)}, nil)
+	move2 := model.NewMove(model.NewCard("2", model.Card4), []model.Action{model.NewAction(model.MoveToPosition, pawn.Copy(), position.Copy())}, nil)
+	move3 := model.NewMove(model.NewCard("3", model.Card5), []model.Action{model.NewAction(model.MoveToPosition, pawn, position)}, nil)
+	move4 := model.NewMove(model.NewCard("4", model.Card4), []model.Action{model.NewAction(model.MoveToStart, pawn, nil)}, nil)
+
+	// the same card type and actions make the same move, regardless of which card or pawn instance is used
+	assert.Equal(t, moveKey(move1), moveKey(move2))
+	assert.NotEqual(t, moveKey(move1), moveKey(move3))
+	assert.NotEqual(t, moveKey(move1), moveKey(move4))
+}
+
+func TestSampleGameAdult(t *testing.T) {
+	game, view, moves := setupNearlyWon(t)
+
+	for i := 0; i < 10; i++ {
+		sampled, err := sampleGame(model.AdultMode, view, moves, nil)
+		assert.NoError(t, err)
+
+		// every card is accounted for, the player's own hand is known, and opponents are dealt a full hand
+		assert.NoError(t, model.ValidateGame(sampled))
+		assert.Equal(t, game.Players()[model.Red].Hand(), sampled.Players()[model.Red].Hand())
+		assert.Equal(t, model.AdultHand, len(sampled.Players()[model.Yellow].Hand()))
+		assert.Equal(t, len(view.DiscardPile()), sampled.Deck().DiscardPileSize())
+
+		for _, color := range []model.PlayerColor{model.Red, model.Yellow} {
+			for i, pawn := range game.Players()[color].Pawns() {
+				assert.Equal(t, pawn.Position(), sampled.Players()[color].Pawns()[i].Position())
+			}
+		}
+	}
+}
+
+func TestSampleGameStandard(t *testing.T) {
+	game, _ := model.NewGame(3, nil, nil)
+	_ = game.Players()[model.Green].Pawns()[2].Position().MoveToSquare(17)
+	card, _ := game.Deck().Draw()
+	view, _ := game.CreatePlayerView(model.Red)
+	moves, _ := rules.NewRules(nil).ConstructLegalMoves(view, card)
+
+	sampled, err := sampleGame(model.StandardMode, view, moves, nil)
+	assert.NoError(t, err)
+
+	// the card in play has already been drawn, so it's the only card missing from the deck
+	assert.Equal(t, model.DeckSize-1, len(sampled.Deck().Cards()))
+	for _, c := range sampled.Deck().Cards() {
+		assert.NotEqual(t, card.Id(), c.Id())
+	}
+	assert.Equal(t, 17, *sampled.Players()[model.Green].Pawns()[2].Position().Square())
+}
+
+func TestSampleGameDiscards(t *testing.T) {
+	game, _ := model.NewGame(3, nil, random.NewSeededRandomizer(7))
+	evaluator := rules.NewRules(nil)
+	assert.NoError(t, evaluator.StartGame(game, model.AdultMode))
+	for i := 0; i < 12; i++ {
+		card, _ := game.Deck().Draw()
+		_ = game.Deck().Discard(card)
+	}
+
+	view, _ := game.CreatePlayerView(model.Red)
+	moves, _ := evaluator.ConstructLegalMoves(view, nil)
+
+	discarded := make(map[string]bool)
+	for _, card := range view.DiscardPile() {
+		discarded[card.Id()] = true
+	}
+
+	// a discarded card is face up, so it is never dealt to an opponent or left in the draw pile
+	for i := 0; i < 20; i++ {
+		sampled, err := sampleGame(model.AdultMode, view, moves, random.NewSeededRandomizer(int64(i)))
+		assert.NoError(t, err)
+		assert.NoError(t, model.ValidateGame(sampled))
+		assert.Equal(t, view.DiscardPile(), sampled.Deck().DiscardPile())
+
+		for _, color := range []model.PlayerColor{model.Yellow, model.Green} {
+			for _, card := range sampled.Players()[color].Hand() {
+				assert.False(t, discarded[card.Id()], card.Id())
+			}
+		}
+
+		for sampled.Deck().DrawPileSize() > 0 {
+			card, _ := sampled.Deck().Draw()
+			assert.False(t, discarded[card.Id()], card.Id())
+		}
+	}
+}
+
+func TestSampleGameReshuffled(t *testing.T) {
+	game, _ := model.NewGame(2, nil, random.NewSeededRandomizer(29))
+	evaluator := rules.NewRules(nil)
+	assert.NoError(t, evaluator.StartGame(game, model.StandardMode))
+	for game.Deck().DrawPileSize() > 0 {
+		card, _ := game.Deck().Draw()
+		_ = game.Deck().Discard(card)
+	}
+
+	// the view was built before the draw, so the card in play is still in its discard pile
+	view, _ := game.CreatePlayerView(model.Red)
+	card, _ := game.Deck().Draw()
+	assert.Contains(t, view.DiscardPile(), card)
+	moves, _ := evaluator.ConstructLegalMoves(view, card)
+
+	sampled, err := sampleGame(model.StandardMode, view, moves, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, model.DeckSize-1, len(sampled.Deck().Cards()))
+	assert.NotContains(t, sampled.Deck().DiscardPile(), card)
+
+	_, err = playMove(model.StandardMode, evaluator, sampled, model.Red, moves[0])
+	assert.NoError(t, err)
+}
+
+// setupNearlyWon sets up an adult-mode game where Red can win immediately by playing a 2 rather than a 1
+func setupNearlyWon(t *testing.T) (model.Game, model.PlayerView, []model.Move) {
+	game, _ := model.NewGame(2, nil, random.NewSeededRandomizer(42))
+	red := game.Players()[model.Red]
+	yellow := game.Players()[model.Yellow]
+
+	for i := 0; i < 3; i++ {
+		_ = red.Pawns()[i].Position().MoveToHome()
+		_ = yellow.Pawns()[i].Position().MoveToHome()
+	}
+	_ = red.Pawns()[3].Position().MoveToSafe(3)
+	_ = yellow.Pawns()[3].Position().MoveToSafe(4)
+
+	// deal out the cards so the game is consistent, with Red holding exactly one 1 and one 2
+	var card1, card2 model.Card
+	others := make([]model.Card, 0)
+	for game.Deck().DrawPileSize() > 0 {
+		card, _ := game.Deck().Draw()
+		if card.Type() == model.Card1 && card1 == nil {
+			card1 = card
+		} else if card.Type() == model.Card2 && card2 == nil {
+			card2 = card
+		} else {
+			others = append(others, card)
+		}
+	}
+
+	red.AppendToHand(card1)
+	red.AppendToHand(card2)
+	for _, card := range others {
+		if card.Type() == model.Card1 || card.Type() == model.Card2 || len(yellow.Hand()) == model.AdultHand {
+			_ = game.Deck().Discard(card)
+		} else {
+			yellow.AppendToHand(card)
+		}
+	}
+
+	view, _ := game.CreatePlayerView(model.Red)
+	moves, err := rules.NewRules(nil).ConstructLegalMoves(view, nil)
+	assert.NoError(t, err)
+
+	return game, view, moves
+}
+
+// treeDepth returns the depth of the deepest node in a search tree
+func treeDepth(n *node) int {
+	depth := 0
+	for _, child := range n.children {
+		depth = max(depth, treeDepth(child)+1)
+	}
+
+	return depth
+}
+
+// collectColors collects the colors of the players that play the moves in a search tree
+func collectColors(n *node, colors map[model.PlayerColor]bool) {
+	for _, child := range n.children {
+		colors[child.color] = true
+		collectColors(child, colors)
+	}
+}
+
+// checkTree checks the visit and availability counts throughout a search tree
+func checkTree(t *testing.T, n *node) {
+	visits := 0
+	for _, child := range n.children {
+		assert.Greater(t, child.visits, 0)
+		assert.LessOrEqual(t, child.visits, child.available)
+		assert.LessOrEqual(t, child.available, n.visits)
+		visits += child.visits
+		checkTree(t, child)
+	}
+
+	assert.LessOrEqual(t, visits, n.visits)
+}
@@ -0,0 +1,306 @@
+package source
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/pronovic/go-apologies/internal/jsonutil"
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+)
+
+// maxReply is the longest reply line that is accepted from a process
+const maxReply = 64 * 1024
+
+// waitDelay is how long to wait for anything else holding a stopped process's standard error before giving up on it
+const waitDelay = time.Second
+
+// ProcessConfig Configuration for a process input source, where zero values select the defaults.
+type ProcessConfig struct {
+	// Timeout The maximum time to wait for the process to reply to a request (default 10 seconds)
+	Timeout time.Duration
+
+	// Dir The working directory for the process (default the current directory)
+	Dir string
+
+	// Env Additional environment variables for the process, in the form "key=value"
+	Env []string
+
+	// Stderr Receives anything that the process writes to standard error (default discarded)
+	Stderr io.Writer
+}
+
+// ProcessRequest A request sent to a process, asking it to choose a move.
+type ProcessRequest struct {
+	// Id Identifies the request, and must be echoed back in the reply
+	Id int `json:"id"`
+
+	// Mode The mode of the game being played
+	Mode model.GameMode `json:"mode"`
+
+	// View The game from the perspective of the player that is choosing a move
+	View model.PlayerView `json:"view"`
+
+	// Moves The legal moves, from which the process must choose one by index
+	Moves []model.Move `json:"moves"`
+}
+
+// NewProcessRequestFromJSON constructs a new process request from JSON in an io.Reader, for bots written in Go
+func NewProcessRequestFromJSON(reader io.Reader) (ProcessRequest, error) {
+	type raw struct {
+		Id    int               `json:"id"`
+		Mode  model.GameMode    `json:"mode"`
+		View  json.RawMessage   `json:"view"`
+		Moves []json.RawMessage `json:"moves"`
+	}
+
+	var temp raw
+	err := json.NewDecoder(reader).Decode(&temp)
+	if err != nil {
+		return ProcessRequest{}, err
+	}
+
+	view, err := jsonutil.DecodeInterfaceJSON(temp.View, model.NewPlayerViewFromJSON)
+	if err != nil {
+		return ProcessRequest{}, err
+	}
+
+	moves, err := jsonutil.DecodeSliceJSON(temp.Moves, model.NewMoveFromJSON)
+	if err != nil {
+		return ProcessRequest{}, err
+	}
+
+	return ProcessRequest{Id: temp.Id, Mode: temp.Mode, View: view, Moves: moves}, nil
+}
+
+// ProcessReply A reply from a process, identifying the move that it chose.
+type ProcessReply struct {
+	// Id The id of the request that this is a reply to
+	Id int `json:"id"`
+
+	// Index The index of the chosen move among the legal moves in the request
+	Index *int `json:"index"`
+}
+
+type processInputSource struct {
+	name       string
+	args       []string
+	config     ProcessConfig
+	randomizer random.Randomizer
+	lock       sync.Mutex
+	process    *process // nil until the first request, and after the process is stopped
+	requests   int
+}
+
+// process is a running subprocess, along with the lines that it has written to standard output
+type process struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	stdout  io.ReadCloser
+	replies chan []byte   // closed once standard output is closed, normally because the process exited
+	done    chan struct{} // closed when the process is stopped, so the reader can give up
+}
+
+// ProcessInputSource source of input for a character which asks a program running in a subprocess to choose its moves,
+// optionally accepting a configuration and a randomizer.
+//
+// This makes it possible to write a bot in any language, with the engine acting as referee.  The program is started
+// on the first request, and then talks to the source using line-delimited JSON: for each move, the source writes a
+// ProcessRequest on a single line to the program's standard input, and the program writes a ProcessReply on a single
+// line to its standard output, like {"id": 1, "index": 0}.  A reply with the id of an earlier request is ignored.
+//
+// The program can't stall or break the game.  If it crashes, does not reply within the timeout, or replies with
+// something other than the index of a legal move, then a legal move is chosen at random instead.  A program that
+// crashes or times out is stopped, and a new one is started on the next request.  On platforms with process groups,
+// the program is stopped along with anything that it started, so a bot can be run by a wrapper like a shell script.
+// The source must be closed once the game is over, to stop the program.
+func ProcessInputSource(name string, args []string, config *ProcessConfig, randomizer random.Randomizer) ExternalInputSource {
+	if randomizer == nil {
+		randomizer = random.NewRandomizer()
+	}
+
+	var c ProcessConfig
+	if config != nil {
+		c = *config
+	}
+
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+
+	return &processInputSource{
+		name:       name,
+		args:       args,
+		config:     c,
+		randomizer: randomizer,
+	}
+}
+
+func (s *processInputSource) Name() string {
+	return "ProcessInputSource"
+}
+
+func (s *processInputSource) ChooseMove(mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	return s.ChooseMoveContext(context.Background(), mode, view, legalMoves)
+}
+
+func (s *processInputSource) ChooseMoveContext(ctx context.Context, mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	if len(legalMoves) == 0 {
+		return nil, errors.New("no legal moves")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.process == nil {
+		var err error
+		if s.process, err = s.start(); err != nil {
+			return nil, err
+		}
+	}
+
+	s.requests += 1
+	request, err := json.Marshal(ProcessRequest{Id: s.requests, Mode: mode, View: view, Moves: legalMoves})
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := s.exchange(ctx, append(request, '\n'))
+	if err != nil {
+		s.stop()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		return random.Choice(s.randomizer, legalMoves)
+	}
+
+	if index < 0 || index >= len(legalMoves) {
+		return random.Choice(s.randomizer, legalMoves)
+	}
+
+	return legalMoves[index], nil
+}
+
+// exchange sends a request to the process and waits for the reply, returning the chosen index, or -1 if the reply is malformed.
+// An error means that the process crashed or timed out, or that the context was done.
+func (s *processInputSource) exchange(ctx context.Context, request []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	// a process that isn't reading its input blocks the write, so the write needs to be interruptible too
+	written := make(chan error, 1)
+	go func(stdin io.Writer) {
+		_, err := stdin.Write(request)
+		written <- err
+	}(s.process.stdin)
+
+	for {
+		select {
+		case err := <-written:
+			if err != nil {
+				return 0, err
+			}
+		case line, ok := <-s.process.replies:
+			if !ok {
+				return 0, errors.New("process exited")
+			}
+
+			var reply ProcessReply
+			if err := json.Unmarshal(line, &reply); err != nil {
+				return -1, nil
+			}
+
+			if reply.Id == s.requests {
+				if reply.Index == nil {
+					return -1, nil
+				}
+
+				return *reply.Index, nil
+			}
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// start starts the process, with a goroutine that passes along each line that it writes to standard output
+func (s *processInputSource) start() (*process, error) {
+	cmd := exec.Command(s.name, s.args...)
+	cmd.Dir = s.config.Dir
+	cmd.Env = append(os.Environ(), s.config.Env...)
+	cmd.Stderr = s.config.Stderr
+	cmd.WaitDelay = waitDelay
+	isolate(cmd)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := &process{cmd: cmd, stdin: stdin, stdout: stdout, replies: make(chan []byte), done: make(chan struct{})}
+
+	go func() {
+		defer close(p.replies)
+
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 4096), maxReply)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case p.replies <- line:
+			case <-p.done:
+				return
+			}
+		}
+	}()
+
+	return p, nil
+}
+
+// stop kills the process, if it is running, and waits for it to exit.  The process is killed along with anything
+// that it started, like the program run by a wrapper script, but something might still be holding standard output
+// open, so it is closed here rather than left for the reader to see the end of it.  Once the reader is finished
+// with standard output, the process is waited on.
+func (s *processInputSource) stop() {
+	if s.process == nil {
+		return
+	}
+
+	close(s.process.done)
+	_ = s.process.stdin.Close()
+	_ = kill(s.process.cmd)
+	_ = s.process.stdout.Close()
+
+	// the reader gives up rather than passing along anything else, and closes replies once it has returned
+	for range s.process.replies {
+	}
+
+	_ = s.process.cmd.Wait()
+	s.process = nil
+}
+
+// Close stops the process, if it is running
+func (s *processInputSource) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.stop()
+	return nil
+}
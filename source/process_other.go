@@ -0,0 +1,16 @@
+//go:build !unix
+
+package source
+
+import (
+	"os/exec"
+)
+
+// isolate does nothing, since process groups are not available on this platform
+func isolate(_ *exec.Cmd) {
+}
+
+// kill kills the process, but not anything that it starts, since process groups are not available on this platform
+func kill(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,281 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProcessInputSourceHelper is not a real test, but the bot that is run as a subprocess by the other tests.
+// The behavior of the bot is selected by the PROCESS_HELPER environment variable.
+func TestProcessInputSourceHelper(t *testing.T) {
+	behavior := os.Getenv("PROCESS_HELPER")
+	if behavior == "" {
+		return
+	}
+
+	// a wrapper runs the real bot as a child, like a shell script or npm script, which keeps standard output open
+	if behavior == "wrapper" {
+		child := exec.Command(os.Args[0], "-test.run=^TestProcessInputSourceHelper$")
+		child.Env = append(os.Environ(), "PROCESS_HELPER=hang")
+		child.Stdin, child.Stdout, child.Stderr = os.Stdin, os.Stdout, os.Stderr
+		_ = child.Run()
+		os.Exit(0)
+	}
+
+	reply := func(id int, index *int) {
+		marshalled, _ := json.Marshal(ProcessReply{Id: id, Index: index})
+		fmt.Println(string(marshalled))
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 0, 4096), 1024*1024)
+	for scanner.Scan() {
+		request, err := NewProcessRequestFromJSON(bytes.NewReader(scanner.Bytes()))
+		if err != nil {
+			os.Exit(2)
+		}
+
+		_, _ = fmt.Fprintf(os.Stderr, "%d %s %s\n", request.Id, request.Mode.Value(), request.View.Player().Color().Value())
+
+		zero, last, invalid := 0, len(request.Moves)-1, len(request.Moves)
+		switch behavior {
+		case "last":
+			reply(request.Id, &last)
+		case "stale":
+			reply(request.Id-1, &zero)
+			reply(request.Id, &last)
+		case "once":
+			reply(request.Id, &last)
+			os.Exit(0)
+		case "garbage":
+			fmt.Println("hello")
+		case "missing":
+			reply(request.Id, nil)
+		case "range":
+			reply(request.Id, &invalid)
+		case "crash":
+			os.Exit(3)
+		case "hang":
+			time.Sleep(time.Hour)
+		}
+	}
+
+	os.Exit(0)
+}
+
+// helper returns a process input source that runs the helper bot with a behavior
+func helper(behavior string, stderr io.Writer, timeout time.Duration, seed int64) ExternalInputSource {
+	config := &ProcessConfig{Timeout: timeout, Env: []string{"PROCESS_HELPER=" + behavior}, Stderr: stderr}
+	return ProcessInputSource(os.Args[0], []string{"-test.run=^TestProcessInputSourceHelper$"}, config, random.NewSeededRandomizer(seed))
+}
+
+// fallback returns the moves that a seeded random input source chooses, which are what a process input source falls back on
+func fallback(seed int64, view model.PlayerView, moves []model.Move, count int) []model.Move {
+	input := RandomInputSource(random.NewSeededRandomizer(seed))
+	result := make([]model.Move, 0, count)
+	for i := 0; i < count; i++ {
+		move, _ := input.ChooseMove(model.AdultMode, view, moves)
+		result = append(result, move)
+	}
+
+	return result
+}
+
+func TestProcessInputSourceName(t *testing.T) {
+	obj := ProcessInputSource("bot", nil, nil, nil)
+	assert.Equal(t, "ProcessInputSource", obj.Name())
+}
+
+func TestProcessInputSourceDefaults(t *testing.T) {
+	obj := ProcessInputSource("bot", []string{"-v"}, nil, nil).(*processInputSource)
+	assert.Equal(t, "bot", obj.name)
+	assert.Equal(t, []string{"-v"}, obj.args)
+	assert.Equal(t, 10*time.Second, obj.config.Timeout)
+	assert.NotNil(t, obj.randomizer)
+	assert.Nil(t, obj.process)
+}
+
+func TestProcessRequestJSON(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+	request := ProcessRequest{Id: 3, Mode: model.AdultMode, View: view, Moves: moves}
+
+	marshalled, err := json.Marshal(request)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(marshalled), "\n")
+
+	unmarshalled, err := NewProcessRequestFromJSON(bytes.NewReader(marshalled))
+	assert.NoError(t, err)
+	assert.Equal(t, request, unmarshalled)
+}
+
+func TestProcessInputSourceChooseMove(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	var stderr bytes.Buffer
+	obj := helper("last", &stderr, 0, 1)
+
+	// the same process answers every request
+	for i := 0; i < 3; i++ {
+		result, err := obj.ChooseMove(model.AdultMode, view, moves)
+		assert.NoError(t, err)
+		assert.Same(t, moves[len(moves)-1], result)
+	}
+
+	// the process is only waited on once the reader has finished with its output
+	running := obj.(*processInputSource).process
+	assert.NoError(t, obj.Close())
+	assert.Nil(t, obj.(*processInputSource).process)
+	_, open := <-running.replies
+	assert.False(t, open)
+	assert.NotNil(t, running.cmd.ProcessState)
+	assert.Equal(t, "1 AdultMode Red\n2 AdultMode Red\n3 AdultMode Red\n", stderr.String())
+
+	// closing again does nothing
+	assert.NoError(t, obj.Close())
+}
+
+func TestProcessInputSourceChooseMoveStale(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	obj := helper("stale", nil, 0, 1)
+	defer obj.Close()
+
+	result, err := obj.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, moves[len(moves)-1], result)
+}
+
+func TestProcessInputSourceChooseMoveMalformed(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	for _, behavior := range []string{"garbage", "missing", "range"} {
+		obj := helper(behavior, nil, 0, 42)
+		expected := fallback(42, view, moves, 5)
+
+		for i := 0; i < 5; i++ {
+			result, err := obj.ChooseMove(model.AdultMode, view, moves)
+			assert.NoError(t, err, behavior)
+			assert.Same(t, expected[i], result, behavior)
+		}
+
+		// a malformed reply does not stop the process
+		assert.NotNil(t, obj.(*processInputSource).process, behavior)
+		assert.NoError(t, obj.Close())
+	}
+}
+
+func TestProcessInputSourceChooseMoveCrash(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	var stderr bytes.Buffer
+	obj := helper("once", &stderr, 0, 42)
+	defer obj.Close()
+	expected := fallback(42, view, moves, 1)
+
+	// the process exits after its first reply, so the second request falls back, and the third starts a new process
+	result, err := obj.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, moves[len(moves)-1], result)
+
+	result, err = obj.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, expected[0], result)
+	assert.Nil(t, obj.(*processInputSource).process)
+
+	result, err = obj.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, moves[len(moves)-1], result)
+
+	assert.NoError(t, obj.Close())
+	assert.Equal(t, "1 AdultMode Red\n3 AdultMode Red\n", stderr.String())
+}
+
+func TestProcessInputSourceChooseMoveExit(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	obj := helper("crash", nil, 0, 42)
+	defer obj.Close()
+	expected := fallback(42, view, moves, 2)
+
+	for i := 0; i < 2; i++ {
+		result, err := obj.ChooseMove(model.AdultMode, view, moves)
+		assert.NoError(t, err)
+		assert.Same(t, expected[i], result)
+		assert.Nil(t, obj.(*processInputSource).process)
+	}
+}
+
+func TestProcessInputSourceChooseMoveTimeout(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	obj := helper("hang", nil, 100*time.Millisecond, 42)
+	defer obj.Close()
+	expected := fallback(42, view, moves, 1)
+
+	start := time.Now()
+	result, err := obj.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, expected[0], result)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// a process that times out is stopped, since it might still be working on the old request
+	assert.Nil(t, obj.(*processInputSource).process)
+}
+
+func TestProcessInputSourceChooseMoveTimeoutWrapper(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	obj := helper("wrapper", nil, 200*time.Millisecond, 42)
+	defer obj.Close()
+	expected := fallback(42, view, moves, 1)
+
+	// the bot that is still holding standard output open does not hold up the fallback
+	start := time.Now()
+	result, err := obj.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, expected[0], result)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Nil(t, obj.(*processInputSource).process)
+}
+
+func TestProcessInputSourceChooseMoveContextCancelled(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	obj := helper("hang", nil, 0, 42).(ContextInputSource)
+	defer obj.(ExternalInputSource).Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// the caller gave up, so there is no move at all
+	_, err := obj.ChooseMoveContext(ctx, model.AdultMode, view, moves)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, obj.(*processInputSource).process)
+}
+
+func TestProcessInputSourceChooseMoveErrors(t *testing.T) {
+	_, view, moves := setupNearlyWon(t)
+
+	obj := helper("last", nil, 0, 42)
+	_, err := obj.ChooseMove(model.AdultMode, view, []model.Move{})
+	assert.EqualError(t, err, "no legal moves")
+
+	// a program that can't be started is a configuration problem, not something to play around
+	obj = ProcessInputSource("/nonexistent/bot", nil, nil, nil)
+	_, err = obj.ChooseMove(model.AdultMode, view, moves)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "/nonexistent/bot"))
+}
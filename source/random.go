@@ -1,15 +1,23 @@
 package source
 
 import (
-	"github.com/pronovic/go-apologies/internal/randomutil"
 	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
 )
 
-type randomInputSource struct{}
+type randomInputSource struct {
+	randomizer random.Randomizer
+}
+
+// RandomInputSource source of input for a character which chooses randomly from among legal moves, optionally accepting a randomizer.
+func RandomInputSource(randomizer random.Randomizer) CharacterInputSource {
+	if randomizer == nil {
+		randomizer = random.NewRandomizer()
+	}
 
-// RandomInputSource source of input for a character which chooses randomly from among legal moves.
-func RandomInputSource() CharacterInputSource {
-	return &randomInputSource{}
+	return &randomInputSource{
+		randomizer: randomizer,
+	}
 }
 
 func (s *randomInputSource) Name() string {
@@ -17,5 +25,5 @@ func (s *randomInputSource) Name() string {
 }
 
 func (s *randomInputSource) ChooseMove(_ model.GameMode, _ model.PlayerView, legalMoves []model.Move) (model.Move, error) {
-	return randomutil.RandomChoice(legalMoves)
+	return random.Choice(s.randomizer, legalMoves)
 }
@@ -4,16 +4,17 @@ import (
 	"testing"
 
 	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestRandomInputSourceName(t *testing.T) {
-	obj := RandomInputSource()
+	obj := RandomInputSource(nil)
 	assert.Equal(t, "RandomInputSource", obj.Name())
 }
 
 func TestRandomInputSourceChooseMove(t *testing.T) {
-	obj := RandomInputSource()
+	obj := RandomInputSource(nil)
 
 	move1 := model.MockMove{}
 	move2 := model.MockMove{}
@@ -26,3 +27,21 @@ func TestRandomInputSourceChooseMove(t *testing.T) {
 		assert.True(t, result == &move1 || result == &move2 || result == &move3)
 	}
 }
+
+func TestRandomInputSourceChooseMoveSeeded(t *testing.T) {
+	first := RandomInputSource(random.NewSeededRandomizer(42))
+	second := RandomInputSource(random.NewSeededRandomizer(42))
+
+	move1 := model.MockMove{}
+	move2 := model.MockMove{}
+	move3 := model.MockMove{}
+	moves := []model.Move{&move1, &move2, &move3}
+
+	for i := 0; i < 100; i++ {
+		result1, err := first.ChooseMove(model.AdultMode, nil, moves)
+		assert.NoError(t, err)
+		result2, err := second.ChooseMove(model.AdultMode, nil, moves)
+		assert.NoError(t, err)
+		assert.Same(t, result1, result2)
+	}
+}
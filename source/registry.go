@@ -0,0 +1,106 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/pronovic/go-apologies/random"
+)
+
+// Factory constructs a character input source, optionally accepting a randomizer
+type Factory func(randomizer random.Randomizer) CharacterInputSource
+
+// Registry Maps character input source names back to source implementations, so that a saved game can be resumed.
+// A saved game records only the name of each source, so a source is always reconstructed from its factory.
+type Registry interface {
+	// Register Register a factory for the source with the given name
+	Register(name string, factory Factory) error
+
+	// Lookup Construct a new source for the given name, optionally accepting a randomizer
+	Lookup(name string, randomizer random.Randomizer) (CharacterInputSource, error)
+
+	// Names The names of all registered sources, in sorted order
+	Names() []string
+}
+
+type registry struct {
+	lock      sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry constructs a new Registry, with the built-in sources already registered using their default configuration.
+// Any other settings that a built-in source was using when a game was saved are lost when the game is resumed.
+func NewRegistry() Registry {
+	r := &registry{
+		factories: make(map[string]Factory),
+	}
+
+	_ = r.Register("RandomInputSource", func(randomizer random.Randomizer) CharacterInputSource {
+		return RandomInputSource(randomizer)
+	})
+
+	_ = r.Register("RewardInputSource", func(_ random.Randomizer) CharacterInputSource {
+		return RewardInputSource(nil, nil)
+	})
+
+	_ = r.Register("HandRewardInputSource", func(_ random.Randomizer) CharacterInputSource {
+		return HandRewardInputSource(nil, nil, nil)
+	})
+
+	_ = r.Register("MonteCarloInputSource", func(randomizer random.Randomizer) CharacterInputSource {
+		return MonteCarloInputSource(nil, nil, randomizer)
+	})
+
+	_ = r.Register("ExpectimaxInputSource", func(_ random.Randomizer) CharacterInputSource {
+		return ExpectimaxInputSource(nil, nil)
+	})
+
+	return r
+}
+
+func (r *registry) Register(name string, factory Factory) error {
+	if name == "" {
+		return errors.New("source name is required")
+	}
+
+	if factory == nil {
+		return errors.New("source factory is nil")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, exists := r.factories[name]; exists {
+		return fmt.Errorf("source %s is already registered", name)
+	}
+
+	r.factories[name] = factory
+	return nil
+}
+
+func (r *registry) Lookup(name string, randomizer random.Randomizer) (CharacterInputSource, error) {
+	r.lock.RLock()
+	factory, exists := r.factories[name]
+	r.lock.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("unknown source %s", name)
+	}
+
+	return factory(randomizer), nil
+}
+
+func (r *registry) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+	return names
+}
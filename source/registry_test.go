@@ -0,0 +1,59 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/pronovic/go-apologies/random"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRegistry(t *testing.T) {
+	obj := NewRegistry()
+	assert.Equal(t, []string{"ExpectimaxInputSource", "HandRewardInputSource", "MonteCarloInputSource", "RandomInputSource", "RewardInputSource"}, obj.Names())
+
+	// every built-in source is registered under its own name
+	for _, name := range obj.Names() {
+		result, err := obj.Lookup(name, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, name, result.Name())
+	}
+
+	// built-in sources are always constructed with their default configuration
+	result, err := obj.Lookup("ExpectimaxInputSource", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.(*expectimaxInputSource).config.Depth)
+}
+
+func TestRegistryLookup(t *testing.T) {
+	obj := NewRegistry()
+
+	result, err := obj.Lookup("bogus", nil)
+	assert.EqualError(t, err, "unknown source bogus")
+	assert.Nil(t, result)
+
+	// the randomizer is passed through to the factory
+	randomizer := random.NewSeededRandomizer(42)
+	result, err = obj.Lookup("RandomInputSource", randomizer)
+	assert.NoError(t, err)
+	assert.Same(t, randomizer, result.(*randomInputSource).randomizer)
+}
+
+func TestRegistryRegister(t *testing.T) {
+	obj := NewRegistry()
+	custom := &MockCharacterInputSource{}
+
+	factory := func(_ random.Randomizer) CharacterInputSource {
+		return custom
+	}
+
+	assert.EqualError(t, obj.Register("", factory), "source name is required")
+	assert.EqualError(t, obj.Register("Custom", nil), "source factory is nil")
+	assert.EqualError(t, obj.Register("RandomInputSource", factory), "source RandomInputSource is already registered")
+
+	assert.NoError(t, obj.Register("Custom", factory))
+	assert.Equal(t, []string{"Custom", "ExpectimaxInputSource", "HandRewardInputSource", "MonteCarloInputSource", "RandomInputSource", "RewardInputSource"}, obj.Names())
+
+	result, err := obj.Lookup("Custom", nil)
+	assert.NoError(t, err)
+	assert.Same(t, custom, result)
+}
@@ -1,6 +1,9 @@
 package source
 
 import (
+	"context"
+	"io"
+
 	"github.com/pronovic/go-apologies/model"
 )
 
@@ -22,3 +25,37 @@ type CharacterInputSource interface {
 	// disadvantageous.
 	ChooseMove(mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error)
 }
+
+// ContextInputSource A CharacterInputSource that stops choosing a move when a context is cancelled.
+//
+// Any input source that might take a long time to choose a move, such as a search or a remote
+// player, should implement this interface, so the engine can abandon a turn cleanly.
+type ContextInputSource interface {
+	CharacterInputSource
+
+	// ChooseMoveContext Choose the next move for a character, as for ChooseMove.
+	// If the context is done before a move has been chosen, the context's error is returned.
+	ChooseMoveContext(ctx context.Context, mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error)
+}
+
+// ExternalInputSource A ContextInputSource backed by something outside of this process, such as a subprocess.
+//
+// The source holds on to resources between moves, so it must be closed once it is no longer needed.
+type ExternalInputSource interface {
+	ContextInputSource
+	io.Closer
+}
+
+// ChooseMoveContext chooses a move using any input source, passing along the context if the source accepts one.
+// A source that does not accept a context cannot be interrupted, but is not asked for a move once the context is done.
+func ChooseMoveContext(ctx context.Context, input CharacterInputSource, mode model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if aware, ok := input.(ContextInputSource); ok {
+		return aware.ChooseMoveContext(ctx, mode, view, legalMoves)
+	}
+
+	return input.ChooseMove(mode, view, legalMoves)
+}
@@ -0,0 +1,49 @@
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChooseMoveContextPlain(t *testing.T) {
+	input := &MockCharacterInputSource{}
+	view := &model.MockPlayerView{}
+	move := model.NewMove(model.NewCard("1", model.Card1), nil, nil)
+	legalMoves := []model.Move{move}
+
+	input.On("ChooseMove", model.StandardMode, view, legalMoves).Return(move, nil).Once()
+
+	// a source that does not accept a context is called as usual
+	result, err := ChooseMoveContext(context.Background(), input, model.StandardMode, view, legalMoves)
+	assert.NoError(t, err)
+	assert.Same(t, move, result)
+
+	// but it is not called at all once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ChooseMoveContext(ctx, input, model.StandardMode, view, legalMoves)
+	assert.ErrorIs(t, err, context.Canceled)
+	input.AssertNumberOfCalls(t, "ChooseMove", 1)
+}
+
+func TestChooseMoveContextAware(t *testing.T) {
+	input := &MockContextInputSource{}
+	view := &model.MockPlayerView{}
+	move := model.NewMove(model.NewCard("1", model.Card1), nil, nil)
+	legalMoves := []model.Move{move}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	input.On("ChooseMoveContext", ctx, model.AdultMode, view, legalMoves).Return(move, nil).Once()
+
+	// a source that accepts a context receives it
+	result, err := ChooseMoveContext(ctx, input, model.AdultMode, view, legalMoves)
+	assert.NoError(t, err)
+	assert.Same(t, move, result)
+	input.AssertNotCalled(t, "ChooseMove", mock.Anything, mock.Anything, mock.Anything)
+}
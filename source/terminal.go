@@ -0,0 +1,130 @@
+package source
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pronovic/go-apologies/model"
+)
+
+type terminalInputSource struct {
+	reader *bufio.Reader
+	writer io.Writer
+}
+
+// TerminalInputSource source of input for a human player at a terminal.
+//
+// The legal moves are printed to the writer, one per line and numbered from 1, and the player's choice
+// is read from the reader, one line at a time.  If the player enters anything other than the number of
+// a legal move, the source explains the problem and prompts again.  The reader is buffered internally,
+// so the same reader must not be shared with anything else.
+func TerminalInputSource(reader io.Reader, writer io.Writer) CharacterInputSource {
+	return &terminalInputSource{
+		reader: bufio.NewReader(reader),
+		writer: writer,
+	}
+}
+
+func (s *terminalInputSource) Name() string {
+	return "TerminalInputSource"
+}
+
+func (s *terminalInputSource) ChooseMove(_ model.GameMode, view model.PlayerView, legalMoves []model.Move) (model.Move, error) {
+	if len(legalMoves) == 0 {
+		return nil, errors.New("no legal moves")
+	}
+
+	_, _ = fmt.Fprintf(s.writer, "%s to move\n", view.Player().Color().Value())
+
+	if len(view.Player().Hand()) > 0 {
+		cards := make([]string, 0, len(view.Player().Hand()))
+		for _, card := range view.Player().Hand() {
+			cards = append(cards, cardName(card))
+		}
+
+		_, _ = fmt.Fprintf(s.writer, "Hand: %s\n", strings.Join(cards, ", "))
+	}
+
+	for i, move := range legalMoves {
+		_, _ = fmt.Fprintf(s.writer, "%3d) %s\n", i+1, DescribeMove(view, move))
+	}
+
+	for {
+		_, _ = fmt.Fprintf(s.writer, "Choose a move [1-%d]: ", len(legalMoves))
+
+		line, err := s.reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				return nil, errors.New("input ended before a move was chosen")
+			}
+
+			return nil, err
+		}
+
+		choice, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil || choice < 1 || choice > len(legalMoves) {
+			_, _ = fmt.Fprintf(s.writer, "Invalid choice %q, enter a number from 1 to %d\n", strings.TrimSpace(line), len(legalMoves))
+			continue
+		}
+
+		return legalMoves[choice-1], nil
+	}
+}
+
+// DescribeMove describes a move in readable form, like "Card 7: Red0 square 10→square 14, Red2 square 30→square 33 (bumps Blue1)"
+func DescribeMove(view model.PlayerView, move model.Move) string {
+	prefix := fmt.Sprintf("Card %s: ", cardName(move.Card()))
+
+	if len(move.Actions()) == 0 {
+		return prefix + "forfeit, discarding the card"
+	}
+
+	// an opponent's pawn sent back to start might be either an action or a side effect, depending on the card
+	moved := make([]string, 0, len(move.Actions()))
+	bumped := make([]string, 0, len(move.SideEffects()))
+	for _, action := range move.MergedActions() {
+		if action.Type() == model.MoveToStart && action.Pawn().Color() != view.Player().Color() {
+			bumped = append(bumped, "bumps "+action.Pawn().Name())
+		} else {
+			moved = append(moved, describeAction(view, action))
+		}
+	}
+
+	description := prefix + strings.Join(moved, ", ")
+	if len(bumped) > 0 {
+		description += fmt.Sprintf(" (%s)", strings.Join(bumped, ", "))
+	}
+
+	return description
+}
+
+// describeAction describes a single action, like "Red0 square 10→square 14"
+func describeAction(view model.PlayerView, action model.Action) string {
+	// the pawn in the view has the current position, which the pawn on the action might not
+	from := action.Pawn().Position()
+	if pawn := view.GetPawn(action.Pawn()); pawn != nil {
+		from = pawn.Position()
+	}
+
+	to := "start"
+	if action.Type() == model.MoveToPosition && action.Position() != nil {
+		to = fmt.Sprintf("%s", action.Position())
+	}
+
+	return fmt.Sprintf("%s %s→%s", action.Pawn().Name(), from, to)
+}
+
+// cardName returns the readable name of a card
+func cardName(card model.Card) string {
+	if card == nil {
+		return "none"
+	} else if card.Type() == model.CardApologies {
+		return "Apologies"
+	} else {
+		return card.Type().Value()
+	}
+}
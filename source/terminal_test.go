@@ -0,0 +1,137 @@
+package source
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminalInputSourceName(t *testing.T) {
+	obj := TerminalInputSource(strings.NewReader(""), &bytes.Buffer{})
+	assert.Equal(t, "TerminalInputSource", obj.Name())
+}
+
+func TestTerminalInputSourceChooseMove(t *testing.T) {
+	view, moves := setupTerminal(t)
+
+	var output bytes.Buffer
+	obj := TerminalInputSource(strings.NewReader("2\n"), &output)
+
+	result, err := obj.ChooseMove(model.StandardMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, moves[1], result)
+
+	expected := "Red to move\n" +
+		"  1) Card 7: Red0 square 10→square 17\n" +
+		"  2) Card 7: Red0 square 10→square 13, Red1 square 30→square 34 (bumps Yellow0)\n" +
+		"Choose a move [1-2]: "
+	assert.Equal(t, expected, output.String())
+}
+
+func TestTerminalInputSourceChooseMoveReprompt(t *testing.T) {
+	view, moves := setupTerminal(t)
+
+	var output bytes.Buffer
+	obj := TerminalInputSource(strings.NewReader("\nbogus\n0\n3\n 1 "), &output)
+
+	// bad input is explained and the player is prompted again, and the last line doesn't need a newline
+	result, err := obj.ChooseMove(model.StandardMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, moves[0], result)
+
+	assert.Equal(t, 5, strings.Count(output.String(), "Choose a move [1-2]: "))
+	assert.Contains(t, output.String(), "Invalid choice \"\", enter a number from 1 to 2\n")
+	assert.Contains(t, output.String(), "Invalid choice \"bogus\", enter a number from 1 to 2\n")
+	assert.Contains(t, output.String(), "Invalid choice \"0\", enter a number from 1 to 2\n")
+	assert.Contains(t, output.String(), "Invalid choice \"3\", enter a number from 1 to 2\n")
+}
+
+func TestTerminalInputSourceChooseMoveSequence(t *testing.T) {
+	view, moves := setupTerminal(t)
+	obj := TerminalInputSource(strings.NewReader("2\n1\n"), &bytes.Buffer{})
+
+	// each call reads the next line, even though the reader is buffered
+	result, err := obj.ChooseMove(model.StandardMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, moves[1], result)
+
+	result, err = obj.ChooseMove(model.StandardMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, moves[0], result)
+
+	_, err = obj.ChooseMove(model.StandardMode, view, moves)
+	assert.EqualError(t, err, "input ended before a move was chosen")
+}
+
+func TestTerminalInputSourceChooseMoveAdult(t *testing.T) {
+	game, _ := model.NewGame(2, nil, nil)
+	player := game.Players()[model.Red]
+	player.AppendToHand(model.NewCard("1", model.Card1))
+	player.AppendToHand(model.NewCard("2", model.CardApologies))
+	view, _ := game.CreatePlayerView(model.Red)
+	moves, _ := rules.NewRules(nil).ConstructLegalMoves(view, nil)
+
+	var output bytes.Buffer
+	obj := TerminalInputSource(strings.NewReader("1\n"), &output)
+
+	result, err := obj.ChooseMove(model.AdultMode, view, moves)
+	assert.NoError(t, err)
+	assert.Same(t, moves[0], result)
+	assert.Contains(t, output.String(), "Hand: 1, Apologies\n")
+}
+
+func TestTerminalInputSourceChooseMoveNoMoves(t *testing.T) {
+	obj := TerminalInputSource(strings.NewReader("1\n"), &bytes.Buffer{})
+	_, err := obj.ChooseMove(model.StandardMode, nil, []model.Move{})
+	assert.EqualError(t, err, "no legal moves")
+}
+
+func TestDescribeMove(t *testing.T) {
+	game, _ := model.NewGame(2, nil, nil)
+	view, _ := game.CreatePlayerView(model.Red)
+
+	card := model.NewCard("1", model.Card12)
+	assert.Equal(t, "Card 12: forfeit, discarding the card", DescribeMove(view, model.NewMove(card, nil, nil)))
+
+	pawn := model.NewPawn(model.Red, 3)
+	start := model.NewAction(model.MoveToStart, pawn, nil)
+	assert.Equal(t, "Card 12: Red3 start→start", DescribeMove(view, model.NewMove(card, []model.Action{start}, nil)))
+
+	apologies := model.NewCard("2", model.CardApologies)
+	_ = game.Players()[model.Yellow].Pawns()[1].Position().MoveToSquare(40)
+	view, _ = game.CreatePlayerView(model.Red)
+	moves, _ := rules.NewRules(nil).ConstructLegalMoves(view, apologies)
+	assert.Equal(t, "Card Apologies: Red0 start→square 40 (bumps Yellow1)", DescribeMove(view, moves[0]))
+}
+
+// setupTerminal sets up a position where Red has two ways to play a 7, one of which bumps Yellow
+func setupTerminal(t *testing.T) (model.PlayerView, []model.Move) {
+	game, _ := model.NewGame(2, nil, nil)
+	_ = game.Players()[model.Red].Pawns()[0].Position().MoveToSquare(10)
+	_ = game.Players()[model.Red].Pawns()[1].Position().MoveToSquare(30)
+	_ = game.Players()[model.Yellow].Pawns()[0].Position().MoveToSquare(34)
+	view, _ := game.CreatePlayerView(model.Red)
+
+	card := model.NewCard("1", model.Card7)
+	red0 := view.Player().Pawns()[0]
+	red1 := view.Player().Pawns()[1]
+	yellow0 := view.Opponents()[model.Yellow].Pawns()[0]
+
+	square := func(square int) model.Position {
+		return model.NewPosition(false, false, nil, &square)
+	}
+
+	moves := []model.Move{
+		model.NewMove(card, []model.Action{model.NewAction(model.MoveToPosition, red0, square(17))}, nil),
+		model.NewMove(card, []model.Action{
+			model.NewAction(model.MoveToPosition, red0, square(13)),
+			model.NewAction(model.MoveToPosition, red1, square(34)),
+		}, []model.Action{model.NewAction(model.MoveToStart, yellow0, nil)}),
+	}
+
+	return view, moves
+}
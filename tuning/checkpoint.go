@@ -0,0 +1,97 @@
+package tuning
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pronovic/go-apologies/reward"
+)
+
+// Trial The result of evaluating one set of weights
+type Trial struct {
+	// Iteration The iteration that the weights were evaluated in
+	Iteration int `json:"iteration"`
+
+	// Weights The weights that were evaluated
+	Weights reward.Weights `json:"weights"`
+
+	// WinRate The fraction of games won against the baseline
+	WinRate float64 `json:"winrate"`
+
+	// Accepted Whether the weights improved on the best weights so far
+	Accepted bool `json:"accepted"`
+}
+
+// Checkpoint The progress of a tuning run, which is enough to resume it
+type Checkpoint struct {
+	// Best The best weights found so far
+	Best reward.Weights `json:"best"`
+
+	// WinRate The win rate for the best weights
+	WinRate float64 `json:"winrate"`
+
+	// Trials Every trial so far, in order
+	Trials []Trial `json:"trials"`
+}
+
+// NewCheckpointFromJSON constructs a new checkpoint from JSON in an io.Reader
+func NewCheckpointFromJSON(reader io.Reader) (*Checkpoint, error) {
+	var checkpoint Checkpoint
+	if err := json.NewDecoder(reader).Decode(&checkpoint); err != nil {
+		return nil, err
+	}
+
+	if checkpoint.Trials == nil {
+		checkpoint.Trials = make([]Trial, 0)
+	}
+
+	return &checkpoint, nil
+}
+
+// ReadCheckpoint reads a checkpoint from a file
+func ReadCheckpoint(path string) (*Checkpoint, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return NewCheckpointFromJSON(file)
+}
+
+// Write writes the checkpoint to a file, replacing it atomically so an interrupted write never loses progress
+func (c *Checkpoint) Write(path string) error {
+	return writeJSON(path, c)
+}
+
+// WriteWeights writes a set of weights to a file, in the JSON format read by reward.NewWeightsFromJSON
+func WriteWeights(path string, weights reward.Weights) error {
+	return writeJSON(path, weights)
+}
+
+// writeJSON writes a value to a temporary file as indented JSON, then renames it into place
+func writeJSON(path string, value any) error {
+	marshalled, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name()) // a no-op once the file has been renamed
+
+	if _, err = temp.Write(append(marshalled, '\n')); err != nil {
+		_ = temp.Close()
+		return err
+	}
+
+	if err = temp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), path)
+}
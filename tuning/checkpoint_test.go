@@ -0,0 +1,69 @@
+package tuning
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pronovic/go-apologies/reward"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointWriteAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	checkpoint := &Checkpoint{
+		Best:    reward.Weights{Distance: 2, Safe: 5},
+		WinRate: 0.75,
+		Trials: []Trial{
+			{Iteration: 0, Weights: *reward.DefaultWeights(), WinRate: 0.5, Accepted: true},
+			{Iteration: 1, Weights: reward.Weights{Distance: 2, Safe: 5}, WinRate: 0.75, Accepted: true},
+		},
+	}
+
+	assert.NoError(t, checkpoint.Write(path))
+	loaded, err := ReadCheckpoint(path)
+	assert.NoError(t, err)
+	assert.Equal(t, checkpoint, loaded)
+
+	// writing again replaces the file, without leaving any temporary files behind
+	checkpoint.WinRate = 0.8
+	assert.NoError(t, checkpoint.Write(path))
+	loaded, err = ReadCheckpoint(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.8, loaded.WinRate)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entries))
+}
+
+func TestReadCheckpointMissing(t *testing.T) {
+	_, err := ReadCheckpoint(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestNewCheckpointFromJSON(t *testing.T) {
+	checkpoint, err := NewCheckpointFromJSON(strings.NewReader(`{"best": {"distance": 1}, "winrate": 0.5}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Best: reward.Weights{Distance: 1}, WinRate: 0.5, Trials: []Trial{}}, checkpoint)
+
+	_, err = NewCheckpointFromJSON(strings.NewReader("bogus"))
+	assert.Error(t, err)
+}
+
+func TestWriteWeights(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weights.json")
+	weights := reward.Weights{Distance: 1.5, Exposed: -3}
+	assert.NoError(t, WriteWeights(path, weights))
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	loaded, err := reward.NewWeightsFromJSON(file)
+	assert.NoError(t, err)
+	assert.Equal(t, weights, *loaded)
+
+	assert.Error(t, WriteWeights(filepath.Join(t.TempDir(), "missing", "weights.json"), weights))
+}
@@ -0,0 +1,231 @@
+package tuning
+
+// The tuning package searches for reward weights automatically, replacing the hand-tuning described
+// in the reward package.  It uses simple hill-climbing: each iteration perturbs the best weights found
+// so far, plays a self-play tournament between a reward source using the candidate weights and a
+// baseline source, and keeps the candidate if it wins more often than the current best.  The candidate
+// weighs its hand as well as the board, so the Hand weight is tuned along with the others, although it
+// only makes a difference in adult mode.
+//
+// Every tournament is played with the same base seed, so candidates are compared on exactly the same
+// set of games, and the perturbation for each iteration is derived from the tuner's seed and the
+// iteration number.  As a result, a tuning run is reproducible, and a run that is resumed from a
+// checkpoint continues exactly as if it had never been interrupted.
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/reward"
+	"github.com/pronovic/go-apologies/simulation"
+	"github.com/pronovic/go-apologies/source"
+)
+
+// precision is the number of steps used to generate a uniform random value between -1 and 1
+const precision = 1000
+
+// Config Configuration for a tuning run, where zero values select the defaults.
+type Config struct {
+	// Mode The game mode to tune for (default StandardMode)
+	Mode model.GameMode
+
+	// Players The number of players in each game (default 2)
+	Players int
+
+	// Games The number of games in each tournament (default 100)
+	Games int
+
+	// Iterations The total number of iterations, including the evaluation of the initial weights (default 20)
+	Iterations int
+
+	// Step The size of each perturbation, relative to the size of the weight (default 0.25)
+	Step float32
+
+	// Seed The seed for the tournaments and for the perturbations
+	Seed int64
+
+	// Workers The number of workers used to play each tournament (default 1)
+	Workers int
+
+	// Initial The weights to start from (default reward.DefaultWeights())
+	Initial *reward.Weights
+
+	// Baseline The source that the tuned weights play against (default RewardInputSource with the original calculator)
+	Baseline *simulation.Source
+
+	// Checkpoint A path where progress is saved after every iteration, and resumed from if it already exists
+	Checkpoint string
+
+	// Output A path where the best weights are written whenever they improve
+	Output string
+}
+
+// Tune runs a tuning session, returning the final checkpoint with the best weights that were found
+func Tune(ctx context.Context, config Config) (*Checkpoint, error) {
+	c, err := defaults(config)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := &Checkpoint{Best: *c.Initial, Trials: make([]Trial, 0, c.Iterations)}
+	if c.Checkpoint != "" {
+		if loaded, err := ReadCheckpoint(c.Checkpoint); err == nil {
+			checkpoint = loaded
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	for iteration := len(checkpoint.Trials); iteration < c.Iterations; iteration++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		candidate := checkpoint.Best
+		if iteration > 0 {
+			candidate, err = perturb(checkpoint.Best, c.Step, random.NewSeededRandomizer(c.Seed+int64(iteration)))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		winRate, err := evaluate(ctx, c, candidate)
+		if err != nil {
+			return nil, err
+		}
+
+		improved := iteration == 0 || winRate > checkpoint.WinRate
+		checkpoint.Trials = append(checkpoint.Trials, Trial{Iteration: iteration, Weights: candidate, WinRate: winRate, Accepted: improved})
+
+		if improved {
+			checkpoint.Best = candidate
+			checkpoint.WinRate = winRate
+
+			if c.Output != "" {
+				if err := WriteWeights(c.Output, candidate); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if c.Checkpoint != "" {
+			if err := checkpoint.Write(c.Checkpoint); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return checkpoint, nil
+}
+
+// defaults validates a configuration and fills in default values
+func defaults(config Config) (Config, error) {
+	if config.Mode == (model.GameMode{}) {
+		config.Mode = model.StandardMode
+	}
+
+	if !model.GameModes.MemberOf(config.Mode.Value()) {
+		return config, errors.New("invalid game mode")
+	}
+
+	if config.Players == 0 {
+		config.Players = 2
+	}
+
+	if config.Players < model.MinPlayers || config.Players > model.MaxPlayers {
+		return config, errors.New("invalid number of players")
+	}
+
+	if config.Games < 1 {
+		config.Games = 100
+	}
+
+	if config.Iterations < 1 {
+		config.Iterations = 20
+	}
+
+	if config.Step <= 0 {
+		config.Step = 0.25
+	}
+
+	if config.Workers < 1 {
+		config.Workers = 1
+	}
+
+	if config.Initial == nil {
+		config.Initial = reward.DefaultWeights()
+	}
+
+	if config.Baseline == nil {
+		config.Baseline = &simulation.Source{Factory: func(_ random.Randomizer) source.CharacterInputSource {
+			return source.RewardInputSource(nil, nil)
+		}}
+	}
+
+	return config, nil
+}
+
+// evaluate plays a tournament between the candidate weights and the baseline, returning the candidate's win rate
+func evaluate(ctx context.Context, config Config, weights reward.Weights) (float64, error) {
+	baseline := *config.Baseline
+	baseline.Name = "baseline" // the baseline might use the same source as the candidate, so the names must differ
+
+	candidate := simulation.Source{
+		Name: "candidate",
+		Factory: func(_ random.Randomizer) source.CharacterInputSource {
+			return source.HandRewardInputSource(nil, reward.NewWeightedCalculator(&weights), reward.NewHandEvaluator(&weights))
+		},
+	}
+
+	scenario := simulation.Scenario{
+		Mode:    config.Mode,
+		Players: config.Players,
+		Games:   config.Games,
+		Seed:    config.Seed,
+		Sources: []simulation.Source{candidate, baseline},
+	}
+
+	results, err := simulation.RunParallel(ctx, scenario, config.Workers)
+	if err != nil {
+		return 0, err
+	}
+
+	return results.BySource()[0].WinRate, nil
+}
+
+// perturb returns a copy of the weights with every weight moved by a random amount, relative to its size
+func perturb(weights reward.Weights, step float32, randomizer random.Randomizer) (reward.Weights, error) {
+	result := weights
+
+	for _, weight := range []*float32{
+		&result.Distance,
+		&result.Safe,
+		&result.Winner,
+		&result.Bumped,
+		&result.SlideStart,
+		&result.Exposed,
+		&result.Start,
+		&result.Hand,
+	} {
+		value, err := randomizer.Int(2*precision + 1)
+		if err != nil {
+			return result, err
+		}
+
+		// weights that start at zero still need to be able to move, so the scale is never less than 1
+		scale := *weight
+		if scale < 0 {
+			scale = -scale
+		}
+		if scale < 1 {
+			scale = 1
+		}
+
+		*weight += step * scale * float32(value-precision) / precision
+	}
+
+	return result, nil
+}
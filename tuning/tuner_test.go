@@ -0,0 +1,140 @@
+package tuning
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pronovic/go-apologies/model"
+	"github.com/pronovic/go-apologies/random"
+	"github.com/pronovic/go-apologies/reward"
+	"github.com/pronovic/go-apologies/simulation"
+	"github.com/pronovic/go-apologies/source"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTuneInvalid(t *testing.T) {
+	_, err := Tune(context.Background(), Config{Players: 5})
+	assert.EqualError(t, err, "invalid number of players")
+}
+
+func TestTuneDefaults(t *testing.T) {
+	c, err := defaults(Config{})
+	assert.NoError(t, err)
+	assert.Equal(t, model.StandardMode, c.Mode)
+	assert.Equal(t, 2, c.Players)
+	assert.Equal(t, 100, c.Games)
+	assert.Equal(t, 20, c.Iterations)
+	assert.Equal(t, float32(0.25), c.Step)
+	assert.Equal(t, 1, c.Workers)
+	assert.Equal(t, reward.DefaultWeights(), c.Initial)
+	assert.NotNil(t, c.Baseline)
+}
+
+func TestTune(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "weights.json")
+	config := Config{Mode: model.StandardMode, Games: 6, Iterations: 4, Seed: 42, Workers: 2, Output: output, Baseline: randomBaseline()}
+
+	checkpoint, err := Tune(context.Background(), config)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(checkpoint.Trials))
+
+	// the initial weights are always evaluated first, and the best weights are the last ones accepted
+	assert.Equal(t, *reward.DefaultWeights(), checkpoint.Trials[0].Weights)
+	assert.True(t, checkpoint.Trials[0].Accepted)
+	var best Trial
+	for i, trial := range checkpoint.Trials {
+		assert.Equal(t, i, trial.Iteration)
+		assert.LessOrEqual(t, trial.WinRate, checkpoint.WinRate)
+		if trial.Accepted {
+			best = trial
+		}
+	}
+	assert.Equal(t, best.Weights, checkpoint.Best)
+	assert.Equal(t, best.WinRate, checkpoint.WinRate)
+
+	// the best weights are written in a form that a weighted calculator can load
+	file, err := os.Open(output)
+	assert.NoError(t, err)
+	defer file.Close()
+	weights, err := reward.NewWeightsFromJSON(file)
+	assert.NoError(t, err)
+	assert.Equal(t, checkpoint.Best, *weights)
+
+	// the same seed always produces the same results
+	again, err := Tune(context.Background(), Config{Mode: model.StandardMode, Games: 6, Iterations: 4, Seed: 42, Baseline: randomBaseline()})
+	assert.NoError(t, err)
+	assert.Equal(t, checkpoint, again)
+}
+
+func TestTuneDefaultMode(t *testing.T) {
+	// without a mode, the tournaments are played in standard mode
+	checkpoint, err := Tune(context.Background(), Config{Games: 2, Iterations: 1, Baseline: randomBaseline()})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(checkpoint.Trials))
+}
+
+func TestTuneResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	config := Config{Mode: model.AdultMode, Games: 4, Iterations: 2, Seed: 7, Checkpoint: path}
+
+	partial, err := Tune(context.Background(), config)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(partial.Trials))
+
+	saved, err := ReadCheckpoint(path)
+	assert.NoError(t, err)
+	assert.Equal(t, partial, saved)
+
+	// resuming picks up after the last completed iteration, with the same result as an uninterrupted run
+	config.Iterations = 4
+	resumed, err := Tune(context.Background(), config)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(resumed.Trials))
+	assert.Equal(t, partial.Trials, resumed.Trials[:2])
+
+	uninterrupted, err := Tune(context.Background(), Config{Mode: model.AdultMode, Games: 4, Iterations: 4, Seed: 7})
+	assert.NoError(t, err)
+	assert.Equal(t, uninterrupted, resumed)
+}
+
+func TestTuneCorruptCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	assert.NoError(t, os.WriteFile(path, []byte("bogus"), 0o600))
+
+	_, err := Tune(context.Background(), Config{Games: 1, Iterations: 1, Checkpoint: path})
+	assert.Error(t, err)
+}
+
+func TestTuneCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Tune(ctx, Config{Games: 1, Iterations: 1})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPerturb(t *testing.T) {
+	weights := reward.Weights{Distance: 1, Safe: 10, Winner: -100}
+	randomizer := random.NewSeededRandomizer(42)
+
+	for i := 0; i < 20; i++ {
+		result, err := perturb(weights, 0.5, randomizer)
+		assert.NoError(t, err)
+
+		// each weight moves by at most the step, relative to its size, and zero weights move as if they were 1
+		assert.InDelta(t, 1, result.Distance, 0.5)
+		assert.InDelta(t, 10, result.Safe, 5)
+		assert.InDelta(t, -100, result.Winner, 50)
+		assert.InDelta(t, 0, result.Exposed, 0.5)
+		assert.InDelta(t, 0, result.Hand, 0.5)
+		assert.NotEqual(t, weights, result)
+	}
+}
+
+func randomBaseline() *simulation.Source {
+	return &simulation.Source{Factory: func(randomizer random.Randomizer) source.CharacterInputSource {
+		return source.RandomInputSource(randomizer)
+	}}
+}